@@ -12,9 +12,23 @@ import (
 
 	"github.com/dim2k2006/correlateapp-be/cmd/api/middleware"
 	"github.com/dim2k2006/correlateapp-be/cmd/api/schemas"
+	"github.com/dim2k2006/correlateapp-be/pkg/clock"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/alert"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/analysis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/annotation"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/experiment"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/goal"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/habit"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/hypothesis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/idempotency"
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/reminder"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/getsentry/sentry-go"
 	sentryfiber "github.com/getsentry/sentry-go/fiber"
 	"github.com/go-playground/validator/v10"
@@ -62,6 +76,27 @@ func main() {
 		log.Fatal("COSMOS_DB_CONNECTION_STRING is empty")
 	}
 
+	// intervalFromEnv reads a positive duration such as "15m", falling back to
+	// the default when the variable is unset.
+	intervalFromEnv := func(name string, fallback time.Duration) time.Duration {
+		intervalString := os.Getenv(name)
+		if intervalString == "" {
+			return fallback
+		}
+
+		interval, err := time.ParseDuration(intervalString)
+		if err != nil || interval <= 0 {
+			log.Fatalf("%s is not a valid duration: %q", name, intervalString)
+		}
+
+		return interval
+	}
+
+	alertEvaluationInterval := intervalFromEnv("ALERT_EVALUATION_INTERVAL", 15*time.Minute)
+	reminderSchedulerInterval := intervalFromEnv("REMINDER_SCHEDULER_INTERVAL", time.Minute)
+	hypothesisEvaluationInterval := intervalFromEnv("HYPOTHESIS_EVALUATION_INTERVAL", time.Hour)
+	idempotencyKeyTTL := intervalFromEnv("IDEMPOTENCY_KEY_TTL", idempotency.DefaultTTL)
+
 	isProduction := appEnv == "production"
 
 	userRepository, userRepositoryErr := user.NewCosmosUserRepository(cosmosDBConnectionString)
@@ -80,8 +115,118 @@ func main() {
 	if measurementRepositoryErr != nil {
 		log.Fatalf("failed to create measurement repository: %v", measurementRepositoryErr)
 	}
+	// Range filters and sorted listings read the epoch fields, so older
+	// measurements get them before the server takes requests.
+	backfilled, backfillErr := measurementRepository.BackfillEpochFields(context.Background())
+	if backfillErr != nil {
+		log.Fatalf("failed to backfill measurement epoch fields: %v", backfillErr)
+	}
+	if backfilled > 0 {
+		log.Printf("backfilled epoch fields of %d measurements", backfilled)
+	}
 	measurementService := measurement.NewService(measurementRepository, parameterService)
 
+	outlierRepository, outlierRepositoryErr := outlier.NewCosmosFlagRepository(cosmosDBConnectionString)
+	if outlierRepositoryErr != nil {
+		log.Fatalf("failed to create outlier flag repository: %v", outlierRepositoryErr)
+	}
+	outlierService := outlier.NewService(outlierRepository, parameterService, measurementService)
+
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+
+	habitService := habit.NewService(userService, parameterService, measurementService)
+
+	experimentRepository, experimentRepositoryErr := experiment.NewCosmosExperimentRepository(cosmosDBConnectionString)
+	if experimentRepositoryErr != nil {
+		log.Fatalf("failed to create experiment repository: %v", experimentRepositoryErr)
+	}
+	experimentService := experiment.NewService(experimentRepository, parameterService, seriesService)
+
+	goalRepository, goalRepositoryErr := goal.NewCosmosGoalRepository(cosmosDBConnectionString)
+	if goalRepositoryErr != nil {
+		log.Fatalf("failed to create goal repository: %v", goalRepositoryErr)
+	}
+	goalService := goal.NewService(goalRepository, userService, parameterService, seriesService)
+
+	annotationRepository, annotationRepositoryErr := annotation.NewCosmosAnnotationRepository(cosmosDBConnectionString)
+	if annotationRepositoryErr != nil {
+		log.Fatalf("failed to create annotation repository: %v", annotationRepositoryErr)
+	}
+	annotationService := annotation.NewService(annotationRepository, userService, parameterService)
+
+	hypothesisRepository, hypothesisRepositoryErr := hypothesis.NewCosmosHypothesisRepository(cosmosDBConnectionString)
+	if hypothesisRepositoryErr != nil {
+		log.Fatalf("failed to create hypothesis repository: %v", hypothesisRepositoryErr)
+	}
+	hypothesisService := hypothesis.NewService(hypothesisRepository, userService, parameterService, seriesService)
+
+	alertRepository, alertRepositoryErr := alert.NewCosmosAlertRepository(cosmosDBConnectionString)
+	if alertRepositoryErr != nil {
+		log.Fatalf("failed to create alert repository: %v", alertRepositoryErr)
+	}
+	alertService := alert.NewService(alertRepository, parameterService, measurementService)
+
+	reminderRepository, reminderRepositoryErr := reminder.NewCosmosReminderRepository(cosmosDBConnectionString)
+	if reminderRepositoryErr != nil {
+		log.Fatalf("failed to create reminder repository: %v", reminderRepositoryErr)
+	}
+	reminderService := reminder.NewService(
+		reminderRepository, userService, parameterService, measurementService, clock.SystemClock{},
+	)
+
+	idempotencyRepository, idempotencyRepositoryErr := idempotency.NewCosmosIdempotencyRepository(
+		cosmosDBConnectionString,
+	)
+	if idempotencyRepositoryErr != nil {
+		log.Fatalf("failed to create idempotency repository: %v", idempotencyRepositoryErr)
+	}
+	idempotencyService := idempotency.NewService(idempotencyRepository, idempotencyKeyTTL, clock.SystemClock{})
+
+	// Measurements created through the API are checked against alert rules,
+	// and measurements deleted through it take their outlier flags with them.
+	// The services above read measurements and keep the undecorated service.
+	//
+	// The wrappers below follow up on a change once it is stored. Their work
+	// is best effort: a failure is logged rather than returned, because the
+	// change itself cannot be taken back. The scheduled jobs skip rules and
+	// schedules whose parameter or user is gone and catch up on evaluations.
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	measurementService = outlier.NewMeasurementService(measurementService, outlierService)
+
+	// Users and parameters deleted through the API take their alert rules and
+	// reminder schedules with them.
+	parameterService = alert.NewParameterService(parameterService, alertService)
+	parameterService = reminder.NewParameterService(parameterService, reminderService)
+	userService = reminder.NewUserService(userService, reminderService)
+
+	// ifMatchHeader returns the ETag a PUT or DELETE is conditional on. "*"
+	// matches any stored version, so it adds no condition.
+	ifMatchHeader := func(c *fiber.Ctx) string {
+		ifMatch := c.Get(fiber.HeaderIfMatch)
+		if ifMatch == "*" {
+			return ""
+		}
+
+		return ifMatch
+	}
+
+	// displayUnitsFor resolves the units a user's measurements are shown in.
+	displayUnitsFor := func(ctx context.Context, userID uuid.UUID) (schemas.DisplayUnits, error) {
+		owner, err := userService.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		ownerParameters, err := parameterService.ListParametersByUser(ctx, userID, parameter.ListOptions{}, pagination.Page{})
+		if err != nil {
+			return nil, err
+		}
+
+		return schemas.NewDisplayUnits(owner, ownerParameters.Items), nil
+	}
+
 	if isProduction {
 		if err := sentry.Init(sentry.ClientOptions{
 			Dsn:              sentryDsn,
@@ -100,7 +245,11 @@ func main() {
 
 	app.Use(sentryHandler)
 
-	app.Use(cors.New())
+	// Browsers only let clients read the ETag needed for If-Match when it is
+	// exposed.
+	app.Use(cors.New(cors.Config{
+		ExposeHeaders: fiber.HeaderETag,
+	}))
 
 	app.All("/error", func(_ *fiber.Ctx) error {
 		panic("y tho")
@@ -118,9 +267,17 @@ func main() {
 		api.Use(middleware.VerifySignatureMiddleware(secretKeys))
 	}
 
+	// Version 2 of the API returns listings in pages of {items, next}. The
+	// listings under /api keep returning bare arrays of every item.
+	apiV2 := api.Group("/v2")
+
 	users := api.Group("/users")
 
-	users.Post("/", func(c *fiber.Ctx) error {
+	// Creating POST routes accept an Idempotency-Key header, so that clients
+	// retrying on flaky networks do not create duplicates.
+	idempotent := middleware.IdempotencyMiddleware(idempotencyService)
+
+	users.Post("/", idempotent, func(c *fiber.Ctx) error {
 		var req schemas.CreateUserRequest
 
 		if err := c.BodyParser(&req); err != nil {
@@ -139,19 +296,30 @@ func main() {
 		}
 
 		input := user.CreateUserInput{
-			ExternalID: req.ExternalID,
-			FirstName:  req.FirstName,
-			LastName:   req.LastName,
+			ExternalID:     req.ExternalID,
+			FirstName:      req.FirstName,
+			LastName:       req.LastName,
+			Timezone:       req.Timezone,
+			DayStartHour:   req.DayStartHour,
+			PreferredUnits: req.PreferredUnits,
 		}
 
 		ctx := context.Background()
 		createdUser, err := userService.CreateUser(ctx, input)
 		if err != nil {
+			if errors.Is(err, user.ErrInvalidTimezone) ||
+				errors.Is(err, user.ErrInvalidDayStartHour) ||
+				errors.Is(err, user.ErrInvalidPreferredUnit) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
 
+		c.Set(fiber.HeaderETag, createdUser.ETag)
 		return c.Status(fiber.StatusCreated).JSON(schemas.NewUserResponse(createdUser))
 	})
 
@@ -172,6 +340,7 @@ func main() {
 			})
 		}
 
+		c.Set(fiber.HeaderETag, userData.ETag)
 		return c.JSON(schemas.NewUserResponse(userData))
 	})
 
@@ -184,6 +353,7 @@ func main() {
 				"error": err.Error(),
 			})
 		}
+		c.Set(fiber.HeaderETag, userData.ETag)
 		return c.JSON(schemas.NewUserResponse(userData))
 	})
 
@@ -213,19 +383,41 @@ func main() {
 		}
 
 		input := user.UpdateUserInput{
-			ID:        id,
-			FirstName: req.FirstName,
-			LastName:  req.LastName,
+			ID:             id,
+			FirstName:      req.FirstName,
+			LastName:       req.LastName,
+			Timezone:       req.Timezone,
+			DayStartHour:   req.DayStartHour,
+			PreferredUnits: req.PreferredUnits,
+			IfMatch:        ifMatchHeader(c),
 		}
 
 		ctx := context.Background()
 		updatedUser, updateUserErr := userService.UpdateUser(ctx, input)
 		if updateUserErr != nil {
+			if errors.Is(updateUserErr, user.ErrVersionConflict) {
+				return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+					"error": updateUserErr.Error(),
+				})
+			}
+			if errors.Is(updateUserErr, user.ErrUserNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": updateUserErr.Error(),
+				})
+			}
+			if errors.Is(updateUserErr, user.ErrInvalidTimezone) ||
+				errors.Is(updateUserErr, user.ErrInvalidDayStartHour) ||
+				errors.Is(updateUserErr, user.ErrInvalidPreferredUnit) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": updateUserErr.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": updateUserErr.Error(),
 			})
 		}
 
+		c.Set(fiber.HeaderETag, updatedUser.ETag)
 		return c.JSON(schemas.NewUserResponse(updatedUser))
 	})
 
@@ -239,7 +431,17 @@ func main() {
 		}
 
 		ctx := context.Background()
-		if err := userService.DeleteUser(ctx, id); err != nil {
+		if err := userService.DeleteUser(ctx, id, ifMatchHeader(c)); err != nil {
+			if errors.Is(err, user.ErrVersionConflict) {
+				return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, user.ErrUserNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -250,8 +452,9 @@ func main() {
 	})
 
 	parameters := api.Group("/parameters")
+	parametersV2 := apiV2.Group("/parameters")
 
-	parameters.Post("/", func(c *fiber.Ctx) error {
+	parameters.Post("/", idempotent, func(c *fiber.Ctx) error {
 		var req schemas.CreateParameterRequest
 
 		if err := c.BodyParser(&req); err != nil {
@@ -275,16 +478,25 @@ func main() {
 			Description: req.Description,
 			DataType:    req.DataType,
 			Unit:        req.Unit,
+			Formula:     req.Formula,
+			Schedule:    schemas.ParseSchedule(req.Schedule),
 		}
 
 		ctx := context.Background()
 		createdParameter, err := parameterService.CreateParameter(ctx, input)
 		if err != nil {
+			if errors.Is(err, parameter.ErrInvalidFormula) || errors.Is(err, parameter.ErrFormulaCycle) ||
+				errors.Is(err, parameter.ErrUnknownUnit) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
 
+		c.Set(fiber.HeaderETag, createdParameter.ETag)
 		return c.Status(fiber.StatusCreated).JSON(schemas.NewParameterResponse(createdParameter))
 	})
 
@@ -305,34 +517,86 @@ func main() {
 			})
 		}
 
+		c.Set(fiber.HeaderETag, parameterData.ETag)
 		return c.JSON(schemas.NewParameterResponse(parameterData))
 	})
 
-	parameters.Get("/user/:userId", func(c *fiber.Ctx) error {
-		userIDStr := c.Params("userId")
-		userID, err := uuid.Parse(userIDStr)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid user ID",
-			})
-		}
-
-		ctx := context.Background()
-		parametersData, err := parameterService.ListParametersByUser(ctx, userID)
-		if err != nil {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		}
-
-		// initialize response with empty array
-		response := []schemas.ParameterResponse{}
-		for _, p := range parametersData {
-			response = append(response, schemas.NewParameterResponse(p))
+	// listParametersByUser returns every parameter of the user as a bare array
+	// or, when paged, one page of them.
+	listParametersByUser := func(paged bool) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			userIDStr := c.Params("userId")
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid user ID",
+				})
+			}
+
+			var query schemas.ParameterListQuery
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid input: " + err.Error(),
+				})
+			}
+
+			if err := query.Validate(); err != nil {
+				var validationErrors validator.ValidationErrors
+				errors.As(err, &validationErrors)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Validation failed",
+					"details": validationErrors.Error(),
+				})
+			}
+
+			page := pagination.Page{}
+			if paged {
+				page = query.Page()
+			}
+
+			ctx := context.Background()
+			parametersPage, err := parameterService.ListParametersByUser(ctx, userID, query.Options(), page)
+			if err != nil {
+				if errors.Is(err, pagination.ErrInvalidCursor) ||
+					errors.Is(err, parameter.ErrInvalidSort) ||
+					errors.Is(err, parameter.ErrInvalidField) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			// initialize response with empty array
+			response := []schemas.ParameterResponse{}
+			for _, p := range parametersPage.Items {
+				response = append(response, schemas.NewParameterResponse(p))
+			}
+
+			if fields := query.FieldList(); len(fields) > 0 {
+				projected, projectErr := schemas.Project(response, fields)
+				if projectErr != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": projectErr.Error(),
+					})
+				}
+				if !paged {
+					return c.JSON(projected)
+				}
+				return c.JSON(schemas.NewPageResponse(projected, parametersPage.Next))
+			}
+
+			if !paged {
+				return c.JSON(response)
+			}
+			return c.JSON(schemas.NewPageResponse(response, parametersPage.Next))
 		}
+	}
 
-		return c.JSON(response)
-	})
+	parameters.Get("/user/:userId", listParametersByUser(false))
+	parametersV2.Get("/user/:userId", listParametersByUser(true))
 
 	parameters.Put("/:id", func(c *fiber.Ctx) error {
 		idStr := c.Params("id")
@@ -364,16 +628,42 @@ func main() {
 			Name:        req.Name,
 			Description: req.Description,
 			Unit:        req.Unit,
+			Formula:     req.Formula,
+			Schedule:    req.ScheduleWeekdays(),
+			IfMatch:     ifMatchHeader(c),
 		}
 
 		ctx := context.Background()
 		updatedParameter, updateParameterErr := parameterService.UpdateParameter(ctx, input)
 		if updateParameterErr != nil {
+			if errors.Is(updateParameterErr, parameter.ErrVersionConflict) {
+				return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+					"error": updateParameterErr.Error(),
+				})
+			}
+			if errors.Is(updateParameterErr, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": updateParameterErr.Error(),
+				})
+			}
+			if errors.Is(updateParameterErr, parameter.ErrInvalidFormula) ||
+				errors.Is(updateParameterErr, parameter.ErrFormulaCycle) ||
+				errors.Is(updateParameterErr, parameter.ErrUnknownUnit) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": updateParameterErr.Error(),
+				})
+			}
+			if errors.Is(updateParameterErr, parameter.ErrUnitChange) {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": updateParameterErr.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": updateParameterErr.Error(),
 			})
 		}
 
+		c.Set(fiber.HeaderETag, updatedParameter.ETag)
 		return c.JSON(schemas.NewParameterResponse(updatedParameter))
 	})
 
@@ -387,7 +677,22 @@ func main() {
 		}
 
 		ctx := context.Background()
-		if err := parameterService.DeleteParameter(ctx, id); err != nil {
+		if err := parameterService.DeleteParameter(ctx, id, ifMatchHeader(c)); err != nil {
+			if errors.Is(err, parameter.ErrVersionConflict) {
+				return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterReferenced) {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": err.Error(),
 			})
@@ -397,18 +702,23 @@ func main() {
 		return c.SendStatus(fiber.StatusNoContent)
 	})
 
-	measurements := api.Group("/measurements")
-
-	measurements.Post("/", func(c *fiber.Ctx) error {
-		var req schemas.CreateMeasurementRequest
+	parameters.Get("/:id/series", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
 
-		if err := c.BodyParser(&req); err != nil {
+		var query schemas.ParameterSeriesQuery
+		if err := c.QueryParser(&query); err != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "Invalid input: " + err.Error(),
 			})
 		}
 
-		if err := req.Validate(); err != nil {
+		if err := query.Validate(); err != nil {
 			var validationErrors validator.ValidationErrors
 			errors.As(err, &validationErrors)
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -417,104 +727,2187 @@ func main() {
 			})
 		}
 
-		input := measurement.CreateMeasurementInput{
-			ParameterID: req.ParameterID,
-			Notes:       req.Notes,
-			Value:       req.Value,
-			Timestamp:   req.Timestamp,
+		input := series.GetSmoothedSeriesInput{
+			GetDailySeriesInput: query.DailySeriesInput(id),
+			Smoothing: series.SmoothingOptions{
+				Window: query.Window,
+				Alpha:  query.Alpha,
+				Span:   query.Span,
+			},
 		}
 
 		ctx := context.Background()
-		createdMeasurement, err := measurementService.CreateMeasurement(ctx, input)
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         input.From,
+			To:           input.To,
+		})
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if query.ExcludeAnnotated {
+			input.Exclude = annotation.Periods(annotations)
+		}
+
+		smoothedSeries, err := seriesService.GetSmoothedSeries(ctx, input)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
 
-		return c.Status(fiber.StatusCreated).JSON(schemas.NewMeasurementResponse(createdMeasurement))
+		return c.JSON(schemas.NewSmoothedSeriesResponse(smoothedSeries, annotations))
 	})
 
-	measurements.Get("/user/:userId", func(c *fiber.Ctx) error {
-		userIDStr := c.Params("userId")
-		userID, err := uuid.Parse(userIDStr)
+	parameters.Get("/:id/statistics", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var query schemas.ParameterStatisticsQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := analysis.GetParameterStatisticsInput{
+			ParameterID: id,
+			Range:       query.TimeRange(),
+			Location:    query.Location(),
+		}
+
+		ctx := context.Background()
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         input.Range.From,
+			To:           input.Range.To,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if query.ExcludeAnnotated {
+			input.Exclude = annotation.Periods(annotations)
+		}
+
+		statistics, err := analysisService.GetParameterStatistics(ctx, input)
 		if err != nil {
+			if errors.Is(err, analysis.ErrInvalidTimeRange) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewParameterStatisticsResponse(statistics, annotations))
+	})
+
+	parameters.Get("/:id/trend", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid user ID",
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var query schemas.TrendQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
 			})
 		}
 
+		input := analysis.GetTrendInput{
+			Series:           query.DailySeriesInput(id),
+			Range:            query.TimeRange(),
+			Alpha:            query.Alpha,
+			Penalty:          query.Penalty,
+			MinSegmentLength: query.MinSegment,
+		}
+
 		ctx := context.Background()
-		measurementsData, err := measurementService.ListMeasurementsByUser(ctx, userID)
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         input.Range.From,
+			To:           input.Range.To,
+		})
 		if err != nil {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
+		if query.ExcludeAnnotated {
+			input.Series.Exclude = annotation.Periods(annotations)
+		}
 
-		response := []schemas.MeasurementResponse{}
-		for _, measurementItem := range measurementsData {
-			response = append(response, schemas.NewMeasurementResponse(measurementItem))
+		trend, err := analysisService.GetTrend(ctx, input)
+		if err != nil {
+			switch {
+			case errors.Is(err, analysis.ErrInvalidTimeRange):
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			case errors.Is(err, analysis.ErrNotEnoughData):
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			default:
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 		}
 
-		return c.JSON(response)
+		return c.JSON(schemas.NewTrendResponse(trend, annotations))
 	})
 
-	measurements.Get("/parameter/:parameterId", func(c *fiber.Ctx) error {
-		parameterIDStr := c.Params("parameterId")
-		parameterID, err := uuid.Parse(parameterIDStr)
+	parameters.Get("/:id/profile", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var query schemas.ProfileQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := analysis.GetProfileInput{
+			Series: query.DailySeriesInput(id),
+			Range:  query.TimeRange(),
+			Alpha:  query.Alpha,
+		}
+
+		ctx := context.Background()
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         input.Range.From,
+			To:           input.Range.To,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if query.ExcludeAnnotated {
+			input.Series.Exclude = annotation.Periods(annotations)
+		}
+
+		profile, err := analysisService.GetProfile(ctx, input)
 		if err != nil {
+			if errors.Is(err, analysis.ErrInvalidTimeRange) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewProfileResponse(profile, annotations))
+	})
+
+	parameters.Get("/:id/forecast", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"error": "Invalid parameter ID",
 			})
 		}
 
+		var query schemas.ForecastQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := analysis.GetForecastInput{
+			Series:  query.DailySeriesInput(id),
+			Range:   query.TimeRange(),
+			Method:  query.Method,
+			Order:   analysis.ARIMAOrder{P: query.P, D: query.D, Q: query.Q},
+			Horizon: query.Horizon,
+			Level:   query.Level,
+		}
+
 		ctx := context.Background()
-		measurementsData, err := measurementService.ListMeasurementsByParameter(ctx, parameterID)
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         input.Range.From,
+			To:           input.Range.To,
+		})
 		if err != nil {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
+		if query.ExcludeAnnotated {
+			input.Series.Exclude = annotation.Periods(annotations)
+		}
 
-		response := []schemas.MeasurementResponse{}
-		for _, measurementItem := range measurementsData {
-			response = append(response, schemas.NewMeasurementResponse(measurementItem))
+		forecast, err := analysisService.GetForecast(ctx, input)
+		if err != nil {
+			switch {
+			case errors.Is(err, analysis.ErrInvalidTimeRange):
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			case errors.Is(err, analysis.ErrNotEnoughData):
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			default:
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
 		}
 
-		return c.JSON(response)
+		return c.JSON(schemas.NewForecastResponse(forecast, annotations))
 	})
 
-	measurements.Delete("/:id", func(c *fiber.Ctx) error {
+	parameters.Get("/:id/event-study", func(c *fiber.Ctx) error {
 		idStr := c.Params("id")
 		id, uuidParseErr := uuid.Parse(idStr)
 		if uuidParseErr != nil {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Invalid measurement ID",
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var query schemas.EventStudyQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
 			})
 		}
 
 		ctx := context.Background()
-		if err := measurementService.DeleteMeasurement(ctx, id); err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		eventDate := query.EventDate()
+		var eventAnnotationID uuid.UUID
+		if query.AnnotationID != "" {
+			eventAnnotation, err := annotationService.GetAnnotationByID(ctx, uuid.MustParse(query.AnnotationID))
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if !eventAnnotation.AppliesTo(id) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Annotation does not apply to the parameter",
+				})
+			}
+			eventDate = eventAnnotation.StartDate
+			eventAnnotationID = eventAnnotation.ID
+		}
+
+		from, to := query.Window(eventDate)
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{id},
+			From:         &from,
+			To:           &to,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": err.Error(),
 			})
 		}
 
-		// Return no content status upon successful deletion.
-		return c.SendStatus(fiber.StatusNoContent)
+		input := analysis.GetEventStudyInput{
+			Series:       query.DailySeriesInput(id),
+			EventDate:    eventDate,
+			DaysBefore:   query.Before,
+			DaysAfter:    query.After,
+			Alpha:        query.Alpha,
+			Permutations: query.Permutations,
+			Seed:         query.Seed,
+		}
+		if query.ExcludeAnnotated {
+			for _, a := range annotations {
+				if a.ID != eventAnnotationID {
+					input.Series.Exclude = append(input.Series.Exclude, a.Period())
+				}
+			}
+		}
+
+		eventStudy, err := analysisService.GetEventStudy(ctx, input)
+		if err != nil {
+			switch {
+			case errors.Is(err, analysis.ErrInvalidWindow) ||
+				errors.Is(err, analysis.ErrInvalidAlpha) ||
+				errors.Is(err, analysis.ErrInvalidPermutations):
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			case errors.Is(err, analysis.ErrNotEnoughData):
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			default:
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+		}
+
+		return c.JSON(schemas.NewEventStudyResponse(eventStudy, annotations))
 	})
 
-	// -------------------------
-	// Start the server in a goroutine
-	// -------------------------
-	log.Println("Starting server on port", port)
-	go func() {
-		if err := app.Listen(":" + port); err != nil {
-			// If Listen fails, we log and send a signal, or exit
-			log.Fatalf("could not start server: %v", err)
+	parameters.Get("/:id/streaks", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
 		}
-	}()
+
+		ctx := context.Background()
+		streaks, err := habitService.GetStreaks(ctx, habit.GetStreaksInput{ParameterID: id})
+		if err != nil {
+			if errors.Is(err, habit.ErrNotBooleanParameter) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewStreaksResponse(streaks))
+	})
+
+	parameters.Post("/:id/outliers/detect", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var req schemas.DetectOutliersRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := outlier.DetectOutliersInput{
+			ParameterID: id,
+			Method:      req.Method,
+			Threshold:   req.Threshold,
+		}
+
+		ctx := context.Background()
+		flags, err := outlierService.DetectOutliers(ctx, input)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.OutlierFlagResponse{}
+		for _, f := range flags {
+			response = append(response, schemas.NewOutlierFlagResponse(f))
+		}
+
+		return c.JSON(response)
+	})
+
+	parameters.Get("/:id/outliers", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		ctx := context.Background()
+		flags, err := outlierService.ListFlagsByParameter(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.OutlierFlagResponse{}
+		for _, f := range flags {
+			response = append(response, schemas.NewOutlierFlagResponse(f))
+		}
+
+		return c.JSON(response)
+	})
+
+	outliers := api.Group("/outliers")
+
+	outliers.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		flags, err := outlierService.ListFlagsByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.OutlierFlagResponse{}
+		for _, f := range flags {
+			response = append(response, schemas.NewOutlierFlagResponse(f))
+		}
+
+		return c.JSON(response)
+	})
+
+	outliers.Put("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid outlier flag ID",
+			})
+		}
+
+		var req schemas.ReviewOutlierRequest
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := outlier.ReviewFlagInput{
+			ID:     id,
+			Status: req.Status,
+		}
+
+		ctx := context.Background()
+		reviewedFlag, reviewFlagErr := outlierService.ReviewFlag(ctx, input)
+		if reviewFlagErr != nil {
+			if errors.Is(reviewFlagErr, outlier.ErrFlagNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": reviewFlagErr.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": reviewFlagErr.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewOutlierFlagResponse(reviewedFlag))
+	})
+
+	measurements := api.Group("/measurements")
+	measurementsV2 := apiV2.Group("/measurements")
+
+	measurements.Post("/", idempotent, func(c *fiber.Ctx) error {
+		var req schemas.CreateMeasurementRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := measurement.CreateMeasurementInput{
+			ParameterID: req.ParameterID,
+			Notes:       req.Notes,
+			Value:       req.Value,
+			Unit:        req.Unit,
+			Timestamp:   req.Timestamp,
+		}
+
+		ctx := context.Background()
+		createdMeasurement, err := measurementService.CreateMeasurement(ctx, input)
+		if err != nil {
+			if errors.Is(err, measurement.ErrDerivedParameter) || errors.Is(err, measurement.ErrIncompatibleUnit) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		display, err := displayUnitsFor(ctx, createdMeasurement.GetUserID())
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(display.Apply(schemas.NewMeasurementResponse(createdMeasurement)))
+	})
+
+	// The batch endpoint takes many measurements, across parameters, in one
+	// signed request. Items succeed or fail on their own and the response
+	// reports each of them, with 207 when any failed.
+	measurements.Post("/batch", func(c *fiber.Ctx) error {
+		var req schemas.CreateMeasurementBatchRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		items := make([]schemas.MeasurementBatchItemResponse, len(req.Measurements))
+		inputs := make([]measurement.CreateMeasurementInput, 0, len(req.Measurements))
+		indexes := make([]int, 0, len(req.Measurements))
+		for i := range req.Measurements {
+			item := &req.Measurements[i]
+			items[i].Index = i
+			if err := item.Validate(); err != nil {
+				var validationErrors validator.ValidationErrors
+				errors.As(err, &validationErrors)
+				items[i].Status = fiber.StatusBadRequest
+				items[i].Error = "Validation failed"
+				items[i].Details = validationErrors.Error()
+				continue
+			}
+
+			inputs = append(inputs, measurement.CreateMeasurementInput{
+				ParameterID: item.ParameterID,
+				Notes:       item.Notes,
+				Value:       item.Value,
+				Unit:        item.Unit,
+				Timestamp:   item.Timestamp,
+			})
+			indexes = append(indexes, i)
+		}
+
+		ctx := context.Background()
+		if len(inputs) > 0 {
+			results, err := measurementService.CreateMeasurements(ctx, inputs)
+			if err != nil {
+				if errors.Is(err, measurement.ErrInvalidBatchSize) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			displayByUser := make(map[uuid.UUID]schemas.DisplayUnits)
+			for j, result := range results {
+				item := &items[indexes[j]]
+				if result.Err != nil {
+					switch {
+					case errors.Is(result.Err, parameter.ErrParameterNotFound):
+						item.Status = fiber.StatusNotFound
+					case errors.Is(result.Err, measurement.ErrDerivedParameter),
+						errors.Is(result.Err, measurement.ErrIncompatibleUnit),
+						errors.Is(result.Err, measurement.ErrInvalidValueType):
+						item.Status = fiber.StatusBadRequest
+					default:
+						item.Status = fiber.StatusInternalServerError
+					}
+					item.Error = result.Err.Error()
+					continue
+				}
+
+				userID := result.Measurement.GetUserID()
+				display, ok := displayByUser[userID]
+				if !ok {
+					// The measurements are already created, so an item whose
+					// display units cannot be resolved is shown in its stored unit.
+					display, err = displayUnitsFor(ctx, userID)
+					if err != nil {
+						log.Printf("resolving display units of user %s failed: %v", userID, err)
+					}
+					displayByUser[userID] = display
+				}
+
+				created := display.Apply(schemas.NewMeasurementResponse(result.Measurement))
+				item.Status = fiber.StatusCreated
+				item.Measurement = &created
+			}
+		}
+
+		response := schemas.NewMeasurementBatchResponse(items)
+		status := fiber.StatusCreated
+		if response.Failed > 0 {
+			status = fiber.StatusMultiStatus
+		}
+
+		return c.Status(status).JSON(response)
+	})
+
+	// listMeasurementsByUser returns every measurement of the user as a bare
+	// array or, when paged, one page of them.
+	listMeasurementsByUser := func(paged bool) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			userIDStr := c.Params("userId")
+			userID, err := uuid.Parse(userIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid user ID",
+				})
+			}
+
+			var query schemas.MeasurementListQuery
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid input: " + err.Error(),
+				})
+			}
+
+			if err := query.Validate(); err != nil {
+				var validationErrors validator.ValidationErrors
+				errors.As(err, &validationErrors)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Validation failed",
+					"details": validationErrors.Error(),
+				})
+			}
+
+			options, err := query.Options()
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			page := pagination.Page{}
+			if paged {
+				page = query.Page()
+			}
+
+			ctx := context.Background()
+			display, err := displayUnitsFor(ctx, userID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			options.Predicate, err = display.StoredPredicate(options.Predicate)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			measurementsPage, err := measurementService.ListMeasurementsByUser(ctx, userID, options, page)
+			if err != nil {
+				if errors.Is(err, measurement.ErrInvalidTimeRange) ||
+					errors.Is(err, measurement.ErrInvalidSort) ||
+					errors.Is(err, measurement.ErrInvalidField) ||
+					errors.Is(err, pagination.ErrInvalidCursor) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			flags, err := outlierService.ListFlagsByUser(ctx, userID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			response := schemas.NewMeasurementListResponse(measurementsPage.Items, flags, display)
+			if fields := query.FieldList(); len(fields) > 0 {
+				projected, projectErr := schemas.Project(response, fields)
+				if projectErr != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": projectErr.Error(),
+					})
+				}
+				if !paged {
+					return c.JSON(projected)
+				}
+				return c.JSON(schemas.NewPageResponse(projected, measurementsPage.Next))
+			}
+
+			if !paged {
+				return c.JSON(response)
+			}
+			return c.JSON(schemas.NewPageResponse(response, measurementsPage.Next))
+		}
+	}
+
+	measurements.Get("/user/:userId", listMeasurementsByUser(false))
+	measurementsV2.Get("/user/:userId", listMeasurementsByUser(true))
+
+	// listMeasurementsByParameter returns every measurement of the parameter
+	// as a bare array or, when paged, one page of them.
+	listMeasurementsByParameter := func(paged bool) fiber.Handler {
+		return func(c *fiber.Ctx) error {
+			parameterIDStr := c.Params("parameterId")
+			parameterID, err := uuid.Parse(parameterIDStr)
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid parameter ID",
+				})
+			}
+
+			var query schemas.MeasurementListQuery
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": "Invalid input: " + err.Error(),
+				})
+			}
+
+			if err := query.Validate(); err != nil {
+				var validationErrors validator.ValidationErrors
+				errors.As(err, &validationErrors)
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Validation failed",
+					"details": validationErrors.Error(),
+				})
+			}
+
+			options, err := query.Options()
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			page := pagination.Page{}
+			if paged {
+				page = query.Page()
+			}
+
+			ctx := context.Background()
+			measurementParameter, err := parameterService.GetParameterByID(ctx, parameterID)
+			if err != nil {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			display, err := displayUnitsFor(ctx, measurementParameter.UserID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			options.Predicate, err = display.StoredPredicate(options.Predicate)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			measurementsPage, err := measurementService.ListMeasurementsByParameter(ctx, parameterID, options, page)
+			if err != nil {
+				if errors.Is(err, measurement.ErrInvalidTimeRange) ||
+					errors.Is(err, measurement.ErrInvalidSort) ||
+					errors.Is(err, measurement.ErrInvalidField) ||
+					errors.Is(err, pagination.ErrInvalidCursor) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error": err.Error(),
+					})
+				}
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			flags, err := outlierService.ListFlagsByParameter(ctx, parameterID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+
+			response := schemas.NewMeasurementListResponse(measurementsPage.Items, flags, display)
+			if fields := query.FieldList(); len(fields) > 0 {
+				projected, projectErr := schemas.Project(response, fields)
+				if projectErr != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": projectErr.Error(),
+					})
+				}
+				if !paged {
+					return c.JSON(projected)
+				}
+				return c.JSON(schemas.NewPageResponse(projected, measurementsPage.Next))
+			}
+
+			if !paged {
+				return c.JSON(response)
+			}
+			return c.JSON(schemas.NewPageResponse(response, measurementsPage.Next))
+		}
+	}
+
+	measurements.Get("/parameter/:parameterId", listMeasurementsByParameter(false))
+	measurementsV2.Get("/parameter/:parameterId", listMeasurementsByParameter(true))
+
+	// Deletes a parameter's measurements, optionally within a time range.
+	// With dryRun=true only the count is returned. A failed delete can be
+	// retried with the same query to remove what is left.
+	measurements.Delete("/parameter/:parameterId", func(c *fiber.Ctx) error {
+		parameterIDStr := c.Params("parameterId")
+		parameterID, err := uuid.Parse(parameterIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		var query schemas.MeasurementDeleteQuery
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		ctx := context.Background()
+		count, err := measurementService.DeleteMeasurementsByParameter(ctx, query.Input(parameterID))
+		if err != nil {
+			if errors.Is(err, measurement.ErrInvalidTimeRange) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			// A delete that stops part way still reports what it deleted.
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+				"count": count,
+			})
+		}
+
+		return c.JSON(schemas.MeasurementDeleteResponse{Count: count, DryRun: query.DryRun})
+	})
+
+	measurements.Delete("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid measurement ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := measurementService.DeleteMeasurement(ctx, id); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		// Return no content status upon successful deletion.
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	unitsGroup := api.Group("/units")
+
+	unitsGroup.Get("/", func(c *fiber.Ctx) error {
+		response := []schemas.DimensionResponse{}
+		for _, dimension := range units.Dimensions() {
+			dimensionUnits, err := units.Units(dimension)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			response = append(response, schemas.NewDimensionResponse(dimension, dimensionUnits))
+		}
+
+		return c.JSON(response)
+	})
+
+	seriesGroup := api.Group("/series")
+
+	seriesGroup.Get("/aligned", func(c *fiber.Ctx) error {
+		var query schemas.AlignedSeriesQuery
+
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		timeRange := query.TimeRange()
+		input := series.AlignSeriesInput{
+			XParameterID:    uuid.MustParse(query.XParameterID),
+			YParameterID:    uuid.MustParse(query.YParameterID),
+			Aggregation:     query.Aggregation,
+			Gaps:            query.GapOptions(),
+			Location:        query.Location(),
+			From:            timeRange.From,
+			To:              timeRange.To,
+			ExcludeOutliers: query.ExcludeOutliers,
+		}
+
+		ctx := context.Background()
+		annotations, err := annotationService.ListOverlappingAnnotations(ctx, annotation.ListOverlappingAnnotationsInput{
+			ParameterIDs: []uuid.UUID{input.XParameterID, input.YParameterID},
+			From:         input.From,
+			To:           input.To,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		if query.ExcludeAnnotated {
+			input.Exclude = annotation.Periods(annotations)
+		}
+
+		alignedSeries, err := seriesService.AlignSeries(ctx, input)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewAlignedSeriesResponse(alignedSeries, annotations))
+	})
+
+	experiments := api.Group("/experiments")
+
+	experiments.Post("/", func(c *fiber.Ctx) error {
+		var req schemas.CreateExperimentRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := experiment.CreateExperimentInput{
+			UserID:             req.UserID,
+			OutcomeParameterID: req.OutcomeParameterID,
+			Name:               req.Name,
+			Intervention:       req.Intervention,
+			Design:             req.Design,
+			StartDate:          req.Start(),
+			PhaseDays:          req.PhaseDays,
+			WashoutDays:        req.WashoutDays,
+		}
+
+		ctx := context.Background()
+		exp, err := experimentService.CreateExperiment(ctx, input)
+		if err != nil {
+			if errors.Is(err, experiment.ErrInvalidDesign) ||
+				errors.Is(err, experiment.ErrInvalidPhaseDays) ||
+				errors.Is(err, experiment.ErrInvalidWashout) ||
+				errors.Is(err, experiment.ErrInvalidOutcome) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewExperimentResponse(exp))
+	})
+
+	experiments.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		userExperiments, err := experimentService.ListExperimentsByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.ExperimentResponse{}
+		for _, e := range userExperiments {
+			response = append(response, schemas.NewExperimentResponse(e))
+		}
+
+		return c.JSON(response)
+	})
+
+	experiments.Get("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid experiment ID",
+			})
+		}
+
+		ctx := context.Background()
+		exp, err := experimentService.GetExperimentByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewExperimentResponse(exp))
+	})
+
+	experiments.Get("/:id/phase", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid experiment ID",
+			})
+		}
+
+		var query schemas.ExperimentPhaseQuery
+
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		ctx := context.Background()
+		phase, err := experimentService.GetPhase(ctx, experiment.GetPhaseInput{
+			ExperimentID: id,
+			Date:         query.Day(),
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewDayPhaseResponse(*phase))
+	})
+
+	experiments.Get("/:id/analysis", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid experiment ID",
+			})
+		}
+
+		var query schemas.ExperimentAnalysisQuery
+
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := experiment.AnalyzeExperimentInput{
+			ExperimentID:    id,
+			Aggregation:     query.Aggregation,
+			Location:        query.Location(),
+			ExcludeOutliers: query.ExcludeOutliers,
+			Permutations:    query.Permutations,
+			Seed:            query.Seed,
+		}
+
+		ctx := context.Background()
+		result, err := experimentService.AnalyzeExperiment(ctx, input)
+		if err != nil {
+			if errors.Is(err, experiment.ErrNotEnoughData) {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, experiment.ErrInvalidPermutations) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewExperimentAnalysisResponse(result))
+	})
+
+	experiments.Delete("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid experiment ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := experimentService.DeleteExperiment(ctx, id); err != nil {
+			if errors.Is(err, experiment.ErrExperimentNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	goals := api.Group("/goals")
+
+	goals.Post("/", func(c *fiber.Ctx) error {
+		var req schemas.CreateGoalRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := goal.CreateGoalInput{
+			ParameterID: req.ParameterID,
+			Kind:        req.Kind,
+			Target:      req.Target,
+			Min:         req.Min,
+			Max:         req.Max,
+			Period:      req.Period,
+			Aggregation: req.Aggregation,
+			StartDate:   req.Start(),
+			Deadline:    req.DeadlineDate(),
+		}
+
+		ctx := context.Background()
+		createdGoal, err := goalService.CreateGoal(ctx, input)
+		if err != nil {
+			if errors.Is(err, goal.ErrInvalidKind) ||
+				errors.Is(err, goal.ErrInvalidPeriod) ||
+				errors.Is(err, goal.ErrInvalidRange) ||
+				errors.Is(err, goal.ErrInvalidTotal) ||
+				errors.Is(err, goal.ErrInvalidDeadline) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewGoalResponse(createdGoal))
+	})
+
+	goals.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		userGoals, err := goalService.ListGoalsByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.GoalResponse{}
+		for _, g := range userGoals {
+			response = append(response, schemas.NewGoalResponse(g))
+		}
+
+		return c.JSON(response)
+	})
+
+	goals.Get("/parameter/:parameterId", func(c *fiber.Ctx) error {
+		parameterIDStr := c.Params("parameterId")
+		parameterID, err := uuid.Parse(parameterIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid parameter ID",
+			})
+		}
+
+		ctx := context.Background()
+		parameterGoals, err := goalService.ListGoalsByParameter(ctx, parameterID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.GoalResponse{}
+		for _, g := range parameterGoals {
+			response = append(response, schemas.NewGoalResponse(g))
+		}
+
+		return c.JSON(response)
+	})
+
+	goals.Get("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid goal ID",
+			})
+		}
+
+		ctx := context.Background()
+		foundGoal, err := goalService.GetGoalByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewGoalResponse(foundGoal))
+	})
+
+	goals.Get("/:id/progress", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid goal ID",
+			})
+		}
+
+		var query schemas.GoalProgressQuery
+
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		ctx := context.Background()
+		progress, err := goalService.GetProgress(ctx, goal.GetProgressInput{
+			GoalID: id,
+			AsOf:   query.Time(),
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewGoalProgressResponse(progress))
+	})
+
+	goals.Delete("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid goal ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := goalService.DeleteGoal(ctx, id); err != nil {
+			if errors.Is(err, goal.ErrGoalNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	alerts := api.Group("/alerts")
+
+	alerts.Post("/rules", func(c *fiber.Ctx) error {
+		var req schemas.CreateAlertRuleRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := alert.CreateRuleInput{
+			ParameterID: req.ParameterID,
+			Name:        req.Name,
+			Kind:        req.Kind,
+			Operator:    req.Operator,
+			Value:       req.Value,
+			WindowDays:  req.WindowDays,
+			Cooldown:    req.Cooldown(),
+		}
+
+		ctx := context.Background()
+		rule, err := alertService.CreateRule(ctx, input)
+		if err != nil {
+			if errors.Is(err, alert.ErrInvalidKind) ||
+				errors.Is(err, alert.ErrInvalidOperator) ||
+				errors.Is(err, alert.ErrInvalidWindow) ||
+				errors.Is(err, alert.ErrInvalidCooldown) ||
+				errors.Is(err, alert.ErrDerivedParameter) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewAlertRuleResponse(rule))
+	})
+
+	alerts.Get("/rules/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		rules, err := alertService.ListRulesByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.AlertRuleResponse{}
+		for _, r := range rules {
+			response = append(response, schemas.NewAlertRuleResponse(r))
+		}
+
+		return c.JSON(response)
+	})
+
+	alerts.Get("/rules/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid alert rule ID",
+			})
+		}
+
+		ctx := context.Background()
+		rule, err := alertService.GetRuleByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewAlertRuleResponse(rule))
+	})
+
+	alerts.Delete("/rules/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid alert rule ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := alertService.DeleteRule(ctx, id); err != nil {
+			if errors.Is(err, alert.ErrRuleNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	alerts.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		userAlerts, err := alertService.ListAlertsByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.AlertResponse{}
+		for _, a := range userAlerts {
+			response = append(response, schemas.NewAlertResponse(a))
+		}
+
+		return c.JSON(response)
+	})
+
+	alerts.Put("/:id/acknowledge", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid alert ID",
+			})
+		}
+
+		ctx := context.Background()
+		acknowledged, err := alertService.AcknowledgeAlert(ctx, id)
+		if err != nil {
+			if errors.Is(err, alert.ErrAlertNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewAlertResponse(acknowledged))
+	})
+
+	reminders := api.Group("/reminders")
+
+	reminders.Post("/schedules", func(c *fiber.Ctx) error {
+		var req schemas.CreateReminderScheduleRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		hour, minute := req.TimeOfDay()
+		input := reminder.CreateScheduleInput{
+			ParameterID: req.ParameterID,
+			Kind:        req.Kind,
+			Hour:        hour,
+			Minute:      minute,
+			Weekdays:    schemas.ParseSchedule(req.Weekdays),
+			Interval:    time.Duration(req.IntervalMinutes) * time.Minute,
+			StartAt:     req.StartAt,
+			Grace:       time.Duration(req.GraceMinutes) * time.Minute,
+		}
+
+		ctx := context.Background()
+		schedule, err := reminderService.CreateSchedule(ctx, input)
+		if err != nil {
+			if errors.Is(err, reminder.ErrInvalidKind) ||
+				errors.Is(err, reminder.ErrInvalidTime) ||
+				errors.Is(err, reminder.ErrInvalidWeekdays) ||
+				errors.Is(err, reminder.ErrInvalidInterval) ||
+				errors.Is(err, reminder.ErrInvalidGrace) ||
+				errors.Is(err, reminder.ErrDerivedParameter) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewReminderScheduleResponse(schedule))
+	})
+
+	reminders.Get("/schedules/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		schedules, err := reminderService.ListSchedulesByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.ReminderScheduleResponse{}
+		for _, s := range schedules {
+			response = append(response, schemas.NewReminderScheduleResponse(s))
+		}
+
+		return c.JSON(response)
+	})
+
+	reminders.Get("/schedules/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid reminder schedule ID",
+			})
+		}
+
+		ctx := context.Background()
+		schedule, err := reminderService.GetScheduleByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewReminderScheduleResponse(schedule))
+	})
+
+	reminders.Delete("/schedules/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid reminder schedule ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := reminderService.DeleteSchedule(ctx, id); err != nil {
+			if errors.Is(err, reminder.ErrScheduleNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	reminders.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		var query schemas.ListRemindersQuery
+
+		if err := c.QueryParser(&query); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := query.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		ctx := context.Background()
+		userReminders, err := reminderService.ListRemindersByUser(ctx, reminder.ListRemindersInput{
+			UserID: userID,
+			Status: query.Status,
+		})
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.ReminderResponse{}
+		for _, r := range userReminders {
+			response = append(response, schemas.NewReminderResponse(r))
+		}
+
+		return c.JSON(response)
+	})
+
+	annotations := api.Group("/annotations")
+
+	annotations.Post("/", func(c *fiber.Ctx) error {
+		var req schemas.CreateAnnotationRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := annotation.CreateAnnotationInput{
+			UserID:       req.UserID,
+			ParameterIDs: req.ParameterIDs,
+			Title:        req.Title,
+			Description:  req.Description,
+			Category:     req.Category,
+			StartDate:    req.Start(),
+			EndDate:      req.End(),
+		}
+
+		ctx := context.Background()
+		createdAnnotation, err := annotationService.CreateAnnotation(ctx, input)
+		if err != nil {
+			if errors.Is(err, annotation.ErrInvalidDateRange) || errors.Is(err, annotation.ErrParameterNotOwned) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, user.ErrUserNotFound) || errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewAnnotationResponse(createdAnnotation))
+	})
+
+	annotations.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		userAnnotations, err := annotationService.ListAnnotationsByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewAnnotationResponses(userAnnotations))
+	})
+
+	annotations.Get("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid annotation ID",
+			})
+		}
+
+		ctx := context.Background()
+		foundAnnotation, err := annotationService.GetAnnotationByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewAnnotationResponse(foundAnnotation))
+	})
+
+	annotations.Delete("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid annotation ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := annotationService.DeleteAnnotation(ctx, id); err != nil {
+			if errors.Is(err, annotation.ErrAnnotationNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	hypotheses := api.Group("/hypotheses")
+
+	hypotheses.Post("/", func(c *fiber.Ctx) error {
+		var req schemas.CreateHypothesisRequest
+
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid input: " + err.Error(),
+			})
+		}
+
+		if err := req.Validate(); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Validation failed",
+				"details": validationErrors.Error(),
+			})
+		}
+
+		input := hypothesis.CreateHypothesisInput{
+			CauseParameterID:  req.CauseParameterID,
+			EffectParameterID: req.EffectParameterID,
+			Statement:         req.Statement,
+			ExpectedDirection: req.ExpectedDirection,
+			MinLagDays:        req.MinLagDays,
+			MaxLagDays:        req.MaxLagDays,
+			Aggregation:       req.Aggregation,
+		}
+
+		ctx := context.Background()
+		createdHypothesis, err := hypothesisService.CreateHypothesis(ctx, input)
+		if err != nil {
+			if errors.Is(err, hypothesis.ErrInvalidParameters) ||
+				errors.Is(err, hypothesis.ErrInvalidDirection) ||
+				errors.Is(err, hypothesis.ErrInvalidLag) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			if errors.Is(err, parameter.ErrParameterNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(schemas.NewHypothesisResponse(createdHypothesis))
+	})
+
+	hypotheses.Get("/user/:userId", func(c *fiber.Ctx) error {
+		userIDStr := c.Params("userId")
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid user ID",
+			})
+		}
+
+		ctx := context.Background()
+		userHypotheses, err := hypothesisService.ListHypothesesByUser(ctx, userID)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.HypothesisResponse{}
+		for _, h := range userHypotheses {
+			response = append(response, schemas.NewHypothesisResponse(h))
+		}
+
+		return c.JSON(response)
+	})
+
+	hypotheses.Get("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid hypothesis ID",
+			})
+		}
+
+		ctx := context.Background()
+		foundHypothesis, err := hypothesisService.GetHypothesisByID(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewHypothesisResponse(foundHypothesis))
+	})
+
+	hypotheses.Get("/:id/evaluations", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid hypothesis ID",
+			})
+		}
+
+		ctx := context.Background()
+		evaluations, err := hypothesisService.ListEvaluations(ctx, id)
+		if err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		response := []schemas.EvaluationResponse{}
+		for _, e := range evaluations {
+			response = append(response, schemas.NewEvaluationResponse(e))
+		}
+
+		return c.JSON(response)
+	})
+
+	hypotheses.Post("/:id/evaluate", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid hypothesis ID",
+			})
+		}
+
+		ctx := context.Background()
+		evaluation, err := hypothesisService.EvaluateHypothesis(ctx, id, time.Now())
+		if err != nil {
+			if errors.Is(err, hypothesis.ErrHypothesisNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.JSON(schemas.NewEvaluationResponse(evaluation))
+	})
+
+	hypotheses.Delete("/:id", func(c *fiber.Ctx) error {
+		idStr := c.Params("id")
+		id, uuidParseErr := uuid.Parse(idStr)
+		if uuidParseErr != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid hypothesis ID",
+			})
+		}
+
+		ctx := context.Background()
+		if err := hypothesisService.DeleteHypothesis(ctx, id); err != nil {
+			if errors.Is(err, hypothesis.ErrHypothesisNotFound) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error": err.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	// -------------------------
+	// Start the server in a goroutine
+	// -------------------------
+	log.Println("Starting server on port", port)
+	go func() {
+		if err := app.Listen(":" + port); err != nil {
+			// If Listen fails, we log and send a signal, or exit
+			log.Fatalf("could not start server: %v", err)
+		}
+	}()
+
+	// -------------------------
+	// Run background jobs on a schedule
+	// -------------------------
+	stopJobs := make(chan struct{})
+	runPeriodically := func(name string, interval time.Duration, job func(ctx context.Context) error) {
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-stopJobs:
+					return
+				case <-ticker.C:
+					if err := job(context.Background()); err != nil {
+						log.Printf("%s failed: %v", name, err)
+					}
+				}
+			}
+		}()
+	}
+
+	runPeriodically("alert evaluation", alertEvaluationInterval, func(ctx context.Context) error {
+		raised, err := alertService.EvaluateRules(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(raised) > 0 {
+			log.Printf("raised %d alerts", len(raised))
+		}
+		return nil
+	})
+
+	runPeriodically("reminder scheduler", reminderSchedulerInterval, func(ctx context.Context) error {
+		result, err := reminderService.RunScheduler(ctx)
+		if err != nil {
+			return err
+		}
+		if len(result.Due) > 0 || len(result.Missed) > 0 {
+			log.Printf("%d reminders due, %d missed", len(result.Due), len(result.Missed))
+		}
+		return nil
+	})
+
+	runPeriodically("hypothesis evaluation", hypothesisEvaluationInterval, func(ctx context.Context) error {
+		recorded, err := hypothesisService.EvaluateHypotheses(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+		if len(recorded) > 0 {
+			log.Printf("re-evaluated %d hypotheses", len(recorded))
+		}
+		return nil
+	})
 
 	// -------------------------
 	// Listen for kill signals (graceful shutdown)
@@ -524,6 +2917,7 @@ func main() {
 	<-quit // Block until we get a signal
 
 	log.Println("Gracefully shutting down server...")
+	close(stopJobs)
 	if err := app.Shutdown(); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
@@ -1,14 +1,17 @@
 package middleware
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/idempotency"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -64,6 +67,86 @@ func VerifySignatureMiddleware(secretKeys []string) fiber.Handler {
 	}
 }
 
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// IdempotencyMiddleware answers a retried request carrying an Idempotency-Key
+// header with the stored response of the first attempt instead of handling it
+// again. Server errors are not stored, so those requests can be retried.
+func IdempotencyMiddleware(idempotencyService idempotency.Service) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+
+		scope := c.Method() + " " + c.Path()
+		ctx := context.Background()
+
+		record, err := idempotencyService.Begin(ctx, idempotency.BeginInput{
+			Scope:   scope,
+			Key:     key,
+			Request: c.Body(),
+		})
+		if err != nil {
+			status := fiber.StatusInternalServerError
+			switch {
+			case errors.Is(err, idempotency.ErrInvalidKey):
+				status = fiber.StatusBadRequest
+			case errors.Is(err, idempotency.ErrKeyInProgress):
+				status = fiber.StatusConflict
+			case errors.Is(err, idempotency.ErrKeyReused):
+				status = fiber.StatusUnprocessableEntity
+			}
+			return c.Status(status).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		if record.Status == idempotency.StatusCompleted {
+			c.Set(IdempotentReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, record.ContentType)
+			if record.ETag != "" {
+				c.Set(fiber.HeaderETag, record.ETag)
+			}
+			return c.Status(record.StatusCode).Send(record.Body)
+		}
+
+		if err := c.Next(); err != nil {
+			if releaseErr := idempotencyService.Release(ctx, scope, key); releaseErr != nil {
+				log.Printf("failed to release idempotency key: %v", releaseErr)
+			}
+			return err
+		}
+
+		statusCode := c.Response().StatusCode()
+		if statusCode >= fiber.StatusInternalServerError {
+			if releaseErr := idempotencyService.Release(ctx, scope, key); releaseErr != nil {
+				log.Printf("failed to release idempotency key: %v", releaseErr)
+			}
+			return nil
+		}
+
+		// The response buffer is reused once the request is done, so the body
+		// is copied before it is stored.
+		_, completeErr := idempotencyService.Complete(ctx, idempotency.CompleteInput{
+			Scope:       scope,
+			Key:         key,
+			StatusCode:  statusCode,
+			ContentType: string(c.Response().Header.ContentType()),
+			ETag:        string(c.Response().Header.Peek(fiber.HeaderETag)),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		})
+		if completeErr != nil {
+			log.Printf("failed to store response for idempotency key: %v", completeErr)
+		}
+
+		return nil
+	}
+}
+
 func computeHMAC(secret, payload string, timestamp int64) string {
 	message := fmt.Sprintf("%s|%d", payload, timestamp)
 	h := hmac.New(sha256.New, []byte(secret))
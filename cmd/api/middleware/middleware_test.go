@@ -0,0 +1,119 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/cmd/api/middleware"
+	"github.com/dim2k2006/correlateapp-be/pkg/clock"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/idempotency"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotentApp serves POST /items behind the idempotency middleware. The
+// handler answers with the statuses given, one per call, and then with 201.
+func newIdempotentApp(idempotencyService idempotency.Service, statuses ...int) (*fiber.App, *int) {
+	calls := 0
+	app := fiber.New()
+	app.Post("/items", middleware.IdempotencyMiddleware(idempotencyService), func(c *fiber.Ctx) error {
+		calls++
+		status := fiber.StatusCreated
+		if calls <= len(statuses) {
+			status = statuses[calls-1]
+		}
+		c.Set(fiber.HeaderETag, `"`+strconv.Itoa(calls)+`"`)
+		return c.Status(status).JSON(fiber.Map{"call": calls})
+	})
+
+	return app, &calls
+}
+
+func post(t *testing.T, app *fiber.App, key, body string) (*http.Response, string) {
+	t.Helper()
+
+	req := httptest.NewRequest(fiber.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+
+	responseBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(responseBody)
+}
+
+func newIdempotencyService() idempotency.Service {
+	return idempotency.NewService(idempotency.NewInMemoryRepository(), time.Hour, clock.SystemClock{})
+}
+
+func TestIdempotencyMiddleware_ReplaysStoredResponse(t *testing.T) {
+	app, calls := newIdempotentApp(newIdempotencyService())
+
+	first, firstBody := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+	assert.Empty(t, first.Header.Get(middleware.IdempotentReplayedHeader))
+
+	replayed, replayedBody := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusCreated, replayed.StatusCode)
+	assert.Equal(t, "true", replayed.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.Equal(t, fiber.MIMEApplicationJSON, replayed.Header.Get(fiber.HeaderContentType))
+	assert.JSONEq(t, firstBody, replayedBody)
+	assert.Equal(t, `"1"`, replayed.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, 1, *calls)
+
+	other, _ := post(t, app, "def", `{"value":1}`)
+	assert.Equal(t, fiber.StatusCreated, other.StatusCode)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestIdempotencyMiddleware_ConflictWhileInProgress(t *testing.T) {
+	idempotencyService := newIdempotencyService()
+	app, calls := newIdempotentApp(idempotencyService)
+
+	// The first request with the key is still being handled.
+	_, err := idempotencyService.Begin(context.Background(), idempotency.BeginInput{
+		Scope:   fiber.MethodPost + " /items",
+		Key:     "abc",
+		Request: []byte(`{"value":1}`),
+	})
+	require.NoError(t, err)
+
+	resp, _ := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Equal(t, 0, *calls)
+}
+
+func TestIdempotencyMiddleware_RejectsReusedKey(t *testing.T) {
+	app, calls := newIdempotentApp(newIdempotencyService())
+
+	first, _ := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+
+	reused, _ := post(t, app, "abc", `{"value":2}`)
+	assert.Equal(t, fiber.StatusUnprocessableEntity, reused.StatusCode)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestIdempotencyMiddleware_ReleasesKeyAfterServerError(t *testing.T) {
+	app, calls := newIdempotentApp(newIdempotencyService(), fiber.StatusServiceUnavailable)
+
+	failed, _ := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusServiceUnavailable, failed.StatusCode)
+
+	retried, body := post(t, app, "abc", `{"value":1}`)
+	assert.Equal(t, fiber.StatusCreated, retried.StatusCode)
+	assert.Empty(t, retried.Header.Get(middleware.IdempotentReplayedHeader))
+	assert.JSONEq(t, `{"call":2}`, body)
+	assert.Equal(t, 2, *calls)
+}
@@ -0,0 +1,88 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/alert"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CreateAlertRuleRequest struct {
+	ParameterID     uuid.UUID      `json:"parameterId" validate:"required,uuid4"`
+	Name            string         `json:"name" validate:"required,min=2,max=100"`
+	Kind            alert.Kind     `json:"kind" validate:"required,oneof=threshold change missing"`
+	Operator        alert.Operator `json:"operator,omitempty" validate:"required_unless=Kind missing,omitempty,oneof=gt gte lt lte"`
+	Value           float64        `json:"value,omitempty"`
+	WindowDays      int            `json:"windowDays,omitempty" validate:"required_unless=Kind threshold,omitempty,min=1,max=365"`
+	CooldownMinutes int            `json:"cooldownMinutes,omitempty" validate:"min=0"`
+}
+
+// Cooldown returns the cooldown as a duration.
+func (r *CreateAlertRuleRequest) Cooldown() time.Duration {
+	return time.Duration(r.CooldownMinutes) * time.Minute
+}
+
+func getAlertRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *CreateAlertRuleRequest) Validate() error {
+	return getAlertRequestValidator().Struct(r)
+}
+
+type AlertRuleResponse struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"userId"`
+	ParameterID     uuid.UUID      `json:"parameterId"`
+	Name            string         `json:"name"`
+	Kind            alert.Kind     `json:"kind"`
+	Operator        alert.Operator `json:"operator,omitempty"`
+	Value           float64        `json:"value"`
+	WindowDays      int            `json:"windowDays,omitempty"`
+	CooldownMinutes int            `json:"cooldownMinutes"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+func NewAlertRuleResponse(r *alert.Rule) AlertRuleResponse {
+	return AlertRuleResponse{
+		ID:              r.ID,
+		UserID:          r.UserID,
+		ParameterID:     r.ParameterID,
+		Name:            r.Name,
+		Kind:            r.Kind,
+		Operator:        r.Operator,
+		Value:           r.Value,
+		WindowDays:      r.WindowDays,
+		CooldownMinutes: int(r.Cooldown / time.Minute),
+		CreatedAt:       r.CreatedAt,
+		UpdatedAt:       r.UpdatedAt,
+	}
+}
+
+type AlertResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	RuleID         uuid.UUID  `json:"ruleId"`
+	UserID         uuid.UUID  `json:"userId"`
+	ParameterID    uuid.UUID  `json:"parameterId"`
+	MeasurementID  *uuid.UUID `json:"measurementId,omitempty"`
+	Value          float64    `json:"value"`
+	Message        string     `json:"message"`
+	TriggeredAt    time.Time  `json:"triggeredAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+}
+
+func NewAlertResponse(a *alert.Alert) AlertResponse {
+	return AlertResponse{
+		ID:             a.ID,
+		RuleID:         a.RuleID,
+		UserID:         a.UserID,
+		ParameterID:    a.ParameterID,
+		MeasurementID:  a.MeasurementID,
+		Value:          a.Value,
+		Message:        a.Message,
+		TriggeredAt:    a.TriggeredAt,
+		AcknowledgedAt: a.AcknowledgedAt,
+	}
+}
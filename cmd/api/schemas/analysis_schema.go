@@ -0,0 +1,491 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/analysis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/annotation"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type TimeRangeQuery struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// TimeRange converts the validated query into a domain time range.
+func (q *TimeRangeQuery) TimeRange() analysis.TimeRange {
+	var timeRange analysis.TimeRange
+	if from, err := time.Parse(time.RFC3339, q.From); err == nil {
+		timeRange.From = &from
+	}
+	if to, err := time.Parse(time.RFC3339, q.To); err == nil {
+		timeRange.To = &to
+	}
+
+	return timeRange
+}
+
+type ParameterStatisticsQuery struct {
+	TimeRangeQuery
+	// ExcludeAnnotated drops the measurements taken on days covered by
+	// overlapping annotations.
+	ExcludeAnnotated bool `query:"excludeAnnotated"`
+	// Timezone is the IANA time zone in which measurements are matched to
+	// annotated days. Defaults to UTC.
+	Timezone string `query:"timezone" validate:"omitempty,timezone"`
+}
+
+// Location returns the validated time zone, or nil for UTC.
+func (q *ParameterStatisticsQuery) Location() *time.Location {
+	return loadLocation(q.Timezone)
+}
+
+type TrendQuery struct {
+	SeriesOptionsQuery
+	TimeRangeQuery
+	Alpha      float64 `query:"alpha" validate:"omitempty,gt=0,lt=1"`
+	Penalty    float64 `query:"penalty" validate:"omitempty,gt=0"`
+	MinSegment int     `query:"minSegment" validate:"omitempty,min=2"`
+}
+
+type ProfileQuery struct {
+	SeriesOptionsQuery
+	TimeRangeQuery
+	Alpha float64 `query:"alpha" validate:"omitempty,gt=0,lt=1"`
+}
+
+type ForecastQuery struct {
+	SeriesOptionsQuery
+	TimeRangeQuery
+	Method  analysis.ForecastMethod `query:"method" validate:"omitempty,oneof=holt_winters arima"`
+	P       int                     `query:"p" validate:"omitempty,min=0,max=2"`
+	D       int                     `query:"d" validate:"omitempty,min=0,max=2"`
+	Q       int                     `query:"q" validate:"omitempty,min=0,max=2"`
+	Horizon int                     `query:"horizon" validate:"omitempty,min=1,max=90"`
+	Level   float64                 `query:"level" validate:"omitempty,gt=0,lt=1"`
+}
+
+type EventStudyQuery struct {
+	SeriesOptionsQuery
+	// Date is the event day. AnnotationID may be given instead, in which case
+	// the annotation's start date is used and the annotation itself is never
+	// excluded by ExcludeAnnotated.
+	Date         string  `query:"date" validate:"required_without=AnnotationID,omitempty,datetime=2006-01-02"`
+	AnnotationID string  `query:"annotationId" validate:"omitempty,uuid"`
+	Before       int     `query:"before" validate:"omitempty,min=1,max=365"`
+	After        int     `query:"after" validate:"omitempty,min=1,max=365"`
+	Alpha        float64 `query:"alpha" validate:"omitempty,gt=0,lt=1"`
+	Permutations int     `query:"permutations" validate:"omitempty,min=1,max=100000"`
+	Seed         uint64  `query:"seed"`
+}
+
+// EventDate returns the validated event date, if one was given.
+func (q *EventStudyQuery) EventDate() time.Time {
+	date, _ := time.Parse(dateLayout, q.Date)
+	return date
+}
+
+// Window returns the days covered by the before and after windows, for
+// looking up overlapping annotations.
+func (q *EventStudyQuery) Window(eventDate time.Time) (time.Time, time.Time) {
+	before, after := q.Before, q.After
+	if before == 0 {
+		before = analysis.DefaultEventWindow
+	}
+	if after == 0 {
+		after = analysis.DefaultEventWindow
+	}
+
+	return eventDate.AddDate(0, 0, -before), eventDate.AddDate(0, 0, after-1)
+}
+
+func getAnalysisRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (q *ParameterStatisticsQuery) Validate() error {
+	return getAnalysisRequestValidator().Struct(q)
+}
+
+func (q *TrendQuery) Validate() error {
+	return getAnalysisRequestValidator().Struct(q)
+}
+
+func (q *ProfileQuery) Validate() error {
+	return getAnalysisRequestValidator().Struct(q)
+}
+
+func (q *ForecastQuery) Validate() error {
+	return getAnalysisRequestValidator().Struct(q)
+}
+
+func (q *EventStudyQuery) Validate() error {
+	return getAnalysisRequestValidator().Struct(q)
+}
+
+type PercentileResponse struct {
+	Rank  float64 `json:"rank"`
+	Value float64 `json:"value"`
+}
+
+type LoggingFrequencyResponse struct {
+	DaysLogged     int     `json:"daysLogged"`
+	DaysSpanned    int     `json:"daysSpanned"`
+	EntriesPerDay  float64 `json:"entriesPerDay"`
+	LoggedDayRatio float64 `json:"loggedDayRatio"`
+}
+
+type ParameterStatisticsResponse struct {
+	ParameterID            uuid.UUID                `json:"parameterId"`
+	From                   *time.Time               `json:"from,omitempty"`
+	To                     *time.Time               `json:"to,omitempty"`
+	Count                  int                      `json:"count"`
+	Mean                   float64                  `json:"mean"`
+	Median                 float64                  `json:"median"`
+	StdDev                 float64                  `json:"stdDev"`
+	Min                    float64                  `json:"min"`
+	Max                    float64                  `json:"max"`
+	Percentiles            []PercentileResponse     `json:"percentiles"`
+	CoefficientOfVariation float64                  `json:"coefficientOfVariation"`
+	FirstLoggedAt          *time.Time               `json:"firstLoggedAt,omitempty"`
+	LastLoggedAt           *time.Time               `json:"lastLoggedAt,omitempty"`
+	Frequency              LoggingFrequencyResponse `json:"frequency"`
+	Annotations            []AnnotationResponse     `json:"annotations"`
+}
+
+func NewParameterStatisticsResponse(
+	s *analysis.Statistics,
+	annotations []*annotation.Annotation,
+) ParameterStatisticsResponse {
+	percentiles := []PercentileResponse{}
+	for _, p := range s.Percentiles {
+		percentiles = append(percentiles, PercentileResponse{
+			Rank:  p.Rank,
+			Value: p.Value,
+		})
+	}
+
+	return ParameterStatisticsResponse{
+		ParameterID:            s.ParameterID,
+		From:                   s.Range.From,
+		To:                     s.Range.To,
+		Count:                  s.Count,
+		Mean:                   s.Mean,
+		Median:                 s.Median,
+		StdDev:                 s.StdDev,
+		Min:                    s.Min,
+		Max:                    s.Max,
+		Percentiles:            percentiles,
+		CoefficientOfVariation: s.CoefficientOfVariation,
+		FirstLoggedAt:          s.FirstLoggedAt,
+		LastLoggedAt:           s.LastLoggedAt,
+		Frequency: LoggingFrequencyResponse{
+			DaysLogged:     s.Frequency.DaysLogged,
+			DaysSpanned:    s.Frequency.DaysSpanned,
+			EntriesPerDay:  s.Frequency.EntriesPerDay,
+			LoggedDayRatio: s.Frequency.LoggedDayRatio,
+		},
+		Annotations: NewAnnotationResponses(annotations),
+	}
+}
+
+type MannKendallResponse struct {
+	S        float64 `json:"s"`
+	Variance float64 `json:"variance"`
+	Z        float64 `json:"z"`
+	PValue   float64 `json:"pValue"`
+	Tau      float64 `json:"tau"`
+}
+
+type SenSlopeResponse struct {
+	SlopePerDay float64 `json:"slopePerDay"`
+	Intercept   float64 `json:"intercept"`
+	Lower       float64 `json:"lower"`
+	Upper       float64 `json:"upper"`
+}
+
+type ChangepointResponse struct {
+	Date       time.Time `json:"date"`
+	MeanBefore float64   `json:"meanBefore"`
+	MeanAfter  float64   `json:"meanAfter"`
+	Shift      float64   `json:"shift"`
+}
+
+type TrendResponse struct {
+	ParameterID  uuid.UUID               `json:"parameterId"`
+	From         *time.Time              `json:"from,omitempty"`
+	To           *time.Time              `json:"to,omitempty"`
+	Points       int                     `json:"points"`
+	Alpha        float64                 `json:"alpha"`
+	Direction    analysis.TrendDirection `json:"direction"`
+	MannKendall  MannKendallResponse     `json:"mannKendall"`
+	SenSlope     SenSlopeResponse        `json:"senSlope"`
+	Changepoints []ChangepointResponse   `json:"changepoints"`
+	Annotations  []AnnotationResponse    `json:"annotations"`
+}
+
+func NewTrendResponse(t *analysis.Trend, annotations []*annotation.Annotation) TrendResponse {
+	changepoints := []ChangepointResponse{}
+	for _, c := range t.Changepoints {
+		changepoints = append(changepoints, ChangepointResponse{
+			Date:       c.Date,
+			MeanBefore: c.MeanBefore,
+			MeanAfter:  c.MeanAfter,
+			Shift:      c.Shift,
+		})
+	}
+
+	return TrendResponse{
+		ParameterID: t.ParameterID,
+		From:        t.Range.From,
+		To:          t.Range.To,
+		Points:      t.Points,
+		Alpha:       t.Alpha,
+		Direction:   t.Direction,
+		MannKendall: MannKendallResponse{
+			S:        t.MannKendall.S,
+			Variance: t.MannKendall.Variance,
+			Z:        t.MannKendall.Z,
+			PValue:   t.MannKendall.PValue,
+			Tau:      t.MannKendall.Tau,
+		},
+		SenSlope: SenSlopeResponse{
+			SlopePerDay: t.SenSlope.Slope,
+			Intercept:   t.SenSlope.Intercept,
+			Lower:       t.SenSlope.Lower,
+			Upper:       t.SenSlope.Upper,
+		},
+		Changepoints: changepoints,
+		Annotations:  NewAnnotationResponses(annotations),
+	}
+}
+
+type ProfileBucketResponse struct {
+	Key    int     `json:"key"`
+	Label  string  `json:"label"`
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+	Lower  float64 `json:"lower"`
+	Upper  float64 `json:"upper"`
+}
+
+type WeekdayEffectResponse struct {
+	FStatistic  float64 `json:"fStatistic"`
+	DFBetween   int     `json:"dfBetween"`
+	DFWithin    int     `json:"dfWithin"`
+	PValue      float64 `json:"pValue"`
+	Significant bool    `json:"significant"`
+}
+
+type LoggingTimesResponse struct {
+	ByHour    []int `json:"byHour"`
+	ByWeekday []int `json:"byWeekday"`
+	PeakHour  int   `json:"peakHour"`
+}
+
+type ProfileResponse struct {
+	ParameterID   uuid.UUID               `json:"parameterId"`
+	From          *time.Time              `json:"from,omitempty"`
+	To            *time.Time              `json:"to,omitempty"`
+	Timezone      string                  `json:"timezone"`
+	Alpha         float64                 `json:"alpha"`
+	Weekdays      []ProfileBucketResponse `json:"weekdays"`
+	Hours         []ProfileBucketResponse `json:"hours"`
+	WeekdayEffect *WeekdayEffectResponse  `json:"weekdayEffect"`
+	LoggingTimes  LoggingTimesResponse    `json:"loggingTimes"`
+	Annotations   []AnnotationResponse    `json:"annotations"`
+}
+
+func newProfileBucketResponses(buckets []analysis.ProfileBucket) []ProfileBucketResponse {
+	response := []ProfileBucketResponse{}
+	for _, b := range buckets {
+		response = append(response, ProfileBucketResponse{
+			Key:    b.Key,
+			Label:  b.Label,
+			Count:  b.Count,
+			Mean:   b.Mean,
+			StdDev: b.StdDev,
+			Lower:  b.Lower,
+			Upper:  b.Upper,
+		})
+	}
+
+	return response
+}
+
+func NewProfileResponse(p *analysis.Profile, annotations []*annotation.Annotation) ProfileResponse {
+	var weekdayEffect *WeekdayEffectResponse
+	if p.WeekdayEffect != nil {
+		weekdayEffect = &WeekdayEffectResponse{
+			FStatistic:  p.WeekdayEffect.FStatistic,
+			DFBetween:   p.WeekdayEffect.DFBetween,
+			DFWithin:    p.WeekdayEffect.DFWithin,
+			PValue:      p.WeekdayEffect.PValue,
+			Significant: p.WeekdayEffect.Significant,
+		}
+	}
+
+	return ProfileResponse{
+		ParameterID:   p.ParameterID,
+		From:          p.Range.From,
+		To:            p.Range.To,
+		Timezone:      p.Location.String(),
+		Alpha:         p.Alpha,
+		Weekdays:      newProfileBucketResponses(p.Weekdays),
+		Hours:         newProfileBucketResponses(p.Hours),
+		WeekdayEffect: weekdayEffect,
+		LoggingTimes: LoggingTimesResponse{
+			ByHour:    p.LoggingTimes.ByHour,
+			ByWeekday: p.LoggingTimes.ByWeekday,
+			PeakHour:  p.LoggingTimes.PeakHour,
+		},
+		Annotations: NewAnnotationResponses(annotations),
+	}
+}
+
+type ARIMAOrderResponse struct {
+	P int `json:"p"`
+	D int `json:"d"`
+	Q int `json:"q"`
+}
+
+type ForecastPointResponse struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+	Lower float64   `json:"lower"`
+	Upper float64   `json:"upper"`
+}
+
+type ForecastResponse struct {
+	ParameterID  uuid.UUID               `json:"parameterId"`
+	Method       analysis.ForecastMethod `json:"method"`
+	Order        *ARIMAOrderResponse     `json:"order,omitempty"`
+	Level        float64                 `json:"level"`
+	Parameters   map[string]float64      `json:"parameters"`
+	Sigma        float64                 `json:"sigma"`
+	TrainingFrom time.Time               `json:"trainingFrom"`
+	TrainingTo   time.Time               `json:"trainingTo"`
+	Points       []ForecastPointResponse `json:"points"`
+	Annotations  []AnnotationResponse    `json:"annotations"`
+}
+
+func NewForecastResponse(f *analysis.Forecast, annotations []*annotation.Annotation) ForecastResponse {
+	var order *ARIMAOrderResponse
+	if f.Order != nil {
+		order = &ARIMAOrderResponse{P: f.Order.P, D: f.Order.D, Q: f.Order.Q}
+	}
+
+	points := []ForecastPointResponse{}
+	for _, p := range f.Points {
+		points = append(points, ForecastPointResponse{
+			Date:  p.Date,
+			Value: p.Value,
+			Lower: p.Lower,
+			Upper: p.Upper,
+		})
+	}
+
+	return ForecastResponse{
+		ParameterID:  f.ParameterID,
+		Method:       f.Method,
+		Order:        order,
+		Level:        f.Level,
+		Parameters:   f.Parameters,
+		Sigma:        f.Sigma,
+		TrainingFrom: f.TrainingFrom,
+		TrainingTo:   f.TrainingTo,
+		Points:       points,
+		Annotations:  NewAnnotationResponses(annotations),
+	}
+}
+
+type EventWindowResponse struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Days   int     `json:"days"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stdDev"`
+}
+
+type PermutationTestResponse struct {
+	Permutations int     `json:"permutations"`
+	PValue       float64 `json:"pValue"`
+}
+
+type CoefficientResponse struct {
+	Estimate   float64 `json:"estimate"`
+	StdError   float64 `json:"stdError"`
+	TStatistic float64 `json:"tStatistic"`
+	PValue     float64 `json:"pValue"`
+}
+
+type InterruptedTimeSeriesResponse struct {
+	Intercept        CoefficientResponse `json:"intercept"`
+	PreSlope         CoefficientResponse `json:"preSlope"`
+	LevelChange      CoefficientResponse `json:"levelChange"`
+	SlopeChange      CoefficientResponse `json:"slopeChange"`
+	RSquared         float64             `json:"rSquared"`
+	DegreesOfFreedom int                 `json:"degreesOfFreedom"`
+}
+
+type EventStudyResponse struct {
+	ParameterID     uuid.UUID                     `json:"parameterId"`
+	EventDate       string                        `json:"eventDate"`
+	Alpha           float64                       `json:"alpha"`
+	Before          EventWindowResponse           `json:"before"`
+	After           EventWindowResponse           `json:"after"`
+	Difference      float64                       `json:"difference"`
+	EffectSize      float64                       `json:"effectSize"`
+	PermutationTest PermutationTestResponse       `json:"permutationTest"`
+	Regression      InterruptedTimeSeriesResponse `json:"regression"`
+	Significant     bool                          `json:"significant"`
+	Annotations     []AnnotationResponse          `json:"annotations"`
+}
+
+func newEventWindowResponse(w analysis.EventWindow) EventWindowResponse {
+	return EventWindowResponse{
+		From:   w.From.Format(dateLayout),
+		To:     w.To.Format(dateLayout),
+		Days:   w.Days,
+		Mean:   w.Mean,
+		StdDev: w.StdDev,
+	}
+}
+
+func newCoefficientResponse(c analysis.Coefficient) CoefficientResponse {
+	return CoefficientResponse{
+		Estimate:   c.Estimate,
+		StdError:   c.StdError,
+		TStatistic: c.TStatistic,
+		PValue:     c.PValue,
+	}
+}
+
+func NewEventStudyResponse(e *analysis.EventStudy, annotations []*annotation.Annotation) EventStudyResponse {
+	return EventStudyResponse{
+		ParameterID: e.ParameterID,
+		EventDate:   e.EventDate.Format(dateLayout),
+		Alpha:       e.Alpha,
+		Before:      newEventWindowResponse(e.Before),
+		After:       newEventWindowResponse(e.After),
+		Difference:  e.Difference,
+		EffectSize:  e.EffectSize,
+		PermutationTest: PermutationTestResponse{
+			Permutations: e.PermutationTest.Permutations,
+			PValue:       e.PermutationTest.PValue,
+		},
+		Regression: InterruptedTimeSeriesResponse{
+			Intercept:        newCoefficientResponse(e.Regression.Intercept),
+			PreSlope:         newCoefficientResponse(e.Regression.PreSlope),
+			LevelChange:      newCoefficientResponse(e.Regression.LevelChange),
+			SlopeChange:      newCoefficientResponse(e.Regression.SlopeChange),
+			RSquared:         e.Regression.RSquared,
+			DegreesOfFreedom: e.Regression.DegreesOfFreedom,
+		},
+		Significant: e.Significant,
+		Annotations: NewAnnotationResponses(annotations),
+	}
+}
@@ -0,0 +1,85 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/annotation"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CreateAnnotationRequest struct {
+	UserID       uuid.UUID   `json:"userId" validate:"required,uuid4"`
+	ParameterIDs []uuid.UUID `json:"parameterIds,omitempty" validate:"omitempty,max=50,unique,dive,uuid4"`
+	Title        string      `json:"title" validate:"required,min=2,max=100"`
+	Description  string      `json:"description,omitempty" validate:"max=1000"`
+	Category     string      `json:"category,omitempty" validate:"max=50"`
+	StartDate    string      `json:"startDate" validate:"required,datetime=2006-01-02"`
+	EndDate      string      `json:"endDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// Start returns the validated start date.
+func (r *CreateAnnotationRequest) Start() time.Time {
+	start, _ := time.Parse(dateLayout, r.StartDate)
+	return start
+}
+
+// End returns the validated end date, or nil for a single-day annotation.
+func (r *CreateAnnotationRequest) End() *time.Time {
+	end, err := time.Parse(dateLayout, r.EndDate)
+	if err != nil {
+		return nil
+	}
+
+	return &end
+}
+
+func getAnnotationRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *CreateAnnotationRequest) Validate() error {
+	return getAnnotationRequestValidator().Struct(r)
+}
+
+type AnnotationResponse struct {
+	ID           uuid.UUID   `json:"id"`
+	UserID       uuid.UUID   `json:"userId"`
+	ParameterIDs []uuid.UUID `json:"parameterIds"`
+	Title        string      `json:"title"`
+	Description  string      `json:"description,omitempty"`
+	Category     string      `json:"category,omitempty"`
+	StartDate    string      `json:"startDate"`
+	EndDate      string      `json:"endDate"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+func NewAnnotationResponse(a *annotation.Annotation) AnnotationResponse {
+	parameterIDs := []uuid.UUID{}
+	parameterIDs = append(parameterIDs, a.ParameterIDs...)
+
+	return AnnotationResponse{
+		ID:           a.ID,
+		UserID:       a.UserID,
+		ParameterIDs: parameterIDs,
+		Title:        a.Title,
+		Description:  a.Description,
+		Category:     a.Category,
+		StartDate:    a.StartDate.Format(dateLayout),
+		EndDate:      a.EndDate.Format(dateLayout),
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
+
+// NewAnnotationResponses converts the annotations that overlap a series or
+// an analysis.
+func NewAnnotationResponses(annotations []*annotation.Annotation) []AnnotationResponse {
+	response := []AnnotationResponse{}
+	for _, a := range annotations {
+		response = append(response, NewAnnotationResponse(a))
+	}
+
+	return response
+}
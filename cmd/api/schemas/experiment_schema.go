@@ -0,0 +1,200 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/experiment"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+type CreateExperimentRequest struct {
+	UserID             uuid.UUID `json:"userId" validate:"required,uuid4"`
+	OutcomeParameterID uuid.UUID `json:"outcomeParameterId" validate:"required,uuid4"`
+	Name               string    `json:"name" validate:"required,min=2,max=100"`
+	Intervention       string    `json:"intervention" validate:"required,max=500"`
+	Design             string    `json:"design" validate:"required,min=2,max=12"`
+	StartDate          string    `json:"startDate" validate:"required,datetime=2006-01-02"`
+	PhaseDays          int       `json:"phaseDays" validate:"required,min=1,max=90"`
+	WashoutDays        int       `json:"washoutDays,omitempty" validate:"min=0"`
+}
+
+// Start returns the validated start date.
+func (r *CreateExperimentRequest) Start() time.Time {
+	start, _ := time.Parse(dateLayout, r.StartDate)
+	return start
+}
+
+type ExperimentPhaseQuery struct {
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// Day returns the requested date, or the zero time for today.
+func (q *ExperimentPhaseQuery) Day() time.Time {
+	day, _ := time.Parse(dateLayout, q.Date)
+	return day
+}
+
+type ExperimentAnalysisQuery struct {
+	Aggregation     series.Aggregation `query:"aggregation" validate:"omitempty,oneof=mean sum min max count"`
+	ExcludeOutliers bool               `query:"excludeOutliers"`
+	Timezone        string             `query:"timezone" validate:"omitempty,timezone"`
+	Permutations    int                `query:"permutations" validate:"omitempty,min=1,max=100000"`
+	Seed            uint64             `query:"seed"`
+}
+
+func (q *ExperimentAnalysisQuery) Location() *time.Location {
+	return loadLocation(q.Timezone)
+}
+
+func getExperimentRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *CreateExperimentRequest) Validate() error {
+	return getExperimentRequestValidator().Struct(r)
+}
+
+func (q *ExperimentPhaseQuery) Validate() error {
+	return getExperimentRequestValidator().Struct(q)
+}
+
+func (q *ExperimentAnalysisQuery) Validate() error {
+	return getExperimentRequestValidator().Struct(q)
+}
+
+type PhaseResponse struct {
+	Kind  experiment.PhaseKind `json:"kind"`
+	Start string               `json:"start"`
+	End   string               `json:"end"`
+}
+
+func NewPhaseResponse(p experiment.Phase) PhaseResponse {
+	return PhaseResponse{
+		Kind:  p.Kind,
+		Start: p.Start.Format(dateLayout),
+		End:   p.End.Format(dateLayout),
+	}
+}
+
+type ExperimentResponse struct {
+	ID                 uuid.UUID       `json:"id"`
+	UserID             uuid.UUID       `json:"userId"`
+	OutcomeParameterID uuid.UUID       `json:"outcomeParameterId"`
+	Name               string          `json:"name"`
+	Intervention       string          `json:"intervention"`
+	Phases             []PhaseResponse `json:"phases"`
+	WashoutDays        int             `json:"washoutDays"`
+	CreatedAt          time.Time       `json:"createdAt"`
+	UpdatedAt          time.Time       `json:"updatedAt"`
+}
+
+func NewExperimentResponse(e *experiment.Experiment) ExperimentResponse {
+	phases := []PhaseResponse{}
+	for _, p := range e.Phases {
+		phases = append(phases, NewPhaseResponse(p))
+	}
+
+	return ExperimentResponse{
+		ID:                 e.ID,
+		UserID:             e.UserID,
+		OutcomeParameterID: e.OutcomeParameterID,
+		Name:               e.Name,
+		Intervention:       e.Intervention,
+		Phases:             phases,
+		WashoutDays:        e.WashoutDays,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+type DayPhaseResponse struct {
+	Date       string               `json:"date"`
+	Kind       experiment.PhaseKind `json:"kind"`
+	PhaseIndex int                  `json:"phaseIndex"`
+	Washout    bool                 `json:"washout"`
+}
+
+func NewDayPhaseResponse(d experiment.DayPhase) DayPhaseResponse {
+	return DayPhaseResponse{
+		Date:       d.Date.Format(dateLayout),
+		Kind:       d.Kind,
+		PhaseIndex: d.PhaseIndex,
+		Washout:    d.Washout,
+	}
+}
+
+type PhaseSummaryResponse struct {
+	PhaseResponse
+	Days int     `json:"days"`
+	Mean float64 `json:"mean"`
+}
+
+type RandomizationTestResponse struct {
+	Permutations int     `json:"permutations"`
+	Exact        bool    `json:"exact"`
+	PValue       float64 `json:"pValue"`
+}
+
+type ObservedDayResponse struct {
+	DayPhaseResponse
+	Value float64 `json:"value"`
+}
+
+type ExperimentAnalysisResponse struct {
+	ExperimentID     uuid.UUID                 `json:"experimentId"`
+	Phases           []PhaseSummaryResponse    `json:"phases"`
+	BaselineDays     int                       `json:"baselineDays"`
+	InterventionDays int                       `json:"interventionDays"`
+	BaselineMean     float64                   `json:"baselineMean"`
+	InterventionMean float64                   `json:"interventionMean"`
+	Difference       float64                   `json:"difference"`
+	EffectSize       float64                   `json:"effectSize"`
+	DayTest          RandomizationTestResponse `json:"dayTest"`
+	PhaseTest        RandomizationTestResponse `json:"phaseTest"`
+	Days             []ObservedDayResponse     `json:"days"`
+}
+
+func NewRandomizationTestResponse(t experiment.RandomizationTest) RandomizationTestResponse {
+	return RandomizationTestResponse{
+		Permutations: t.Permutations,
+		Exact:        t.Exact,
+		PValue:       t.PValue,
+	}
+}
+
+func NewExperimentAnalysisResponse(r *experiment.Result) ExperimentAnalysisResponse {
+	phases := []PhaseSummaryResponse{}
+	for _, p := range r.Phases {
+		phases = append(phases, PhaseSummaryResponse{
+			PhaseResponse: NewPhaseResponse(p.Phase),
+			Days:          p.Days,
+			Mean:          p.Mean,
+		})
+	}
+
+	days := []ObservedDayResponse{}
+	for _, d := range r.Days {
+		days = append(days, ObservedDayResponse{
+			DayPhaseResponse: NewDayPhaseResponse(d.DayPhase),
+			Value:            d.Value,
+		})
+	}
+
+	return ExperimentAnalysisResponse{
+		ExperimentID:     r.ExperimentID,
+		Phases:           phases,
+		BaselineDays:     r.BaselineDays,
+		InterventionDays: r.InterventionDays,
+		BaselineMean:     r.BaselineMean,
+		InterventionMean: r.InterventionMean,
+		Difference:       r.Difference,
+		EffectSize:       r.EffectSize,
+		DayTest:          NewRandomizationTestResponse(r.DayTest),
+		PhaseTest:        NewRandomizationTestResponse(r.PhaseTest),
+		Days:             days,
+	}
+}
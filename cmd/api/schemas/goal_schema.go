@@ -0,0 +1,147 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/goal"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CreateGoalRequest struct {
+	ParameterID uuid.UUID          `json:"parameterId" validate:"required,uuid4"`
+	Kind        goal.Kind          `json:"kind" validate:"required,oneof=target range total"`
+	Target      float64            `json:"target,omitempty"`
+	Min         *float64           `json:"min,omitempty"`
+	Max         *float64           `json:"max,omitempty"`
+	Period      goal.Period        `json:"period,omitempty" validate:"omitempty,oneof=day week"`
+	Aggregation series.Aggregation `json:"aggregation,omitempty" validate:"omitempty,oneof=mean sum min max count"`
+	StartDate   string             `json:"startDate,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Deadline    string             `json:"deadline,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// Start returns the validated start date, or the zero time for today.
+func (r *CreateGoalRequest) Start() time.Time {
+	start, _ := time.Parse(dateLayout, r.StartDate)
+	return start
+}
+
+// DeadlineDate returns the validated deadline, if one was given.
+func (r *CreateGoalRequest) DeadlineDate() *time.Time {
+	deadline, err := time.Parse(dateLayout, r.Deadline)
+	if err != nil {
+		return nil
+	}
+
+	return &deadline
+}
+
+type GoalProgressQuery struct {
+	AsOf string `query:"asOf" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// Time returns the requested reference time, or the zero time for now.
+func (q *GoalProgressQuery) Time() time.Time {
+	asOf, _ := time.Parse(time.RFC3339, q.AsOf)
+	return asOf
+}
+
+func getGoalRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *CreateGoalRequest) Validate() error {
+	return getGoalRequestValidator().Struct(r)
+}
+
+func (q *GoalProgressQuery) Validate() error {
+	return getGoalRequestValidator().Struct(q)
+}
+
+type GoalResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"userId"`
+	ParameterID uuid.UUID          `json:"parameterId"`
+	Kind        goal.Kind          `json:"kind"`
+	Target      float64            `json:"target"`
+	Min         *float64           `json:"min,omitempty"`
+	Max         *float64           `json:"max,omitempty"`
+	Period      goal.Period        `json:"period,omitempty"`
+	Aggregation series.Aggregation `json:"aggregation,omitempty"`
+	StartDate   string             `json:"startDate"`
+	Deadline    string             `json:"deadline,omitempty"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+func NewGoalResponse(g *goal.Goal) GoalResponse {
+	response := GoalResponse{
+		ID:          g.ID,
+		UserID:      g.UserID,
+		ParameterID: g.ParameterID,
+		Kind:        g.Kind,
+		Target:      g.Target,
+		Min:         g.Min,
+		Max:         g.Max,
+		Period:      g.Period,
+		Aggregation: g.Aggregation,
+		StartDate:   g.StartDate.Format(dateLayout),
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+	if g.Deadline != nil {
+		response.Deadline = g.Deadline.Format(dateLayout)
+	}
+
+	return response
+}
+
+type PeriodProgressResponse struct {
+	Start string  `json:"start"`
+	End   string  `json:"end"`
+	Value float64 `json:"value"`
+	Days  int     `json:"days"`
+	Met   bool    `json:"met"`
+}
+
+type GoalProgressResponse struct {
+	GoalID              uuid.UUID                `json:"goalId"`
+	Kind                goal.Kind                `json:"kind"`
+	AsOf                time.Time                `json:"asOf"`
+	Status              goal.Status              `json:"status"`
+	Current             float64                  `json:"current"`
+	Percent             float64                  `json:"percent"`
+	SlopePerDay         float64                  `json:"slopePerDay"`
+	ProjectedCompletion string                   `json:"projectedCompletion,omitempty"`
+	Periods             []PeriodProgressResponse `json:"periods"`
+}
+
+func NewGoalProgressResponse(p *goal.Progress) GoalProgressResponse {
+	periods := []PeriodProgressResponse{}
+	for _, period := range p.Periods {
+		periods = append(periods, PeriodProgressResponse{
+			Start: period.Start.Format(dateLayout),
+			End:   period.End.Format(dateLayout),
+			Value: period.Value,
+			Days:  period.Days,
+			Met:   period.Met,
+		})
+	}
+
+	response := GoalProgressResponse{
+		GoalID:      p.GoalID,
+		Kind:        p.Kind,
+		AsOf:        p.AsOf,
+		Status:      p.Status,
+		Current:     p.Current,
+		Percent:     p.Percent,
+		SlopePerDay: p.SlopePerDay,
+		Periods:     periods,
+	}
+	if p.ProjectedCompletion != nil {
+		response.ProjectedCompletion = p.ProjectedCompletion.Format(dateLayout)
+	}
+
+	return response
+}
@@ -0,0 +1,86 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/habit"
+	"github.com/google/uuid"
+)
+
+type StreakResponse struct {
+	Length int        `json:"length"`
+	Start  *time.Time `json:"start,omitempty"`
+	End    *time.Time `json:"end,omitempty"`
+}
+
+type PeriodRateResponse struct {
+	Start     time.Time `json:"start"`
+	Hits      int       `json:"hits"`
+	Scheduled int       `json:"scheduled"`
+	Rate      float64   `json:"rate"`
+}
+
+type HabitDayResponse struct {
+	Date      time.Time       `json:"date"`
+	Status    habit.DayStatus `json:"status"`
+	Scheduled bool            `json:"scheduled"`
+}
+
+type StreaksResponse struct {
+	ParameterID    uuid.UUID            `json:"parameterId"`
+	Schedule       []string             `json:"schedule,omitempty"`
+	Current        StreakResponse       `json:"current"`
+	Longest        StreakResponse       `json:"longest"`
+	Hits           int                  `json:"hits"`
+	Scheduled      int                  `json:"scheduled"`
+	CompletionRate float64              `json:"completionRate"`
+	Weekly         []PeriodRateResponse `json:"weekly"`
+	Monthly        []PeriodRateResponse `json:"monthly"`
+	Calendar       []HabitDayResponse   `json:"calendar"`
+}
+
+func NewStreakResponse(s habit.Streak) StreakResponse {
+	return StreakResponse{
+		Length: s.Length,
+		Start:  s.Start,
+		End:    s.End,
+	}
+}
+
+func newPeriodRateResponses(rates []habit.PeriodRate) []PeriodRateResponse {
+	response := []PeriodRateResponse{}
+	for _, r := range rates {
+		response = append(response, PeriodRateResponse{
+			Start:     r.Start,
+			Hits:      r.Hits,
+			Scheduled: r.Scheduled,
+			Rate:      r.Rate,
+		})
+	}
+
+	return response
+}
+
+func NewStreaksResponse(s *habit.Streaks) StreaksResponse {
+	calendar := []HabitDayResponse{}
+	for _, d := range s.Calendar {
+		calendar = append(calendar, HabitDayResponse{
+			Date:      d.Date,
+			Status:    d.Status,
+			Scheduled: d.Scheduled,
+		})
+	}
+
+	return StreaksResponse{
+		ParameterID:    s.ParameterID,
+		Schedule:       formatSchedule(s.Schedule),
+		Current:        NewStreakResponse(s.Current),
+		Longest:        NewStreakResponse(s.Longest),
+		Hits:           s.Hits,
+		Scheduled:      s.Scheduled,
+		CompletionRate: s.CompletionRate,
+		Weekly:         newPeriodRateResponses(s.Weekly),
+		Monthly:        newPeriodRateResponses(s.Monthly),
+		Calendar:       calendar,
+	}
+}
@@ -0,0 +1,92 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/hypothesis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type CreateHypothesisRequest struct {
+	CauseParameterID  uuid.UUID            `json:"causeParameterId" validate:"required,uuid4"`
+	EffectParameterID uuid.UUID            `json:"effectParameterId" validate:"required,uuid4"`
+	Statement         string               `json:"statement" validate:"required,min=2,max=500"`
+	ExpectedDirection hypothesis.Direction `json:"expectedDirection" validate:"required,oneof=positive negative"`
+	MinLagDays        int                  `json:"minLagDays" validate:"min=0,max=14"`
+	MaxLagDays        int                  `json:"maxLagDays" validate:"min=0,max=14,gtefield=MinLagDays"`
+	Aggregation       series.Aggregation   `json:"aggregation,omitempty" validate:"omitempty,oneof=mean sum min max count"`
+}
+
+func getHypothesisRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *CreateHypothesisRequest) Validate() error {
+	return getHypothesisRequestValidator().Struct(r)
+}
+
+type HypothesisResponse struct {
+	ID                uuid.UUID            `json:"id"`
+	UserID            uuid.UUID            `json:"userId"`
+	CauseParameterID  uuid.UUID            `json:"causeParameterId"`
+	EffectParameterID uuid.UUID            `json:"effectParameterId"`
+	Statement         string               `json:"statement"`
+	ExpectedDirection hypothesis.Direction `json:"expectedDirection"`
+	MinLagDays        int                  `json:"minLagDays"`
+	MaxLagDays        int                  `json:"maxLagDays"`
+	Aggregation       series.Aggregation   `json:"aggregation,omitempty"`
+	Verdict           hypothesis.Verdict   `json:"verdict,omitempty"`
+	LastEvaluatedAt   *time.Time           `json:"lastEvaluatedAt,omitempty"`
+	CreatedAt         time.Time            `json:"createdAt"`
+	UpdatedAt         time.Time            `json:"updatedAt"`
+}
+
+func NewHypothesisResponse(h *hypothesis.Hypothesis) HypothesisResponse {
+	return HypothesisResponse{
+		ID:                h.ID,
+		UserID:            h.UserID,
+		CauseParameterID:  h.CauseParameterID,
+		EffectParameterID: h.EffectParameterID,
+		Statement:         h.Statement,
+		ExpectedDirection: h.ExpectedDirection,
+		MinLagDays:        h.MinLagDays,
+		MaxLagDays:        h.MaxLagDays,
+		Aggregation:       h.Aggregation,
+		Verdict:           h.Verdict,
+		LastEvaluatedAt:   h.LastEvaluatedAt,
+		CreatedAt:         h.CreatedAt,
+		UpdatedAt:         h.UpdatedAt,
+	}
+}
+
+type EvaluationResponse struct {
+	ID           uuid.UUID          `json:"id"`
+	HypothesisID uuid.UUID          `json:"hypothesisId"`
+	EvaluatedAt  time.Time          `json:"evaluatedAt"`
+	Days         int                `json:"days"`
+	Effect       float64            `json:"effect"`
+	Lower        float64            `json:"lower"`
+	Upper        float64            `json:"upper"`
+	Level        float64            `json:"level"`
+	PValue       float64            `json:"pValue"`
+	Verdict      hypothesis.Verdict `json:"verdict"`
+	Changed      bool               `json:"changed"`
+}
+
+func NewEvaluationResponse(e *hypothesis.Evaluation) EvaluationResponse {
+	return EvaluationResponse{
+		ID:           e.ID,
+		HypothesisID: e.HypothesisID,
+		EvaluatedAt:  e.EvaluatedAt,
+		Days:         e.Days,
+		Effect:       e.Effect,
+		Lower:        e.Lower,
+		Upper:        e.Upper,
+		Level:        hypothesis.Level,
+		PValue:       e.PValue,
+		Verdict:      e.Verdict,
+		Changed:      e.Changed,
+	}
+}
@@ -4,6 +4,10 @@ import (
 	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -12,6 +16,7 @@ type CreateMeasurementRequest struct {
 	ParameterID uuid.UUID   `json:"parameterId" validate:"required,uuid4"`
 	Notes       string      `json:"notes,omitempty" validate:"omitempty"`
 	Value       interface{} `json:"value" validate:"required"`
+	Unit        string      `json:"unit,omitempty" validate:"omitempty,max=20"`
 	Timestamp   time.Time   `json:"timestamp,omitempty" validate:"omitempty"`
 }
 
@@ -23,6 +28,136 @@ func (r *CreateMeasurementRequest) Validate() error {
 	return getMeasurementRequestValidator().Struct(r)
 }
 
+// CreateMeasurementBatchRequest holds the items of a batch. Only the envelope
+// is validated here; each item is validated on its own so that one bad item
+// does not reject the rest.
+type CreateMeasurementBatchRequest struct {
+	Measurements []CreateMeasurementRequest `json:"measurements" validate:"required,min=1,max=500"`
+}
+
+func (r *CreateMeasurementBatchRequest) Validate() error {
+	return getMeasurementRequestValidator().Struct(r)
+}
+
+// MeasurementBatchItemResponse is the outcome of the batch item at Index,
+// with the HTTP status a single create of the item would have returned.
+type MeasurementBatchItemResponse struct {
+	Index       int                  `json:"index"`
+	Status      int                  `json:"status"`
+	Measurement *MeasurementResponse `json:"measurement,omitempty"`
+	Error       string               `json:"error,omitempty"`
+	Details     string               `json:"details,omitempty"`
+}
+
+type MeasurementBatchResponse struct {
+	Created int                            `json:"created"`
+	Failed  int                            `json:"failed"`
+	Items   []MeasurementBatchItemResponse `json:"items"`
+}
+
+func NewMeasurementBatchResponse(items []MeasurementBatchItemResponse) MeasurementBatchResponse {
+	response := MeasurementBatchResponse{Items: items}
+	for _, item := range items {
+		if item.Measurement != nil {
+			response.Created++
+		} else {
+			response.Failed++
+		}
+	}
+
+	return response
+}
+
+type MeasurementListQuery struct {
+	PageQuery
+	FieldsQuery
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	Sort string `query:"sort" validate:"omitempty,oneof=timestamp -timestamp value -value createdAt -createdAt"`
+	// Filter is a value and notes predicate such as "value < 6" or
+	// `notes contains "run"`.
+	Filter string `query:"filter" validate:"omitempty,max=500"`
+}
+
+func (q *MeasurementListQuery) Validate() error {
+	return getMeasurementRequestValidator().Struct(q)
+}
+
+// Options converts the validated query into measurement list options. It
+// fails when the filter does not parse.
+func (q *MeasurementListQuery) Options() (measurement.ListOptions, error) {
+	var options measurement.ListOptions
+	if from, err := time.Parse(time.RFC3339, q.From); err == nil {
+		options.From = &from
+	}
+	if to, err := time.Parse(time.RFC3339, q.To); err == nil {
+		options.To = &to
+	}
+
+	if q.Sort != "" {
+		field, descending := parseSort(q.Sort)
+		options.Sort = measurement.Sort{Field: measurement.SortField(field), Descending: descending}
+	}
+
+	for _, field := range q.FieldList() {
+		switch field {
+		case "unit":
+			// The display unit is resolved from the value.
+			options.Fields = append(options.Fields, measurement.FieldValue)
+		case "outlier":
+			// Outlier marks come from the flags, not the measurement.
+		default:
+			options.Fields = append(options.Fields, measurement.Field(field))
+		}
+	}
+	if len(q.FieldList()) > 0 && len(options.Fields) == 0 {
+		// Only derived fields were asked for, so the required ones suffice.
+		options.Fields = []measurement.Field{measurement.FieldID}
+	}
+
+	if q.Filter != "" {
+		predicate, err := measurement.ParsePredicate(q.Filter)
+		if err != nil {
+			return measurement.ListOptions{}, err
+		}
+		options.Predicate = predicate
+	}
+
+	return options, nil
+}
+
+type MeasurementDeleteQuery struct {
+	From string `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To   string `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// DryRun reports how many measurements would be deleted.
+	DryRun bool `query:"dryRun"`
+}
+
+func (q *MeasurementDeleteQuery) Validate() error {
+	return getMeasurementRequestValidator().Struct(q)
+}
+
+// Input converts the validated query into a bulk delete of the parameter's
+// measurements.
+func (q *MeasurementDeleteQuery) Input(parameterID uuid.UUID) measurement.DeleteMeasurementsInput {
+	input := measurement.DeleteMeasurementsInput{ParameterID: parameterID, DryRun: q.DryRun}
+	if from, err := time.Parse(time.RFC3339, q.From); err == nil {
+		input.From = &from
+	}
+	if to, err := time.Parse(time.RFC3339, q.To); err == nil {
+		input.To = &to
+	}
+
+	return input
+}
+
+type MeasurementDeleteResponse struct {
+	// Count is the number of measurements deleted or, on a dry run, the
+	// number that would be.
+	Count  int  `json:"count"`
+	DryRun bool `json:"dryRun"`
+}
+
 type MeasurementResponse struct {
 	ID          uuid.UUID            `json:"id"`
 	Type        measurement.DataType `json:"type"`
@@ -31,6 +166,8 @@ type MeasurementResponse struct {
 	Timestamp   time.Time            `json:"timestamp"`
 	Notes       string               `json:"notes,omitempty"`
 	Value       interface{}          `json:"value"`
+	Unit        string               `json:"unit,omitempty"`
+	Outlier     *OutlierMarkResponse `json:"outlier,omitempty"`
 	CreatedAt   time.Time            `json:"createdAt"`
 	UpdatedAt   time.Time            `json:"updatedAt"`
 }
@@ -54,7 +191,112 @@ func NewMeasurementResponse(m measurement.Measurement) MeasurementResponse {
 			CreatedAt:   floatMeasurement.GetCreatedAt(),
 			UpdatedAt:   floatMeasurement.GetUpdatedAt(),
 		}
+	case measurement.DataTypeBoolean:
+		booleanMeasurement, ok := m.(*measurement.BooleanMeasurement)
+		if !ok {
+			return MeasurementResponse{}
+		}
+
+		return MeasurementResponse{
+			ID:          booleanMeasurement.GetID(),
+			Type:        booleanMeasurement.GetType(),
+			UserID:      booleanMeasurement.GetUserID(),
+			ParameterID: booleanMeasurement.GetParameterID(),
+			Timestamp:   booleanMeasurement.GetTimestamp(),
+			Notes:       booleanMeasurement.GetNotes(),
+			Value:       booleanMeasurement.GetValue(),
+			CreatedAt:   booleanMeasurement.GetCreatedAt(),
+			UpdatedAt:   booleanMeasurement.GetUpdatedAt(),
+		}
 	default:
 		return MeasurementResponse{}
 	}
 }
+
+// DisplayUnits maps parameters to the unit their values are shown in.
+type DisplayUnits map[uuid.UUID]displayUnit
+
+type displayUnit struct {
+	stored string
+	shown  string
+}
+
+// NewDisplayUnits shows every parameter with a registered unit in the owner's
+// preferred unit for its dimension, and all others in their own unit.
+func NewDisplayUnits(owner *user.User, parameters []*parameter.Parameter) DisplayUnits {
+	display := make(DisplayUnits, len(parameters))
+	for _, p := range parameters {
+		shown := p.Unit
+		if u, registered := p.RegisteredUnit(); registered {
+			if preferred, ok := owner.PreferredUnit(u.Dimension); ok {
+				shown = preferred
+			}
+		}
+
+		display[p.ID] = displayUnit{stored: p.Unit, shown: shown}
+	}
+
+	return display
+}
+
+// Apply converts a measurement response into its display unit.
+func (d DisplayUnits) Apply(r MeasurementResponse) MeasurementResponse {
+	unit, ok := d[r.ParameterID]
+	value, isFloat := r.Value.(float64)
+	if !ok || !isFloat {
+		return r
+	}
+
+	r.Unit = unit.stored
+	if unit.shown != unit.stored {
+		if converted, err := units.Convert(value, unit.stored, unit.shown); err == nil {
+			r.Value, r.Unit = converted, unit.shown
+		}
+	}
+
+	return r
+}
+
+// StoredPredicate converts the number operands of a predicate, written in
+// the display units, to the unit each parameter's values are stored in.
+func (d DisplayUnits) StoredPredicate(predicate *measurement.Predicate) (*measurement.Predicate, error) {
+	for parameterID, unit := range d {
+		if predicate == nil || unit.shown == unit.stored {
+			continue
+		}
+
+		var err error
+		predicate, err = predicate.ConvertFor(parameterID, func(value float64) (float64, error) {
+			return units.Convert(value, unit.shown, unit.stored)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return predicate, nil
+}
+
+// NewMeasurementListResponse marks every measurement that has an outlier flag
+// and shows values in their display unit.
+func NewMeasurementListResponse(
+	measurements []measurement.Measurement,
+	flags []*outlier.Flag,
+	display DisplayUnits,
+) []MeasurementResponse {
+	flagsByMeasurement := make(map[uuid.UUID]*outlier.Flag, len(flags))
+	for _, f := range flags {
+		flagsByMeasurement[f.MeasurementID] = f
+	}
+
+	response := []MeasurementResponse{}
+	for _, measurementItem := range measurements {
+		item := display.Apply(NewMeasurementResponse(measurementItem))
+		if f, ok := flagsByMeasurement[measurementItem.GetID()]; ok {
+			item.Outlier = NewOutlierMarkResponse(f)
+		}
+		response = append(response, item)
+	}
+
+	return response
+}
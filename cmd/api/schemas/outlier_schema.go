@@ -0,0 +1,79 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+type DetectOutliersRequest struct {
+	Method    outlier.Method `json:"method" validate:"required,oneof=zscore mad iqr"`
+	Threshold float64        `json:"threshold,omitempty" validate:"omitempty,gt=0"`
+}
+
+type ReviewOutlierRequest struct {
+	Status outlier.Status `json:"status" validate:"required,oneof=confirmed dismissed"`
+}
+
+func getOutlierRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (r *DetectOutliersRequest) Validate() error {
+	return getOutlierRequestValidator().Struct(r)
+}
+
+func (r *ReviewOutlierRequest) Validate() error {
+	return getOutlierRequestValidator().Struct(r)
+}
+
+type OutlierFlagResponse struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"userId"`
+	ParameterID   uuid.UUID      `json:"parameterId"`
+	MeasurementID uuid.UUID      `json:"measurementId"`
+	Method        outlier.Method `json:"method"`
+	Threshold     float64        `json:"threshold"`
+	Score         float64        `json:"score"`
+	Value         float64        `json:"value"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Status        outlier.Status `json:"status"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+func NewOutlierFlagResponse(f *outlier.Flag) OutlierFlagResponse {
+	return OutlierFlagResponse{
+		ID:            f.ID,
+		UserID:        f.UserID,
+		ParameterID:   f.ParameterID,
+		MeasurementID: f.MeasurementID,
+		Method:        f.Method,
+		Threshold:     f.Threshold,
+		Score:         f.Score,
+		Value:         f.Value,
+		Timestamp:     f.Timestamp,
+		Status:        f.Status,
+		CreatedAt:     f.CreatedAt,
+		UpdatedAt:     f.UpdatedAt,
+	}
+}
+
+// OutlierMarkResponse is the short form of a flag embedded into measurement responses.
+type OutlierMarkResponse struct {
+	FlagID uuid.UUID      `json:"flagId"`
+	Method outlier.Method `json:"method"`
+	Score  float64        `json:"score"`
+	Status outlier.Status `json:"status"`
+}
+
+func NewOutlierMarkResponse(f *outlier.Flag) *OutlierMarkResponse {
+	return &OutlierMarkResponse{
+		FlagID: f.ID,
+		Method: f.Method,
+		Score:  f.Score,
+		Status: f.Status,
+	}
+}
@@ -0,0 +1,44 @@
+package schemas
+
+import (
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/go-playground/validator/v10"
+)
+
+type PageQuery struct {
+	// Limit defaults to pagination.DefaultLimit.
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=500"`
+	Cursor string `query:"cursor" validate:"omitempty,base64rawurl"`
+}
+
+func getPageQueryValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (q *PageQuery) Validate() error {
+	return getPageQueryValidator().Struct(q)
+}
+
+// Page converts the validated query into a page request.
+func (q *PageQuery) Page() pagination.Page {
+	limit := q.Limit
+	if limit == 0 {
+		limit = pagination.DefaultLimit
+	}
+
+	return pagination.Page{Limit: limit, Cursor: q.Cursor}
+}
+
+type PageResponse[T any] struct {
+	Items []T `json:"items"`
+	// Next is the cursor of the following page, omitted on the last one.
+	Next string `json:"next,omitempty"`
+}
+
+func NewPageResponse[T any](items []T, next string) PageResponse[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return PageResponse[T]{Items: items, Next: next}
+}
@@ -1,6 +1,7 @@
 package schemas
 
 import (
+	"strings"
 	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
@@ -12,18 +13,74 @@ type CreateParameterRequest struct {
 	UserID      uuid.UUID          `json:"userId" validate:"required,uuid4"`
 	Name        string             `json:"name" validate:"required,min=2,max=100"`
 	Description string             `json:"description,omitempty"`
-	DataType    parameter.DataType `json:"dataType" validate:"required,oneof=float"`
+	DataType    parameter.DataType `json:"dataType" validate:"required,oneof=float boolean"`
 	Unit        string             `json:"unit,omitempty"`
+	Formula     string             `json:"formula,omitempty" validate:"max=1000"`
+	Schedule    []string           `json:"schedule,omitempty" validate:"omitempty,unique,dive,weekday"`
 }
 
 type UpdateParameterRequest struct {
-	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
-	Description *string `json:"description,omitempty" validate:"omitempty"`
-	Unit        *string `json:"unit,omitempty" validate:"omitempty"`
+	Name        *string   `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description *string   `json:"description,omitempty" validate:"omitempty"`
+	Unit        *string   `json:"unit,omitempty" validate:"omitempty"`
+	Formula     *string   `json:"formula,omitempty" validate:"omitempty,max=1000"`
+	Schedule    *[]string `json:"schedule,omitempty" validate:"omitempty,unique,dive,weekday"`
 }
 
 func getParameterRequestValidator() *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+	registerWeekdayValidation(validate)
+
+	return validate
+}
+
+// registerWeekdayValidation adds the "weekday" tag, which accepts lower-case
+// weekday names.
+func registerWeekdayValidation(validate *validator.Validate) {
+	_ = validate.RegisterValidation("weekday", func(fl validator.FieldLevel) bool {
+		_, ok := weekdays[fl.Field().String()]
+		return ok
+	})
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ParseSchedule converts validated lower-case weekday names.
+func ParseSchedule(names []string) []time.Weekday {
+	schedule := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		schedule = append(schedule, weekdays[name])
+	}
+
+	return schedule
+}
+
+func formatSchedule(schedule []time.Weekday) []string {
+	names := make([]string, 0, len(schedule))
+	for _, weekday := range schedule {
+		names = append(names, strings.ToLower(weekday.String()))
+	}
+
+	return names
+}
+
+// ScheduleWeekdays returns the requested schedule, or nil when it is left
+// unchanged.
+func (r *UpdateParameterRequest) ScheduleWeekdays() *[]time.Weekday {
+	if r.Schedule == nil {
+		return nil
+	}
+
+	schedule := ParseSchedule(*r.Schedule)
+	return &schedule
 }
 
 func (r *CreateParameterRequest) Validate() error {
@@ -34,6 +91,31 @@ func (r *UpdateParameterRequest) Validate() error {
 	return getParameterRequestValidator().Struct(r)
 }
 
+type ParameterListQuery struct {
+	PageQuery
+	FieldsQuery
+	Sort string `query:"sort" validate:"omitempty,oneof=createdAt -createdAt name -name"`
+}
+
+func (q *ParameterListQuery) Validate() error {
+	return getParameterRequestValidator().Struct(q)
+}
+
+// Options converts the validated query into parameter list options.
+func (q *ParameterListQuery) Options() parameter.ListOptions {
+	var options parameter.ListOptions
+	if q.Sort != "" {
+		field, descending := parseSort(q.Sort)
+		options.Sort = parameter.Sort{Field: parameter.SortField(field), Descending: descending}
+	}
+
+	for _, field := range q.FieldList() {
+		options.Fields = append(options.Fields, parameter.Field(field))
+	}
+
+	return options
+}
+
 type ParameterResponse struct {
 	ID          uuid.UUID          `json:"id"`
 	UserID      uuid.UUID          `json:"userId"`
@@ -41,6 +123,8 @@ type ParameterResponse struct {
 	Description string             `json:"description,omitempty"`
 	DataType    parameter.DataType `json:"dataType"`
 	Unit        string             `json:"unit,omitempty"`
+	Formula     string             `json:"formula,omitempty"`
+	Schedule    []string           `json:"schedule,omitempty"`
 	CreatedAt   time.Time          `json:"createdAt"`
 	UpdatedAt   time.Time          `json:"updatedAt"`
 }
@@ -53,6 +137,8 @@ func NewParameterResponse(p *parameter.Parameter) ParameterResponse {
 		Description: p.Description,
 		DataType:    p.DataType,
 		Unit:        p.Unit,
+		Formula:     p.Formula,
+		Schedule:    formatSchedule(p.Schedule),
 		CreatedAt:   p.CreatedAt,
 		UpdatedAt:   p.UpdatedAt,
 	}
@@ -0,0 +1,57 @@
+package schemas
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+type FieldsQuery struct {
+	// Fields is a comma-separated list of response fields. Empty returns
+	// all of them.
+	Fields string `query:"fields" validate:"omitempty,max=500"`
+}
+
+// FieldList returns the requested fields, or nil for all of them.
+func (q *FieldsQuery) FieldList() []string {
+	var fields []string
+	for _, field := range strings.Split(q.Fields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// parseSort splits a sort parameter such as "-timestamp" into its field and
+// direction.
+func parseSort(sort string) (string, bool) {
+	field, descending := strings.CutPrefix(sort, "-")
+	return field, descending
+}
+
+// Project keeps the requested fields of every item, and always its id.
+func Project[T any](items []T, fields []string) ([]map[string]json.RawMessage, error) {
+	projected := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		itemJSON, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+
+		var all map[string]json.RawMessage
+		if err = json.Unmarshal(itemJSON, &all); err != nil {
+			return nil, err
+		}
+
+		kept := map[string]json.RawMessage{"id": all["id"]}
+		for _, field := range fields {
+			if value, ok := all[field]; ok {
+				kept[field] = value
+			}
+		}
+		projected = append(projected, kept)
+	}
+
+	return projected, nil
+}
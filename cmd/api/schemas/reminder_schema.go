@@ -0,0 +1,112 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/reminder"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+const timeOfDayLayout = "15:04"
+
+type CreateReminderScheduleRequest struct {
+	ParameterID     uuid.UUID     `json:"parameterId" validate:"required,uuid4"`
+	Kind            reminder.Kind `json:"kind" validate:"required,oneof=daily interval"`
+	Time            string        `json:"time,omitempty" validate:"required_if=Kind daily,omitempty,datetime=15:04"`
+	Weekdays        []string      `json:"weekdays,omitempty" validate:"omitempty,unique,dive,weekday"`
+	IntervalMinutes int           `json:"intervalMinutes,omitempty" validate:"required_if=Kind interval,omitempty,min=60"`
+	StartAt         time.Time     `json:"startAt,omitempty"`
+	GraceMinutes    int           `json:"graceMinutes,omitempty" validate:"min=0"`
+}
+
+// TimeOfDay returns the validated hour and minute of daily reminders.
+func (r *CreateReminderScheduleRequest) TimeOfDay() (int, int) {
+	t, err := time.Parse(timeOfDayLayout, r.Time)
+	if err != nil {
+		return 0, 0
+	}
+
+	return t.Hour(), t.Minute()
+}
+
+type ListRemindersQuery struct {
+	Status reminder.Status `query:"status" validate:"omitempty,oneof=pending completed missed"`
+}
+
+func getReminderRequestValidator() *validator.Validate {
+	validate := validator.New()
+	registerWeekdayValidation(validate)
+
+	return validate
+}
+
+func (r *CreateReminderScheduleRequest) Validate() error {
+	return getReminderRequestValidator().Struct(r)
+}
+
+func (q *ListRemindersQuery) Validate() error {
+	return getReminderRequestValidator().Struct(q)
+}
+
+type ReminderScheduleResponse struct {
+	ID              uuid.UUID     `json:"id"`
+	UserID          uuid.UUID     `json:"userId"`
+	ParameterID     uuid.UUID     `json:"parameterId"`
+	Kind            reminder.Kind `json:"kind"`
+	Time            string        `json:"time,omitempty"`
+	Weekdays        []string      `json:"weekdays,omitempty"`
+	IntervalMinutes int           `json:"intervalMinutes,omitempty"`
+	StartAt         time.Time     `json:"startAt"`
+	GraceMinutes    int           `json:"graceMinutes"`
+	LastDueAt       *time.Time    `json:"lastDueAt,omitempty"`
+	CreatedAt       time.Time     `json:"createdAt"`
+	UpdatedAt       time.Time     `json:"updatedAt"`
+}
+
+func NewReminderScheduleResponse(s *reminder.Schedule) ReminderScheduleResponse {
+	response := ReminderScheduleResponse{
+		ID:              s.ID,
+		UserID:          s.UserID,
+		ParameterID:     s.ParameterID,
+		Kind:            s.Kind,
+		IntervalMinutes: int(s.Interval / time.Minute),
+		StartAt:         s.StartAt,
+		GraceMinutes:    int(s.Grace / time.Minute),
+		LastDueAt:       s.LastDueAt,
+		CreatedAt:       s.CreatedAt,
+		UpdatedAt:       s.UpdatedAt,
+	}
+	if s.Kind == reminder.KindDaily {
+		response.Time = time.Date(0, 1, 1, s.Hour, s.Minute, 0, 0, time.UTC).Format(timeOfDayLayout)
+		response.Weekdays = formatSchedule(s.Weekdays)
+	}
+
+	return response
+}
+
+type ReminderResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	ScheduleID    uuid.UUID       `json:"scheduleId"`
+	UserID        uuid.UUID       `json:"userId"`
+	ParameterID   uuid.UUID       `json:"parameterId"`
+	DueAt         time.Time       `json:"dueAt"`
+	ExpiresAt     time.Time       `json:"expiresAt"`
+	Status        reminder.Status `json:"status"`
+	MeasurementID *uuid.UUID      `json:"measurementId,omitempty"`
+	ResolvedAt    *time.Time      `json:"resolvedAt,omitempty"`
+}
+
+func NewReminderResponse(r *reminder.Reminder) ReminderResponse {
+	return ReminderResponse{
+		ID:            r.ID,
+		ScheduleID:    r.ScheduleID,
+		UserID:        r.UserID,
+		ParameterID:   r.ParameterID,
+		DueAt:         r.DueAt,
+		ExpiresAt:     r.ExpiresAt(),
+		Status:        r.Status,
+		MeasurementID: r.MeasurementID,
+		ResolvedAt:    r.ResolvedAt,
+	}
+}
@@ -0,0 +1,173 @@
+package schemas
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/annotation"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// SeriesOptionsQuery holds the query parameters shared by every endpoint that
+// builds daily series.
+type SeriesOptionsQuery struct {
+	TimeRangeQuery
+	Aggregation series.Aggregation `query:"aggregation" validate:"omitempty,oneof=mean sum min max count"`
+	Gap         series.GapStrategy `query:"gap" validate:"omitempty,oneof=drop locf linear zero"`
+	MaxGap      int                `query:"maxGap" validate:"omitempty,min=0"`
+	// ExcludeOutliers drops measurements with a confirmed outlier flag.
+	ExcludeOutliers bool `query:"excludeOutliers"`
+	// ExcludeAnnotated drops the days covered by overlapping annotations.
+	ExcludeAnnotated bool `query:"excludeAnnotated"`
+	// Timezone is the IANA time zone that defines day boundaries. Defaults to UTC.
+	Timezone string `query:"timezone" validate:"omitempty,timezone"`
+}
+
+// Location returns the validated time zone, or nil for UTC.
+func (q *SeriesOptionsQuery) Location() *time.Location {
+	return loadLocation(q.Timezone)
+}
+
+func loadLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil
+	}
+
+	return loc
+}
+
+func (q *SeriesOptionsQuery) GapOptions() series.GapOptions {
+	return series.GapOptions{
+		Strategy: q.Gap,
+		MaxGap:   q.MaxGap,
+	}
+}
+
+func (q *SeriesOptionsQuery) DailySeriesInput(parameterID uuid.UUID) series.GetDailySeriesInput {
+	timeRange := q.TimeRange()
+
+	return series.GetDailySeriesInput{
+		ParameterID:     parameterID,
+		Aggregation:     q.Aggregation,
+		Gaps:            q.GapOptions(),
+		Location:        q.Location(),
+		From:            timeRange.From,
+		To:              timeRange.To,
+		ExcludeOutliers: q.ExcludeOutliers,
+	}
+}
+
+type AlignedSeriesQuery struct {
+	SeriesOptionsQuery
+	XParameterID string `query:"x" validate:"required,uuid"`
+	YParameterID string `query:"y" validate:"required,uuid"`
+}
+
+type ParameterSeriesQuery struct {
+	SeriesOptionsQuery
+	Window int     `query:"window" validate:"omitempty,min=1,max=365"`
+	Alpha  float64 `query:"alpha" validate:"omitempty,gt=0,lte=1"`
+	Span   float64 `query:"span" validate:"omitempty,gt=0,lte=1"`
+}
+
+func getSeriesRequestValidator() *validator.Validate {
+	return validator.New()
+}
+
+func (q *AlignedSeriesQuery) Validate() error {
+	return getSeriesRequestValidator().Struct(q)
+}
+
+func (q *ParameterSeriesQuery) Validate() error {
+	return getSeriesRequestValidator().Struct(q)
+}
+
+type AlignedPointResponse struct {
+	Date     time.Time `json:"date"`
+	X        float64   `json:"x"`
+	Y        float64   `json:"y"`
+	XImputed bool      `json:"xImputed"`
+	YImputed bool      `json:"yImputed"`
+}
+
+type AlignedSeriesResponse struct {
+	XParameterID uuid.UUID              `json:"xParameterId"`
+	YParameterID uuid.UUID              `json:"yParameterId"`
+	Points       []AlignedPointResponse `json:"points"`
+	Imputed      []time.Time            `json:"imputed"`
+	Annotations  []AnnotationResponse   `json:"annotations"`
+}
+
+func NewAlignedSeriesResponse(s *series.AlignedSeries, annotations []*annotation.Annotation) AlignedSeriesResponse {
+	points := []AlignedPointResponse{}
+	for _, p := range s.Points {
+		points = append(points, AlignedPointResponse{
+			Date:     p.Date,
+			X:        p.X,
+			Y:        p.Y,
+			XImputed: p.XImputed,
+			YImputed: p.YImputed,
+		})
+	}
+
+	return AlignedSeriesResponse{
+		XParameterID: s.XParameterID,
+		YParameterID: s.YParameterID,
+		Points:       points,
+		Imputed:      s.ImputedDates(),
+		Annotations:  NewAnnotationResponses(annotations),
+	}
+}
+
+type SmoothedPointResponse struct {
+	Date    time.Time `json:"date"`
+	Value   float64   `json:"value"`
+	Count   int       `json:"count"`
+	Imputed bool      `json:"imputed"`
+	SMA     float64   `json:"sma"`
+	EMA     float64   `json:"ema"`
+	Loess   float64   `json:"loess"`
+}
+
+type SmoothedSeriesResponse struct {
+	ParameterID uuid.UUID               `json:"parameterId"`
+	Aggregation series.Aggregation      `json:"aggregation"`
+	Window      int                     `json:"window"`
+	Alpha       float64                 `json:"alpha"`
+	Span        float64                 `json:"span"`
+	Points      []SmoothedPointResponse `json:"points"`
+	Imputed     []time.Time             `json:"imputed"`
+	Annotations []AnnotationResponse    `json:"annotations"`
+}
+
+func NewSmoothedSeriesResponse(s *series.SmoothedSeries, annotations []*annotation.Annotation) SmoothedSeriesResponse {
+	points := []SmoothedPointResponse{}
+	for _, p := range s.Points {
+		points = append(points, SmoothedPointResponse{
+			Date:    p.Date,
+			Value:   p.Value,
+			Count:   p.Count,
+			Imputed: p.Imputed,
+			SMA:     p.MovingAverage,
+			EMA:     p.ExponentialMovingAverage,
+			Loess:   p.Loess,
+		})
+	}
+
+	return SmoothedSeriesResponse{
+		ParameterID: s.ParameterID,
+		Aggregation: s.Aggregation,
+		Window:      s.Smoothing.Window,
+		Alpha:       s.Smoothing.Alpha,
+		Span:        s.Smoothing.Span,
+		Points:      points,
+		Imputed:     s.ImputedDates(),
+		Annotations: NewAnnotationResponses(annotations),
+	}
+}
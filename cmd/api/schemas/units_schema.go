@@ -0,0 +1,30 @@
+package schemas
+
+import (
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
+)
+
+type UnitResponse struct {
+	Symbol string `json:"symbol"`
+	Name   string `json:"name"`
+}
+
+type DimensionResponse struct {
+	Dimension units.Dimension `json:"dimension"`
+	Units     []UnitResponse  `json:"units"`
+}
+
+func NewDimensionResponse(dimension units.Dimension, dimensionUnits []units.Unit) DimensionResponse {
+	response := DimensionResponse{
+		Dimension: dimension,
+		Units:     []UnitResponse{},
+	}
+	for _, u := range dimensionUnits {
+		response.Units = append(response.Units, UnitResponse{
+			Symbol: u.Symbol,
+			Name:   u.Name,
+		})
+	}
+
+	return response
+}
@@ -4,23 +4,36 @@ import (
 	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
 
 type CreateUserRequest struct {
-	ExternalID string `json:"externalId" validate:"required,uuid4"`
-	FirstName  string `json:"firstName" validate:"required,min=2,max=50"`
-	LastName   string `json:"lastName" validate:"required,min=2,max=50"`
+	ExternalID     string                     `json:"externalId" validate:"required,uuid4"`
+	FirstName      string                     `json:"firstName" validate:"required,min=2,max=50"`
+	LastName       string                     `json:"lastName" validate:"required,min=2,max=50"`
+	Timezone       string                     `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	DayStartHour   int                        `json:"dayStartHour,omitempty" validate:"min=0,max=23"`
+	PreferredUnits map[units.Dimension]string `json:"preferredUnits,omitempty" validate:"omitempty,dive,keys,dimension,endkeys,required"`
 }
 
 type UpdateUserRequest struct {
-	FirstName *string `json:"firstName,omitempty" validate:"omitempty,min=2,max=50"`
-	LastName  *string `json:"lastName,omitempty" validate:"omitempty,min=2,max=50"`
+	FirstName      *string                     `json:"firstName,omitempty" validate:"omitempty,min=2,max=50"`
+	LastName       *string                     `json:"lastName,omitempty" validate:"omitempty,min=2,max=50"`
+	Timezone       *string                     `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	DayStartHour   *int                        `json:"dayStartHour,omitempty" validate:"omitempty,min=0,max=23"`
+	PreferredUnits *map[units.Dimension]string `json:"preferredUnits,omitempty" validate:"omitempty,dive,keys,dimension,endkeys,required"`
 }
 
 func getUserRequestValidator() *validator.Validate {
-	return validator.New()
+	validate := validator.New()
+	_ = validate.RegisterValidation("dimension", func(fl validator.FieldLevel) bool {
+		_, err := units.Units(units.Dimension(fl.Field().String()))
+		return err == nil
+	})
+
+	return validate
 }
 
 func (r *CreateUserRequest) Validate() error {
@@ -32,21 +45,27 @@ func (r *UpdateUserRequest) Validate() error {
 }
 
 type UserResponse struct {
-	ID         uuid.UUID `json:"id"`
-	ExternalID string    `json:"externalId"`
-	FirstName  string    `json:"firstName"`
-	LastName   string    `json:"lastName"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             uuid.UUID                  `json:"id"`
+	ExternalID     string                     `json:"externalId"`
+	FirstName      string                     `json:"firstName"`
+	LastName       string                     `json:"lastName"`
+	Timezone       string                     `json:"timezone,omitempty"`
+	DayStartHour   int                        `json:"dayStartHour"`
+	PreferredUnits map[units.Dimension]string `json:"preferredUnits,omitempty"`
+	CreatedAt      time.Time                  `json:"createdAt"`
+	UpdatedAt      time.Time                  `json:"updatedAt"`
 }
 
 func NewUserResponse(u *user.User) UserResponse {
 	return UserResponse{
-		ID:         u.ID,
-		ExternalID: u.ExternalID,
-		FirstName:  u.FirstName,
-		LastName:   u.LastName,
-		CreatedAt:  u.CreatedAt,
-		UpdatedAt:  u.UpdatedAt,
+		ID:             u.ID,
+		ExternalID:     u.ExternalID,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		Timezone:       u.Timezone,
+		DayStartHour:   u.DayStartHour,
+		PreferredUnits: u.PreferredUnits,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
 	}
 }
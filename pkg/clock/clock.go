@@ -0,0 +1,15 @@
+package clock
+
+import "time"
+
+// Clock tells services what time it is, so that tests can move time forward
+// without waiting.
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,316 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/internal/cosmosutil"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName       = "correlateapp"
+	ruleContainerName  = "AlertRules"
+	alertContainerName = "Alerts"
+	partitionKey       = "/userId"
+)
+
+type CosmosAlertRepository struct {
+	client         *azcosmos.Client
+	ruleContainer  *azcosmos.ContainerClient
+	alertContainer *azcosmos.ContainerClient
+}
+
+func NewCosmosAlertRepository(connectionString string) (*CosmosAlertRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for alert repository: %w", err)
+	}
+
+	ruleContainer, err := client.NewContainer(databaseName, ruleContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for alert rules: %w", err)
+	}
+
+	alertContainer, err := client.NewContainer(databaseName, alertContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for alerts: %w", err)
+	}
+
+	return &CosmosAlertRepository{
+		client:         client,
+		ruleContainer:  ruleContainer,
+		alertContainer: alertContainer,
+	}, nil
+}
+
+func (r *CosmosAlertRepository) CreateRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	ruleJSON, err := json.Marshal(NewCosmosRule(rule))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert rule: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(rule.UserID.String())
+
+	_, err = r.ruleContainer.CreateItem(ctx, pk, ruleJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alert rule in Cosmos DB: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (r *CosmosAlertRepository) GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error) {
+	query := "SELECT * FROM rules r WHERE r.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	rules, err := r.queryRules(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rules) == 0 {
+		return nil, ErrRuleNotFound
+	}
+
+	return rules[0], nil
+}
+
+func (r *CosmosAlertRepository) ListRules(ctx context.Context) ([]*Rule, error) {
+	return r.queryRules(ctx, "SELECT * FROM rules r", nil)
+}
+
+func (r *CosmosAlertRepository) ListRulesByUser(ctx context.Context, userID uuid.UUID) ([]*Rule, error) {
+	query := "SELECT * FROM rules r WHERE r.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryRules(ctx, query, params)
+}
+
+func (r *CosmosAlertRepository) ListRulesByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Rule, error) {
+	query := "SELECT * FROM rules r WHERE r.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+
+	return r.queryRules(ctx, query, params)
+}
+
+func (r *CosmosAlertRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the rule to get its UserID (required for partition key)
+	rule, err := r.GetRuleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(rule.UserID.String())
+
+	_, err = r.ruleContainer.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete alert rule from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosAlertRepository) CreateAlert(ctx context.Context, alert *Alert) (*Alert, error) {
+	alertJSON, err := json.Marshal(NewCosmosAlert(alert))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(alert.UserID.String())
+
+	_, err = r.alertContainer.CreateItem(ctx, pk, alertJSON, nil)
+	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusConflict) {
+			return nil, ErrAlertExists
+		}
+		return nil, fmt.Errorf("failed to create alert in Cosmos DB: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (r *CosmosAlertRepository) GetAlertByID(ctx context.Context, id uuid.UUID) (*Alert, error) {
+	query := "SELECT * FROM alerts a WHERE a.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	alerts, err := r.queryAlerts(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(alerts) == 0 {
+		return nil, ErrAlertNotFound
+	}
+
+	return alerts[0], nil
+}
+
+func (r *CosmosAlertRepository) ListAlertsByUser(ctx context.Context, userID uuid.UUID) ([]*Alert, error) {
+	query := "SELECT * FROM alerts a WHERE a.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryAlerts(ctx, query, params)
+}
+
+func (r *CosmosAlertRepository) ListAlertsByRule(ctx context.Context, ruleID uuid.UUID) ([]*Alert, error) {
+	query := "SELECT * FROM alerts a WHERE a.ruleId = @ruleID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@ruleID", Value: ruleID.String()},
+	}
+
+	return r.queryAlerts(ctx, query, params)
+}
+
+func (r *CosmosAlertRepository) UpdateAlert(ctx context.Context, alert *Alert) (*Alert, error) {
+	alertJSON, err := json.Marshal(NewCosmosAlert(alert))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(alert.UserID.String())
+
+	_, err = r.alertContainer.ReplaceItem(ctx, pk, alert.ID.String(), alertJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update alert in Cosmos DB: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (r *CosmosAlertRepository) queryRules(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Rule, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.ruleContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	rules := []*Rule{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosRule CosmosRule
+			if err := json.Unmarshal(item, &cosmosRule); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal alert rule: %w", err)
+			}
+			rules = append(rules, NewRule(&cosmosRule))
+		}
+	}
+
+	return rules, nil
+}
+
+func (r *CosmosAlertRepository) queryAlerts(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Alert, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.alertContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	alerts := []*Alert{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosAlert CosmosAlert
+			if err := json.Unmarshal(item, &cosmosAlert); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal alert: %w", err)
+			}
+			alerts = append(alerts, NewAlert(&cosmosAlert))
+		}
+	}
+
+	return alerts, nil
+}
+
+type CosmosRule struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"userId"`
+	ParameterID     uuid.UUID `json:"parameterId"`
+	Name            string    `json:"name"`
+	Kind            Kind      `json:"kind"`
+	Operator        Operator  `json:"operator,omitempty"`
+	Value           float64   `json:"value"`
+	WindowDays      int       `json:"windowDays,omitempty"`
+	CooldownMinutes int       `json:"cooldownMinutes"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+func NewCosmosRule(rule *Rule) *CosmosRule {
+	return &CosmosRule{
+		ID:              rule.ID,
+		UserID:          rule.UserID,
+		ParameterID:     rule.ParameterID,
+		Name:            rule.Name,
+		Kind:            rule.Kind,
+		Operator:        rule.Operator,
+		Value:           rule.Value,
+		WindowDays:      rule.WindowDays,
+		CooldownMinutes: int(rule.Cooldown / time.Minute),
+		CreatedAt:       rule.CreatedAt,
+		UpdatedAt:       rule.UpdatedAt,
+	}
+}
+
+func NewRule(cosmosRule *CosmosRule) *Rule {
+	return &Rule{
+		ID:          cosmosRule.ID,
+		UserID:      cosmosRule.UserID,
+		ParameterID: cosmosRule.ParameterID,
+		Name:        cosmosRule.Name,
+		Kind:        cosmosRule.Kind,
+		Operator:    cosmosRule.Operator,
+		Value:       cosmosRule.Value,
+		WindowDays:  cosmosRule.WindowDays,
+		Cooldown:    time.Duration(cosmosRule.CooldownMinutes) * time.Minute,
+		CreatedAt:   cosmosRule.CreatedAt,
+		UpdatedAt:   cosmosRule.UpdatedAt,
+	}
+}
+
+type CosmosAlert struct {
+	ID             uuid.UUID  `json:"id"`
+	RuleID         uuid.UUID  `json:"ruleId"`
+	UserID         uuid.UUID  `json:"userId"`
+	ParameterID    uuid.UUID  `json:"parameterId"`
+	MeasurementID  *uuid.UUID `json:"measurementId,omitempty"`
+	Value          float64    `json:"value"`
+	Message        string     `json:"message"`
+	Fingerprint    string     `json:"fingerprint"`
+	TriggeredAt    time.Time  `json:"triggeredAt"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+}
+
+func NewCosmosAlert(alert *Alert) *CosmosAlert {
+	cosmosAlert := CosmosAlert(*alert)
+	return &cosmosAlert
+}
+
+func NewAlert(cosmosAlert *CosmosAlert) *Alert {
+	alert := Alert(*cosmosAlert)
+	return &alert
+}
@@ -0,0 +1,161 @@
+package alert
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu     sync.RWMutex
+	rules  map[uuid.UUID]*Rule
+	alerts map[uuid.UUID]*Alert
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		rules:  make(map[uuid.UUID]*Rule),
+		alerts: make(map[uuid.UUID]*Alert),
+	}
+}
+
+func (r *InMemoryRepository) CreateRule(_ context.Context, rule *Rule) (*Rule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rules[rule.ID] = rule
+
+	return rule, nil
+}
+
+func (r *InMemoryRepository) GetRuleByID(_ context.Context, id uuid.UUID) (*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, ok := r.rules[id]
+	if !ok {
+		return nil, ErrRuleNotFound
+	}
+
+	return rule, nil
+}
+
+func (r *InMemoryRepository) ListRules(_ context.Context) ([]*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rules := make([]*Rule, 0, len(r.rules))
+	for _, rule := range r.rules {
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (r *InMemoryRepository) ListRulesByUser(_ context.Context, userID uuid.UUID) ([]*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rules []*Rule
+	for _, rule := range r.rules {
+		if rule.UserID == userID {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (r *InMemoryRepository) ListRulesByParameter(_ context.Context, parameterID uuid.UUID) ([]*Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var rules []*Rule
+	for _, rule := range r.rules {
+		if rule.ParameterID == parameterID {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+func (r *InMemoryRepository) DeleteRule(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.rules[id]; !ok {
+		return ErrRuleNotFound
+	}
+
+	delete(r.rules, id)
+
+	return nil
+}
+
+func (r *InMemoryRepository) CreateAlert(_ context.Context, alert *Alert) (*Alert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.alerts[alert.ID]; ok {
+		return nil, ErrAlertExists
+	}
+
+	r.alerts[alert.ID] = alert
+
+	return alert, nil
+}
+
+func (r *InMemoryRepository) GetAlertByID(_ context.Context, id uuid.UUID) (*Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alert, ok := r.alerts[id]
+	if !ok {
+		return nil, ErrAlertNotFound
+	}
+
+	return alert, nil
+}
+
+func (r *InMemoryRepository) ListAlertsByUser(_ context.Context, userID uuid.UUID) ([]*Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var alerts []*Alert
+	for _, alert := range r.alerts {
+		if alert.UserID == userID {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (r *InMemoryRepository) ListAlertsByRule(_ context.Context, ruleID uuid.UUID) ([]*Alert, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var alerts []*Alert
+	for _, alert := range r.alerts {
+		if alert.RuleID == ruleID {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+func (r *InMemoryRepository) UpdateAlert(_ context.Context, alert *Alert) (*Alert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.alerts[alert.ID]; !ok {
+		return nil, ErrAlertNotFound
+	}
+
+	r.alerts[alert.ID] = alert
+
+	return alert, nil
+}
@@ -0,0 +1,61 @@
+package alert
+
+import (
+	"context"
+	"log"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+)
+
+// MeasurementService evaluates alert rules for the measurements it creates.
+type MeasurementService struct {
+	measurement.Service
+	alertService Service
+}
+
+func NewMeasurementService(measurementService measurement.Service, alertService Service) measurement.Service {
+	return &MeasurementService{
+		Service:      measurementService,
+		alertService: alertService,
+	}
+}
+
+// CreateMeasurement stores the measurement and then evaluates its rules.
+func (s *MeasurementService) CreateMeasurement(
+	ctx context.Context,
+	input measurement.CreateMeasurementInput,
+) (measurement.Measurement, error) {
+	created, err := s.Service.CreateMeasurement(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.alertService.EvaluateMeasurement(ctx, created); err != nil {
+		log.Printf("failed to evaluate alert rules for measurement %s: %v", created.GetID(), err)
+	}
+
+	return created, nil
+}
+
+// CreateMeasurements stores the batch and then evaluates the created measurements.
+func (s *MeasurementService) CreateMeasurements(
+	ctx context.Context,
+	inputs []measurement.CreateMeasurementInput,
+) ([]measurement.BatchResult, error) {
+	results, err := s.Service.CreateMeasurements(ctx, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]measurement.Measurement, 0, len(results))
+	for _, result := range results {
+		if result.Err == nil {
+			created = append(created, result.Measurement)
+		}
+	}
+	if _, err := s.alertService.EvaluateMeasurements(ctx, created); err != nil {
+		log.Printf("failed to evaluate alert rules for a batch of %d measurements: %v", len(created), err)
+	}
+
+	return results, nil
+}
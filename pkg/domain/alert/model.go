@@ -0,0 +1,93 @@
+package alert
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindThreshold compares each new value with a fixed value.
+	KindThreshold Kind = "threshold"
+	// KindChange compares the absolute change over a window of days with a
+	// fixed value.
+	KindChange Kind = "change"
+	// KindMissing fires when nothing was logged for a number of days.
+	KindMissing Kind = "missing"
+)
+
+type Operator string
+
+const (
+	OperatorGreater        Operator = "gt"
+	OperatorGreaterOrEqual Operator = "gte"
+	OperatorLess           Operator = "lt"
+	OperatorLessOrEqual    Operator = "lte"
+)
+
+// Compare reports whether value op threshold holds.
+func (o Operator) Compare(value, threshold float64) bool {
+	switch o {
+	case OperatorGreater:
+		return value > threshold
+	case OperatorGreaterOrEqual:
+		return value >= threshold
+	case OperatorLess:
+		return value < threshold
+	case OperatorLessOrEqual:
+		return value <= threshold
+	default:
+		return false
+	}
+}
+
+func (o Operator) Symbol() string {
+	switch o {
+	case OperatorGreater:
+		return ">"
+	case OperatorGreaterOrEqual:
+		return ">="
+	case OperatorLess:
+		return "<"
+	case OperatorLessOrEqual:
+		return "<="
+	default:
+		return string(o)
+	}
+}
+
+type Rule struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ParameterID uuid.UUID
+	Name        string
+	Kind        Kind
+	Operator    Operator
+	Value       float64
+	// WindowDays is the look-back of change rules and the silence that
+	// triggers missing rules.
+	WindowDays int
+	// Cooldown is the minimum time between two alerts of the rule.
+	Cooldown  time.Duration
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Alert struct {
+	ID          uuid.UUID
+	RuleID      uuid.UUID
+	UserID      uuid.UUID
+	ParameterID uuid.UUID
+	// MeasurementID is the measurement that triggered the alert. It is nil
+	// for missing-entry alerts.
+	MeasurementID *uuid.UUID
+	Value         float64
+	Message       string
+	// Fingerprint identifies the condition that fired, so that evaluating the
+	// same data twice does not raise the alert twice.
+	Fingerprint    string
+	TriggeredAt    time.Time
+	AcknowledgedAt *time.Time
+}
@@ -0,0 +1,35 @@
+package alert
+
+import (
+	"context"
+	"log"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/google/uuid"
+)
+
+// ParameterService deletes alert rules along with their parameter.
+type ParameterService struct {
+	parameter.Service
+	alertService Service
+}
+
+func NewParameterService(parameterService parameter.Service, alertService Service) parameter.Service {
+	return &ParameterService{
+		Service:      parameterService,
+		alertService: alertService,
+	}
+}
+
+// DeleteParameter deletes the parameter and then its rules.
+func (s *ParameterService) DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error {
+	if err := s.Service.DeleteParameter(ctx, id, ifMatch); err != nil {
+		return err
+	}
+
+	if err := s.alertService.DeleteRulesByParameter(ctx, id); err != nil {
+		log.Printf("failed to delete alert rules for parameter %s: %v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package alert
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrRuleNotFound  = errors.New("alert rule not found")
+	ErrAlertNotFound = errors.New("alert not found")
+	ErrAlertExists   = errors.New("alert already exists")
+)
+
+type Repository interface {
+	CreateRule(ctx context.Context, rule *Rule) (*Rule, error)
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	ListRules(ctx context.Context) ([]*Rule, error)
+	ListRulesByUser(ctx context.Context, userID uuid.UUID) ([]*Rule, error)
+	ListRulesByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Rule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	CreateAlert(ctx context.Context, alert *Alert) (*Alert, error)
+	GetAlertByID(ctx context.Context, id uuid.UUID) (*Alert, error)
+	ListAlertsByUser(ctx context.Context, userID uuid.UUID) ([]*Alert, error)
+	ListAlertsByRule(ctx context.Context, ruleID uuid.UUID) ([]*Alert, error)
+	UpdateAlert(ctx context.Context, alert *Alert) (*Alert, error)
+}
@@ -0,0 +1,40 @@
+package alert
+
+import (
+	"context"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateRule(ctx context.Context, input CreateRuleInput) (*Rule, error)
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	ListRulesByUser(ctx context.Context, userID uuid.UUID) ([]*Rule, error)
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	DeleteRulesByParameter(ctx context.Context, parameterID uuid.UUID) error
+	ListAlertsByUser(ctx context.Context, userID uuid.UUID) ([]*Alert, error)
+	AcknowledgeAlert(ctx context.Context, id uuid.UUID) (*Alert, error)
+	// EvaluateMeasurement runs the threshold and change rules of the
+	// measurement's parameter against it.
+	EvaluateMeasurement(ctx context.Context, m measurement.Measurement) ([]*Alert, error)
+	// EvaluateMeasurements does the same for a batch, reading each
+	// parameter's rules and history once. A parameter whose rules fail to
+	// evaluate is logged and skipped.
+	EvaluateMeasurements(ctx context.Context, measurements []measurement.Measurement) ([]*Alert, error)
+	// EvaluateRules runs every rule against the latest data as of now. It is
+	// meant to be called on a schedule and is the only place missing-entry
+	// rules fire. A rule that fails to evaluate is logged and skipped.
+	EvaluateRules(ctx context.Context, now time.Time) ([]*Alert, error)
+}
+
+type CreateRuleInput struct {
+	ParameterID uuid.UUID
+	Name        string
+	Kind        Kind
+	Operator    Operator
+	Value       float64
+	WindowDays  int
+	Cooldown    time.Duration
+}
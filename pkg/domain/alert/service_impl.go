@@ -0,0 +1,547 @@
+package alert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+const day = 24 * time.Hour
+
+var (
+	ErrInvalidKind      = errors.New("rule kind must be threshold, change or missing")
+	ErrInvalidOperator  = errors.New("operator must be gt, gte, lt or lte")
+	ErrInvalidWindow    = errors.New("change and missing rules need a window of at least one day")
+	ErrInvalidCooldown  = errors.New("cooldown must not be negative")
+	ErrDerivedParameter = errors.New("alert rules cannot watch a derived parameter")
+)
+
+type ServiceImpl struct {
+	repo               Repository
+	parameterService   parameter.Service
+	measurementService measurement.Service
+}
+
+func NewService(
+	repo Repository,
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+) Service {
+	return &ServiceImpl{
+		repo:               repo,
+		parameterService:   parameterService,
+		measurementService: measurementService,
+	}
+}
+
+func (s *ServiceImpl) CreateRule(ctx context.Context, input CreateRuleInput) (*Rule, error) {
+	ruleParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ruleParameter.IsDerived() {
+		return nil, ErrDerivedParameter
+	}
+
+	rule := &Rule{
+		ID:          uuid.New(),
+		UserID:      ruleParameter.UserID,
+		ParameterID: ruleParameter.ID,
+		Name:        input.Name,
+		Kind:        input.Kind,
+		Operator:    input.Operator,
+		Value:       input.Value,
+		WindowDays:  input.WindowDays,
+		Cooldown:    input.Cooldown,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	if err := validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateRule(ctx, rule)
+}
+
+func (s *ServiceImpl) GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error) {
+	return s.repo.GetRuleByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListRulesByUser(ctx context.Context, userID uuid.UUID) ([]*Rule, error) {
+	return s.repo.ListRulesByUser(ctx, userID)
+}
+
+func (s *ServiceImpl) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteRule(ctx, id)
+}
+
+func (s *ServiceImpl) DeleteRulesByParameter(ctx context.Context, parameterID uuid.UUID) error {
+	rules, err := s.repo.ListRulesByParameter(ctx, parameterID)
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if err := s.repo.DeleteRule(ctx, rule.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) ListAlertsByUser(ctx context.Context, userID uuid.UUID) ([]*Alert, error) {
+	alerts, err := s.repo.ListAlertsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].TriggeredAt.After(alerts[j].TriggeredAt)
+	})
+
+	return alerts, nil
+}
+
+func (s *ServiceImpl) AcknowledgeAlert(ctx context.Context, id uuid.UUID) (*Alert, error) {
+	alert, err := s.repo.GetAlertByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if alert.AcknowledgedAt != nil {
+		return alert, nil
+	}
+
+	acknowledged := *alert
+	now := time.Now()
+	acknowledged.AcknowledgedAt = &now
+
+	return s.repo.UpdateAlert(ctx, &acknowledged)
+}
+
+func (s *ServiceImpl) EvaluateMeasurement(ctx context.Context, m measurement.Measurement) ([]*Alert, error) {
+	return s.evaluateParameter(ctx, m.GetParameterID(), []measurement.Measurement{m})
+}
+
+func (s *ServiceImpl) EvaluateMeasurements(
+	ctx context.Context,
+	measurements []measurement.Measurement,
+) ([]*Alert, error) {
+	var parameterIDs []uuid.UUID
+	byParameter := make(map[uuid.UUID][]measurement.Measurement)
+	for _, m := range measurements {
+		if _, ok := byParameter[m.GetParameterID()]; !ok {
+			parameterIDs = append(parameterIDs, m.GetParameterID())
+		}
+		byParameter[m.GetParameterID()] = append(byParameter[m.GetParameterID()], m)
+	}
+
+	alerts := []*Alert{}
+	for _, parameterID := range parameterIDs {
+		raised, err := s.evaluateParameter(ctx, parameterID, byParameter[parameterID])
+		if err != nil {
+			log.Printf("failed to evaluate alert rules for parameter %s: %v", parameterID, err)
+			continue
+		}
+		alerts = append(alerts, raised...)
+	}
+
+	return alerts, nil
+}
+
+// evaluateParameter runs the threshold and change rules of a parameter
+// against its new measurements. The history the change rules look back on is
+// loaded once for all of them.
+func (s *ServiceImpl) evaluateParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	measurements []measurement.Measurement,
+) ([]*Alert, error) {
+	rules, err := s.repo.ListRulesByParameter(ctx, parameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []measurement.Measurement
+	if window := lookBack(rules); window > 0 {
+		from, to := measurements[0].GetTimestamp(), measurements[0].GetTimestamp()
+		for _, m := range measurements[1:] {
+			if m.GetTimestamp().Before(from) {
+				from = m.GetTimestamp()
+			}
+			if m.GetTimestamp().After(to) {
+				to = m.GetTimestamp()
+			}
+		}
+		history, err = s.historyWithin(ctx, parameterID, from.Add(-window), to)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	alerts := []*Alert{}
+	for _, m := range measurements {
+		for _, rule := range rules {
+			observed, ok := observe(rule, m, history)
+			if !ok {
+				continue
+			}
+
+			alert, alertErr := s.measurementAlert(ctx, rule, m, observed, time.Now())
+			if alertErr != nil {
+				return nil, alertErr
+			}
+
+			raised, raiseErr := s.raise(ctx, rule, alert)
+			if raiseErr != nil {
+				return nil, raiseErr
+			}
+			if raised {
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+func (s *ServiceImpl) EvaluateRules(ctx context.Context, now time.Time) ([]*Alert, error) {
+	rules, err := s.repo.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByParameter := make(map[uuid.UUID][]*Rule)
+	for _, rule := range rules {
+		rulesByParameter[rule.ParameterID] = append(rulesByParameter[rule.ParameterID], rule)
+	}
+
+	histories := make(map[uuid.UUID][]measurement.Measurement)
+	alerts := []*Alert{}
+	for _, rule := range rules {
+		history, ok := histories[rule.ParameterID]
+		if !ok {
+			var listErr error
+			history, listErr = s.recentHistory(ctx, rule.ParameterID, lookBack(rulesByParameter[rule.ParameterID]), now)
+			if listErr != nil {
+				log.Printf("failed to list measurements for alert rule %s: %v", rule.ID, listErr)
+				continue
+			}
+			histories[rule.ParameterID] = history
+		}
+
+		alert, raised, err := s.evaluateRule(ctx, rule, history, now)
+		if err != nil {
+			log.Printf("failed to evaluate alert rule %s: %v", rule.ID, err)
+			continue
+		}
+		if raised {
+			alerts = append(alerts, alert)
+		}
+	}
+
+	return alerts, nil
+}
+
+// recentHistory loads what the scheduled rules of a parameter look at: its
+// latest measurement as of now and the window before it.
+func (s *ServiceImpl) recentHistory(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	window time.Duration,
+	now time.Time,
+) ([]measurement.Measurement, error) {
+	latest, err := s.measurementService.ListMeasurementsByParameter(ctx, parameterID, measurement.ListOptions{
+		Filter: measurement.Filter{To: &now},
+		Sort:   measurement.Sort{Field: measurement.SortByTimestamp, Descending: true},
+	}, pagination.Page{Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(latest.Items) == 0 || window == 0 {
+		return latest.Items, nil
+	}
+
+	return s.historyWithin(ctx, parameterID, latest.Items[0].GetTimestamp().Add(-window), now)
+}
+
+// historyWithin lists the parameter's measurements taken between from and to.
+func (s *ServiceImpl) historyWithin(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	from, to time.Time,
+) ([]measurement.Measurement, error) {
+	listed, err := s.measurementService.ListMeasurementsByParameter(ctx, parameterID, measurement.ListOptions{
+		Filter: measurement.Filter{From: &from, To: &to},
+	}, pagination.Page{})
+	if err != nil {
+		return nil, err
+	}
+
+	return listed.Items, nil
+}
+
+// lookBack returns the longest window of the change rules, which is how much
+// history the rules need before the measurement they evaluate.
+func lookBack(rules []*Rule) time.Duration {
+	var window time.Duration
+	for _, rule := range rules {
+		if rule.Kind == KindChange {
+			window = max(window, time.Duration(rule.WindowDays)*day)
+		}
+	}
+
+	return window
+}
+
+// evaluateRule runs one rule against the latest measurement as of now and
+// reports whether it raised an alert.
+func (s *ServiceImpl) evaluateRule(
+	ctx context.Context,
+	rule *Rule,
+	history []measurement.Measurement,
+	now time.Time,
+) (*Alert, bool, error) {
+	latest := latestBefore(history, now)
+
+	var alert *Alert
+	fired := false
+	switch rule.Kind {
+	case KindMissing:
+		alert, fired = missingAlert(rule, latest, now)
+	case KindThreshold, KindChange:
+		var observed float64
+		if latest != nil {
+			observed, fired = observe(rule, latest, history)
+		}
+		if fired {
+			var err error
+			alert, err = s.measurementAlert(ctx, rule, latest, observed, now)
+			if err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	if !fired {
+		return nil, false, nil
+	}
+
+	raised, err := s.raise(ctx, rule, alert)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return alert, raised, nil
+}
+
+// observe returns the value a threshold or change rule looks at and whether
+// it crosses the rule's limit. Change rules compare the absolute change since
+// the oldest measurement in their window.
+func observe(rule *Rule, m measurement.Measurement, history []measurement.Measurement) (float64, bool) {
+	value, ok := numericValue(m)
+	if !ok {
+		return 0, false
+	}
+
+	switch rule.Kind {
+	case KindThreshold:
+		return value, rule.Operator.Compare(value, rule.Value)
+	case KindChange:
+		earliest := earliestWithin(history, m, time.Duration(rule.WindowDays)*day)
+		if earliest == nil {
+			return 0, false
+		}
+		previous, _ := numericValue(earliest)
+		change := value - previous
+		return change, rule.Operator.Compare(math.Abs(change), rule.Value)
+	case KindMissing:
+		return 0, false
+	default:
+		return 0, false
+	}
+}
+
+func (s *ServiceImpl) measurementAlert(
+	ctx context.Context,
+	rule *Rule,
+	m measurement.Measurement,
+	observed float64,
+	now time.Time,
+) (*Alert, error) {
+	ruleParameter, err := s.parameterService.GetParameterByID(ctx, rule.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	message := fmt.Sprintf("%s is %s (%s %s)",
+		ruleParameter.Name, withUnit(observed, ruleParameter.Unit), rule.Operator.Symbol(), formatValue(rule.Value))
+	if rule.Kind == KindChange {
+		message = fmt.Sprintf("%s changed by %s within %d days (%s %s)",
+			ruleParameter.Name, withUnit(observed, ruleParameter.Unit), rule.WindowDays,
+			rule.Operator.Symbol(), formatValue(rule.Value))
+	}
+
+	measurementID := m.GetID()
+
+	return &Alert{
+		MeasurementID: &measurementID,
+		Value:         observed,
+		Message:       message,
+		Fingerprint:   rule.ID.String() + ":" + measurementID.String(),
+		TriggeredAt:   now,
+	}, nil
+}
+
+// missingAlert fires once the parameter has been silent for the rule's
+// window. The silence is counted from the rule's creation if nothing was
+// logged since.
+func missingAlert(rule *Rule, latest measurement.Measurement, now time.Time) (*Alert, bool) {
+	since := rule.CreatedAt
+	if latest != nil && latest.GetTimestamp().After(since) {
+		since = latest.GetTimestamp()
+	}
+
+	silence := now.Sub(since)
+	if silence < time.Duration(rule.WindowDays)*day {
+		return nil, false
+	}
+
+	days := int(silence / day)
+
+	return &Alert{
+		Value:       float64(days),
+		Message:     fmt.Sprintf("Nothing logged for %d days", days),
+		Fingerprint: rule.ID.String() + ":missing:" + strconv.FormatInt(since.Unix(), 10),
+		TriggeredAt: now,
+	}, true
+}
+
+// raise stores an alert unless the same condition was already reported or
+// the rule is still cooling down from its previous alert. The alert's ID is
+// derived from its fingerprint, so a concurrent evaluation that reports the
+// same condition fails to create it rather than storing a duplicate.
+func (s *ServiceImpl) raise(ctx context.Context, rule *Rule, alert *Alert) (bool, error) {
+	previous, err := s.repo.ListAlertsByRule(ctx, rule.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, p := range previous {
+		if p.Fingerprint == alert.Fingerprint || alert.TriggeredAt.Sub(p.TriggeredAt) < rule.Cooldown {
+			return false, nil
+		}
+	}
+
+	alert.ID = uuid.NewSHA1(rule.ID, []byte(alert.Fingerprint))
+	alert.RuleID = rule.ID
+	alert.UserID = rule.UserID
+	alert.ParameterID = rule.ParameterID
+
+	if _, err := s.repo.CreateAlert(ctx, alert); err != nil {
+		if errors.Is(err, ErrAlertExists) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func validateRule(rule *Rule) error {
+	switch rule.Kind {
+	case KindThreshold, KindChange:
+		switch rule.Operator {
+		case OperatorGreater, OperatorGreaterOrEqual, OperatorLess, OperatorLessOrEqual:
+		default:
+			return ErrInvalidOperator
+		}
+		if rule.Kind == KindChange && rule.WindowDays < 1 {
+			return ErrInvalidWindow
+		}
+	case KindMissing:
+		if rule.WindowDays < 1 {
+			return ErrInvalidWindow
+		}
+	default:
+		return ErrInvalidKind
+	}
+
+	if rule.Cooldown < 0 {
+		return ErrInvalidCooldown
+	}
+
+	return nil
+}
+
+func numericValue(m measurement.Measurement) (float64, bool) {
+	switch typed := m.(type) {
+	case *measurement.FloatMeasurement:
+		return typed.Value, true
+	case *measurement.BooleanMeasurement:
+		if typed.Value {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func latestBefore(history []measurement.Measurement, now time.Time) measurement.Measurement {
+	var latest measurement.Measurement
+	for _, m := range history {
+		if m.GetTimestamp().After(now) {
+			continue
+		}
+		if latest == nil || m.GetTimestamp().After(latest.GetTimestamp()) {
+			latest = m
+		}
+	}
+
+	return latest
+}
+
+// earliestWithin returns the oldest measurement taken in the window before m.
+func earliestWithin(history []measurement.Measurement, m measurement.Measurement, window time.Duration) measurement.Measurement {
+	from := m.GetTimestamp().Add(-window)
+
+	var earliest measurement.Measurement
+	for _, h := range history {
+		ts := h.GetTimestamp()
+		if h.GetID() == m.GetID() || ts.Before(from) || !ts.Before(m.GetTimestamp()) {
+			continue
+		}
+		if earliest == nil || ts.Before(earliest.GetTimestamp()) {
+			earliest = h
+		}
+	}
+
+	return earliest
+}
+
+// formatValue rounds to two decimals so that differences of stored floats
+// read naturally.
+func formatValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func withUnit(value float64, unit string) string {
+	if unit == "" {
+		return formatValue(value)
+	}
+
+	return formatValue(value) + " " + unit
+}
@@ -0,0 +1,342 @@
+package alert_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/alert"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdRuleFiresOnCreate(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	rule, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Name:        "High glucose",
+		Kind:        alert.KindThreshold,
+		Operator:    alert.OperatorGreater,
+		Value:       180,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	domaintest.LogValue(t, measurementService, glucose.ID, now.Add(-2*time.Hour), 150)
+	domaintest.LogValue(t, measurementService, glucose.ID, now.Add(-time.Hour), 190)
+
+	alerts, err := alertService.ListAlertsByUser(ctx, rule.UserID)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, rule.ID, alerts[0].RuleID)
+	assert.InDelta(t, 190, alerts[0].Value, 1e-9)
+	assert.Equal(t, "Glucose is 190 (> 180)", alerts[0].Message)
+	require.NotNil(t, alerts[0].MeasurementID)
+
+	// The scheduled run sees the same measurement and must not repeat it.
+	raised, err := alertService.EvaluateRules(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, raised)
+
+	acknowledged, err := alertService.AcknowledgeAlert(ctx, alerts[0].ID)
+	require.NoError(t, err)
+	assert.NotNil(t, acknowledged.AcknowledgedAt)
+}
+
+func TestCooldownSuppressesRepeatedAlerts(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	rule, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Name:        "High glucose",
+		Kind:        alert.KindThreshold,
+		Operator:    alert.OperatorGreaterOrEqual,
+		Value:       180,
+		Cooldown:    time.Hour,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	domaintest.LogValue(t, measurementService, glucose.ID, now.Add(-10*time.Minute), 200)
+	domaintest.LogValue(t, measurementService, glucose.ID, now.Add(-5*time.Minute), 210)
+
+	alerts, err := alertService.ListAlertsByUser(ctx, rule.UserID)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+
+	// Once the cooldown has passed, the latest reading alerts again.
+	raised, err := alertService.EvaluateRules(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, raised, 1)
+	assert.InDelta(t, 210, raised[0].Value, 1e-9)
+}
+
+func TestChangeRule(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	weight := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	ctx := context.Background()
+
+	_, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: weight.ID,
+		Name:        "Rapid weight change",
+		Kind:        alert.KindChange,
+		Operator:    alert.OperatorGreater,
+		Value:       2,
+		WindowDays:  7,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	domaintest.LogValue(t, measurementService, weight.ID, now.AddDate(0, 0, -20), 70)
+	domaintest.LogValue(t, measurementService, weight.ID, now.AddDate(0, 0, -6), 80)
+	domaintest.LogValue(t, measurementService, weight.ID, now.AddDate(0, 0, -3), 81)
+
+	raised, err := alertService.EvaluateRules(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, raised, "the rise from 70 is outside the window")
+
+	domaintest.LogValue(t, measurementService, weight.ID, now.Add(-time.Hour), 77.7)
+
+	alerts, err := alertService.ListAlertsByUser(ctx, weight.UserID)
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.InDelta(t, -2.3, alerts[0].Value, 1e-9)
+	assert.Equal(t, "Weight changed by -2.3 kg within 7 days (> 2)", alerts[0].Message)
+}
+
+func TestBatchEvaluatesEachParameter(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	userID := uuid.New()
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	weight := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	ctx := context.Background()
+
+	_, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Name:        "High glucose",
+		Kind:        alert.KindThreshold,
+		Operator:    alert.OperatorGreaterOrEqual,
+		Value:       180,
+	})
+	require.NoError(t, err)
+	_, err = alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: weight.ID,
+		Name:        "Rapid weight change",
+		Kind:        alert.KindChange,
+		Operator:    alert.OperatorGreater,
+		Value:       2,
+		WindowDays:  7,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	results, err := measurementService.CreateMeasurements(ctx, []measurement.CreateMeasurementInput{
+		{ParameterID: weight.ID, Value: 80.0, Timestamp: now.AddDate(0, 0, -20)},
+		{ParameterID: glucose.ID, Value: 190.0, Timestamp: now.Add(-time.Hour)},
+		{ParameterID: weight.ID, Value: 76.0, Timestamp: now.AddDate(0, 0, -5)},
+		{ParameterID: weight.ID, Value: 79.0, Timestamp: now.AddDate(0, 0, -1)},
+	})
+	require.NoError(t, err)
+	for _, result := range results {
+		require.NoError(t, result.Err)
+	}
+
+	alerts, err := alertService.ListAlertsByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	byParameter := make(map[uuid.UUID]*alert.Alert)
+	for _, a := range alerts {
+		byParameter[a.ParameterID] = a
+	}
+	assert.InDelta(t, 190, byParameter[glucose.ID].Value, 1e-9)
+	assert.InDelta(t, 3, byParameter[weight.ID].Value, 1e-9, "the 80 kg reading is outside the window")
+}
+
+func TestMissingRule(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	measurementService = alert.NewMeasurementService(measurementService, alertService)
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	now := time.Now()
+	domaintest.LogValue(t, measurementService, mood.ID, now.AddDate(0, 0, -1), 5)
+
+	rule, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: mood.ID,
+		Name:        "Log mood",
+		Kind:        alert.KindMissing,
+		WindowDays:  3,
+	})
+	require.NoError(t, err)
+
+	raised, err := alertService.EvaluateRules(ctx, now)
+	require.NoError(t, err)
+	assert.Empty(t, raised)
+
+	// Silence is counted from the rule's creation, not from the older entry.
+	raised, err = alertService.EvaluateRules(ctx, now.AddDate(0, 0, 3).Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, raised, 1)
+	assert.Equal(t, rule.ID, raised[0].RuleID)
+	assert.Nil(t, raised[0].MeasurementID)
+
+	// The same silence is reported once.
+	raised, err = alertService.EvaluateRules(ctx, now.AddDate(0, 0, 4))
+	require.NoError(t, err)
+	assert.Empty(t, raised)
+}
+
+func TestEvaluateRulesSkipsFailingRule(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	now := time.Now()
+	domaintest.LogValue(t, measurementService, glucose.ID, now.Add(-time.Hour), 190)
+
+	_, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Name:        "High glucose",
+		Kind:        alert.KindThreshold,
+		Operator:    alert.OperatorGreater,
+		Value:       180,
+	})
+	require.NoError(t, err)
+
+	moodRule, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: mood.ID,
+		Name:        "Log mood",
+		Kind:        alert.KindMissing,
+		WindowDays:  1,
+	})
+	require.NoError(t, err)
+
+	// The glucose rule can no longer describe its parameter, which must not
+	// keep the mood rule from firing.
+	require.NoError(t, parameterService.DeleteParameter(ctx, glucose.ID, ""))
+
+	raised, err := alertService.EvaluateRules(ctx, now.AddDate(0, 0, 2))
+	require.NoError(t, err)
+	require.Len(t, raised, 1)
+	assert.Equal(t, moodRule.ID, raised[0].RuleID)
+}
+
+func TestCreateRuleValidation(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	_, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Kind:        alert.KindThreshold,
+		Operator:    "eq",
+		Value:       100,
+	})
+	require.ErrorIs(t, err, alert.ErrInvalidOperator)
+
+	_, err = alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Kind:        alert.KindChange,
+		Operator:    alert.OperatorGreater,
+		Value:       10,
+	})
+	require.ErrorIs(t, err, alert.ErrInvalidWindow)
+
+	_, err = alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Kind:        "trend",
+	})
+	require.ErrorIs(t, err, alert.ErrInvalidKind)
+}
+
+func TestDeleteParameterDeletesRules(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	alertService := alert.NewService(alert.NewInMemoryRepository(), parameterService, measurementService)
+	deletingService := alert.NewParameterService(parameterService, alertService)
+	glucose := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Glucose",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	rule, err := alertService.CreateRule(ctx, alert.CreateRuleInput{
+		ParameterID: glucose.ID,
+		Name:        "Log glucose",
+		Kind:        alert.KindMissing,
+		WindowDays:  1,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, deletingService.DeleteParameter(ctx, glucose.ID, ""))
+
+	_, err = alertService.GetRuleByID(ctx, rule.ID)
+	require.ErrorIs(t, err, alert.ErrRuleNotFound)
+}
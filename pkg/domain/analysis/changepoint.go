@@ -0,0 +1,93 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+// madToSigma converts the MAD of first differences to the noise standard
+// deviation: differences of i.i.d. noise have variance 2σ².
+const madToSigma = 0.6745 * math.Sqrt2
+
+// detectChangepoints finds shifts in the mean level using PELT with a normal
+// mean-change cost. It returns the indices where new segments start.
+func detectChangepoints(values []float64, penalty float64, minSegment int) []int {
+	n := len(values)
+	if n < 2*minSegment {
+		return nil
+	}
+
+	if penalty == 0 {
+		penalty = defaultPenalty(values)
+	}
+	if penalty == 0 {
+		return nil
+	}
+
+	sum := make([]float64, n+1)
+	sumSquares := make([]float64, n+1)
+	for i, v := range values {
+		sum[i+1] = sum[i] + v
+		sumSquares[i+1] = sumSquares[i] + v*v
+	}
+
+	cost := func(from, to int) float64 {
+		s := sum[to] - sum[from]
+		return sumSquares[to] - sumSquares[from] - s*s/float64(to-from)
+	}
+
+	best := make([]float64, n+1)
+	last := make([]int, n+1)
+	best[0] = -penalty
+	candidates := []int{0}
+
+	for t := minSegment; t <= n; t++ {
+		best[t] = math.Inf(1)
+		evaluated := make(map[int]float64, len(candidates))
+		for _, tau := range candidates {
+			if t-tau < minSegment {
+				continue
+			}
+			total := best[tau] + cost(tau, t) + penalty
+			evaluated[tau] = total
+			if total < best[t] {
+				best[t] = total
+				last[t] = tau
+			}
+		}
+
+		pruned := candidates[:0]
+		for _, tau := range candidates {
+			if total, ok := evaluated[tau]; !ok || total-penalty <= best[t] {
+				pruned = append(pruned, tau)
+			}
+		}
+		candidates = append(pruned, t)
+	}
+
+	var changepoints []int
+	for t := last[n]; t > 0; t = last[t] {
+		changepoints = append([]int{t}, changepoints...)
+	}
+
+	return changepoints
+}
+
+// defaultPenalty is a BIC-style penalty scaled by a robust noise estimate.
+func defaultPenalty(values []float64) float64 {
+	diffs := make([]float64, len(values)-1)
+	for i := range diffs {
+		diffs[i] = values[i+1] - values[i]
+	}
+
+	sigma := stats.MedianAbsoluteDeviation(diffs) / madToSigma
+	if sigma == 0 {
+		sigma = stats.StdDev(values)
+	}
+	if math.IsNaN(sigma) {
+		return 0
+	}
+
+	return 2 * sigma * sigma * math.Log(float64(len(values)))
+}
@@ -0,0 +1,139 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const (
+	// itsCoefficients is the number of terms in the segmented regression.
+	itsCoefficients = 4
+	// singularPivot is the smallest pivot accepted when inverting the normal
+	// equations.
+	singularPivot = 1e-12
+)
+
+// interruptedTimeSeries fits the segmented regression by ordinary least
+// squares. days holds the offset of each value from the event day. It
+// returns false when the design is singular.
+func interruptedTimeSeries(days, values []float64) (InterruptedTimeSeries, bool) {
+	n := len(values)
+	df := n - itsCoefficients
+	if df <= 0 {
+		return InterruptedTimeSeries{}, false
+	}
+
+	rows := make([][itsCoefficients]float64, n)
+	var xtx [itsCoefficients][itsCoefficients]float64
+	var xty [itsCoefficients]float64
+	for i, t := range days {
+		after := 0.0
+		if t >= 0 {
+			after = 1
+		}
+		rows[i] = [itsCoefficients]float64{1, t, after, t * after}
+
+		for j := range itsCoefficients {
+			xty[j] += rows[i][j] * values[i]
+			for k := range itsCoefficients {
+				xtx[j][k] += rows[i][j] * rows[i][k]
+			}
+		}
+	}
+
+	inverse, ok := invert(xtx)
+	if !ok {
+		return InterruptedTimeSeries{}, false
+	}
+
+	var beta [itsCoefficients]float64
+	for j := range itsCoefficients {
+		for k := range itsCoefficients {
+			beta[j] += inverse[j][k] * xty[k]
+		}
+	}
+
+	mean := stats.Mean(values)
+	var sse, sst float64
+	for i, row := range rows {
+		fitted := 0.0
+		for j := range itsCoefficients {
+			fitted += row[j] * beta[j]
+		}
+		sse += (values[i] - fitted) * (values[i] - fitted)
+		sst += (values[i] - mean) * (values[i] - mean)
+	}
+
+	sigma2 := sse / float64(df)
+	coefficient := func(j int) Coefficient {
+		c := Coefficient{
+			Estimate: beta[j],
+			StdError: math.Sqrt(sigma2 * inverse[j][j]),
+			PValue:   1,
+		}
+		if c.StdError > 0 {
+			c.TStatistic = c.Estimate / c.StdError
+			c.PValue = 2 * (1 - stats.StudentTCDF(math.Abs(c.TStatistic), float64(df)))
+		} else if c.Estimate != 0 {
+			// An exact fit leaves no doubt about a non-zero coefficient.
+			c.PValue = 0
+		}
+		return c
+	}
+
+	result := InterruptedTimeSeries{
+		Intercept:        coefficient(0),
+		PreSlope:         coefficient(1),
+		LevelChange:      coefficient(2),
+		SlopeChange:      coefficient(3),
+		DegreesOfFreedom: df,
+	}
+	if sst > 0 {
+		result.RSquared = 1 - sse/sst
+	}
+
+	return result, true
+}
+
+// invert inverts a small symmetric matrix by Gauss–Jordan elimination with
+// partial pivoting.
+func invert(m [itsCoefficients][itsCoefficients]float64) ([itsCoefficients][itsCoefficients]float64, bool) {
+	var inverse [itsCoefficients][itsCoefficients]float64
+	for i := range itsCoefficients {
+		inverse[i][i] = 1
+	}
+
+	for col := range itsCoefficients {
+		pivot := col
+		for row := col + 1; row < itsCoefficients; row++ {
+			if math.Abs(m[row][col]) > math.Abs(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(m[pivot][col]) < singularPivot {
+			return inverse, false
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		inverse[col], inverse[pivot] = inverse[pivot], inverse[col]
+
+		scale := m[col][col]
+		for k := range itsCoefficients {
+			m[col][k] /= scale
+			inverse[col][k] /= scale
+		}
+
+		for row := range itsCoefficients {
+			if row == col {
+				continue
+			}
+			factor := m[row][col]
+			for k := range itsCoefficients {
+				m[row][k] -= factor * m[col][k]
+				inverse[row][k] -= factor * inverse[col][k]
+			}
+		}
+	}
+
+	return inverse, true
+}
@@ -0,0 +1,293 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const (
+	weeklySeason = 7
+
+	optimizerStep       = 0.5
+	optimizerIterations = 2000
+)
+
+type fittedModel struct {
+	parameters map[string]float64
+	sigma      float64
+	forecast   []float64
+	// variances are the forecast error variances for each horizon step.
+	variances []float64
+}
+
+// holtWinters fits additive Holt–Winters with a weekly season by minimizing
+// the one-step-ahead squared errors.
+func holtWinters(values []float64, horizon int) (*fittedModel, error) {
+	m := weeklySeason
+	if len(values) < 2*m {
+		return nil, ErrNotEnoughData
+	}
+
+	initialLevel := stats.Mean(values[:m])
+	initialTrend := (stats.Mean(values[m:2*m]) - initialLevel) / float64(m)
+	initialSeason := make([]float64, m)
+	for i := range m {
+		initialSeason[i] = values[i] - initialLevel
+	}
+
+	run := func(alpha, beta, gamma float64) (float64, float64, float64, []float64) {
+		level, trend := initialLevel, initialTrend
+		season := append([]float64{}, initialSeason...)
+		sse := 0.0
+		for t := m; t < len(values); t++ {
+			s := season[t%m]
+			errorTerm := values[t] - (level + trend + s)
+			sse += errorTerm * errorTerm
+
+			previousLevel := level
+			level = alpha*(values[t]-s) + (1-alpha)*(level+trend)
+			trend = beta*(level-previousLevel) + (1-beta)*trend
+			season[t%m] = gamma*(values[t]-level) + (1-gamma)*s
+		}
+		return sse, level, trend, season
+	}
+
+	objective := func(x []float64) float64 {
+		sse, _, _, _ := run(stats.Logistic(x[0]), stats.Logistic(x[1]), stats.Logistic(x[2]))
+		return sse
+	}
+
+	best := stats.Minimize(objective, []float64{-1, -3, -2}, optimizerStep, optimizerIterations)
+	alpha, beta, gamma := stats.Logistic(best[0]), stats.Logistic(best[1]), stats.Logistic(best[2])
+	sse, level, trend, season := run(alpha, beta, gamma)
+
+	fitted := len(values) - m
+	sigma := math.Sqrt(sse / float64(max(fitted-3, 1)))
+
+	forecast := make([]float64, horizon)
+	variances := make([]float64, horizon)
+	cumulative := 0.0
+	for h := 1; h <= horizon; h++ {
+		forecast[h-1] = level + float64(h)*trend + season[(len(values)+h-1)%m]
+		variances[h-1] = sigma * sigma * (1 + cumulative)
+
+		// Variance term for the next step of the equivalent ETS(A,A,A) model,
+		// whose seasonal smoothing parameter is gamma * (1 - alpha).
+		c := alpha * (1 + float64(h)*beta)
+		if h%m == 0 {
+			c += gamma * (1 - alpha)
+		}
+		cumulative += c * c
+	}
+
+	return &fittedModel{
+		parameters: map[string]float64{"alpha": alpha, "beta": beta, "gamma": gamma},
+		sigma:      sigma,
+		forecast:   forecast,
+		variances:  variances,
+	}, nil
+}
+
+// arima fits ARIMA(p, d, q) by conditional sum of squares. The AR and MA
+// coefficients are parameterized through partial autocorrelations, which keeps
+// the model stationary and invertible.
+func arima(values []float64, order ARIMAOrder, horizon int) (*fittedModel, error) {
+	p, d, q := order.P, order.D, order.Q
+
+	differenced := append([]float64{}, values...)
+	for range d {
+		differenced = difference(differenced)
+	}
+
+	if len(differenced) < p+q+minTrendPoints {
+		return nil, ErrNotEnoughData
+	}
+
+	mean := 0.0
+	if d == 0 {
+		mean = stats.Mean(differenced)
+	}
+	centered := make([]float64, len(differenced))
+	for i, v := range differenced {
+		centered[i] = v - mean
+	}
+
+	residuals := func(phi, theta []float64) ([]float64, float64) {
+		errs := make([]float64, len(centered))
+		sse := 0.0
+		for t := p; t < len(centered); t++ {
+			prediction := 0.0
+			for i := range p {
+				prediction += phi[i] * centered[t-i-1]
+			}
+			for j := range q {
+				if t-j-1 >= 0 {
+					prediction += theta[j] * errs[t-j-1]
+				}
+			}
+			errs[t] = centered[t] - prediction
+			sse += errs[t] * errs[t]
+		}
+		return errs, sse
+	}
+
+	unpack := func(x []float64) ([]float64, []float64) {
+		return fromPartialAutocorrelations(x[:p]), negate(fromPartialAutocorrelations(x[p:]))
+	}
+
+	phi, theta := []float64{}, []float64{}
+	if p+q > 0 {
+		best := stats.Minimize(func(x []float64) float64 {
+			_, sse := residuals(unpack(x))
+			return sse
+		}, make([]float64, p+q), optimizerStep, optimizerIterations)
+		phi, theta = unpack(best)
+	}
+
+	errs, sse := residuals(phi, theta)
+	sigma := math.Sqrt(sse / float64(max(len(centered)-p-p-q, 1)))
+
+	extended := append([]float64{}, centered...)
+	extendedErrs := append([]float64{}, errs...)
+	for range horizon {
+		t := len(extended)
+		next := 0.0
+		for i := range p {
+			next += phi[i] * extended[t-i-1]
+		}
+		for j := range q {
+			next += theta[j] * extendedErrs[t-j-1]
+		}
+		extended = append(extended, next)
+		extendedErrs = append(extendedErrs, 0)
+	}
+
+	forecast := extended[len(centered):]
+	for i := range forecast {
+		forecast[i] += mean
+	}
+	forecast = integrate(values, forecast, d)
+
+	psi := psiWeights(integratedAR(phi, d), theta, horizon)
+	variances := make([]float64, horizon)
+	cumulative := 0.0
+	for h := range horizon {
+		cumulative += psi[h] * psi[h]
+		variances[h] = sigma * sigma * cumulative
+	}
+
+	parameters := map[string]float64{}
+	for i, v := range phi {
+		parameters[fmt.Sprintf("ar%d", i+1)] = v
+	}
+	for j, v := range theta {
+		parameters[fmt.Sprintf("ma%d", j+1)] = v
+	}
+	if d == 0 {
+		parameters["mean"] = mean
+	}
+
+	return &fittedModel{
+		parameters: parameters,
+		sigma:      sigma,
+		forecast:   forecast,
+		variances:  variances,
+	}, nil
+}
+
+func difference(values []float64) []float64 {
+	result := make([]float64, len(values)-1)
+	for i := range result {
+		result[i] = values[i+1] - values[i]
+	}
+
+	return result
+}
+
+// integrate undoes d rounds of differencing for forecasts of the differenced series.
+func integrate(history, forecast []float64, d int) []float64 {
+	if d == 0 {
+		return forecast
+	}
+
+	levels := [][]float64{history}
+	for range d - 1 {
+		levels = append(levels, difference(levels[len(levels)-1]))
+	}
+
+	result := forecast
+	for k := d - 1; k >= 0; k-- {
+		last := levels[k][len(levels[k])-1]
+		integrated := make([]float64, len(result))
+		for i, v := range result {
+			last += v
+			integrated[i] = last
+		}
+		result = integrated
+	}
+
+	return result
+}
+
+// fromPartialAutocorrelations maps unconstrained values to coefficients of a
+// stationary AR polynomial using the Durbin–Levinson recursion.
+func fromPartialAutocorrelations(x []float64) []float64 {
+	coefficients := []float64{}
+	for k := range x {
+		r := math.Tanh(x[k])
+		next := make([]float64, k+1)
+		for j := range k {
+			next[j] = coefficients[j] - r*coefficients[k-j-1]
+		}
+		next[k] = r
+		coefficients = next
+	}
+
+	return coefficients
+}
+
+func negate(values []float64) []float64 {
+	result := make([]float64, len(values))
+	for i, v := range values {
+		result[i] = -v
+	}
+
+	return result
+}
+
+// integratedAR multiplies the AR polynomial by (1 - B)^d and returns the
+// coefficients in the form x_t = sum(c_i * x_{t-i}).
+func integratedAR(phi []float64, d int) []float64 {
+	// Polynomial 1 - phi_1 B - ... - phi_p B^p.
+	polynomial := append([]float64{1}, negate(phi)...)
+	for range d {
+		next := make([]float64, len(polynomial)+1)
+		for i, c := range polynomial {
+			next[i] += c
+			next[i+1] -= c
+		}
+		polynomial = next
+	}
+
+	return negate(polynomial[1:])
+}
+
+func psiWeights(ar, theta []float64, horizon int) []float64 {
+	psi := make([]float64, horizon)
+	for j := range horizon {
+		if j == 0 {
+			psi[j] = 1
+			continue
+		}
+		if j <= len(theta) {
+			psi[j] = theta[j-1]
+		}
+		for i := 1; i <= min(j, len(ar)); i++ {
+			psi[j] += ar[i-1] * psi[j-i]
+		}
+	}
+
+	return psi
+}
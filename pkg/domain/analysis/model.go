@@ -0,0 +1,227 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TimeRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From != nil && t.Before(*r.From) {
+		return false
+	}
+	if r.To != nil && t.After(*r.To) {
+		return false
+	}
+
+	return true
+}
+
+type Percentile struct {
+	Rank  float64
+	Value float64
+}
+
+type Statistics struct {
+	ParameterID            uuid.UUID
+	Range                  TimeRange
+	Count                  int
+	Mean                   float64
+	Median                 float64
+	StdDev                 float64
+	Min                    float64
+	Max                    float64
+	Percentiles            []Percentile
+	CoefficientOfVariation float64
+	FirstLoggedAt          *time.Time
+	LastLoggedAt           *time.Time
+	Frequency              LoggingFrequency
+}
+
+type LoggingFrequency struct {
+	// DaysLogged is the number of distinct calendar days with at least one entry.
+	DaysLogged int
+	// DaysSpanned is the number of calendar days from the first to the last entry, inclusive.
+	DaysSpanned int
+	// EntriesPerDay is Count divided by DaysSpanned.
+	EntriesPerDay float64
+	// LoggedDayRatio is DaysLogged divided by DaysSpanned.
+	LoggedDayRatio float64
+}
+
+type TrendDirection string
+
+const (
+	TrendIncreasing TrendDirection = "increasing"
+	TrendDecreasing TrendDirection = "decreasing"
+	TrendNone       TrendDirection = "none"
+)
+
+type MannKendall struct {
+	S        float64
+	Variance float64
+	Z        float64
+	PValue   float64
+	// Tau is Kendall's rank correlation between time and value.
+	Tau float64
+}
+
+type SenSlope struct {
+	// Slope is the median change per day.
+	Slope     float64
+	Intercept float64
+	Lower     float64
+	Upper     float64
+}
+
+type Changepoint struct {
+	// Date is the first day of the new level.
+	Date       time.Time
+	MeanBefore float64
+	MeanAfter  float64
+	Shift      float64
+}
+
+type Trend struct {
+	ParameterID  uuid.UUID
+	Range        TimeRange
+	Points       int
+	Alpha        float64
+	Direction    TrendDirection
+	MannKendall  MannKendall
+	SenSlope     SenSlope
+	Changepoints []Changepoint
+}
+
+type ProfileBucket struct {
+	// Key is the weekday (0 = Sunday) or the hour of day.
+	Key    int
+	Label  string
+	Count  int
+	Mean   float64
+	StdDev float64
+	Lower  float64
+	Upper  float64
+}
+
+// WeekdayEffect is a one-way ANOVA of daily values grouped by weekday.
+type WeekdayEffect struct {
+	FStatistic  float64
+	DFBetween   int
+	DFWithin    int
+	PValue      float64
+	Significant bool
+}
+
+type LoggingTimes struct {
+	// ByHour counts entries per local hour of day.
+	ByHour []int
+	// ByWeekday counts entries per weekday, starting with Sunday.
+	ByWeekday []int
+	PeakHour  int
+}
+
+type Profile struct {
+	ParameterID   uuid.UUID
+	Range         TimeRange
+	Location      *time.Location
+	Alpha         float64
+	Weekdays      []ProfileBucket
+	Hours         []ProfileBucket
+	WeekdayEffect *WeekdayEffect
+	LoggingTimes  LoggingTimes
+}
+
+type ForecastMethod string
+
+const (
+	ForecastMethodHoltWinters ForecastMethod = "holt_winters"
+	ForecastMethodARIMA       ForecastMethod = "arima"
+)
+
+type ARIMAOrder struct {
+	P int
+	D int
+	Q int
+}
+
+type ForecastPoint struct {
+	Date  time.Time
+	Value float64
+	Lower float64
+	Upper float64
+}
+
+type Forecast struct {
+	ParameterID uuid.UUID
+	Method      ForecastMethod
+	Order       *ARIMAOrder
+	Level       float64
+	// Parameters are the fitted model coefficients by name.
+	Parameters map[string]float64
+	// Sigma is the standard deviation of the one-step-ahead residuals.
+	Sigma        float64
+	TrainingFrom time.Time
+	TrainingTo   time.Time
+	Points       []ForecastPoint
+}
+
+// EventWindow summarizes the observed days on one side of an event.
+type EventWindow struct {
+	From   time.Time
+	To     time.Time
+	Days   int
+	Mean   float64
+	StdDev float64
+}
+
+type PermutationTest struct {
+	Permutations int
+	// PValue is two-sided and counts the observed labelling, so it is never zero.
+	PValue float64
+}
+
+type Coefficient struct {
+	Estimate   float64
+	StdError   float64
+	TStatistic float64
+	PValue     float64
+}
+
+// InterruptedTimeSeries is the segmented regression
+//
+//	y = b0 + b1·t + b2·after + b3·t·after
+//
+// where t is the number of days since the event and after is 1 from the
+// event day on. LevelChange is b2 and SlopeChange is b3.
+type InterruptedTimeSeries struct {
+	Intercept   Coefficient
+	PreSlope    Coefficient
+	LevelChange Coefficient
+	SlopeChange Coefficient
+	RSquared    float64
+	// DegreesOfFreedom is the number of days minus the four coefficients.
+	DegreesOfFreedom int
+}
+
+type EventStudy struct {
+	ParameterID uuid.UUID
+	// EventDate is the first day of the after window.
+	EventDate  time.Time
+	Alpha      float64
+	Before     EventWindow
+	After      EventWindow
+	Difference float64
+	// EffectSize is Hedges' g of the after days against the before days.
+	EffectSize      float64
+	PermutationTest PermutationTest
+	Regression      InterruptedTimeSeries
+	// Significant reports whether the permutation test rejects no change at
+	// Alpha.
+	Significant bool
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const (
+	daysPerWeek = 7
+	hoursPerDay = 24
+)
+
+// bucket summarizes values with a two-sided (1 - alpha) confidence interval for the mean.
+func bucket(key int, label string, values []float64, alpha float64) ProfileBucket {
+	result := ProfileBucket{
+		Key:   key,
+		Label: label,
+		Count: len(values),
+		Mean:  stats.Mean(values),
+	}
+
+	result.Lower = result.Mean
+	result.Upper = result.Mean
+	if len(values) > 1 {
+		result.StdDev = stats.StdDev(values)
+		n := float64(len(values))
+		margin := stats.StudentTQuantile(1-alpha/2, n-1) * result.StdDev / math.Sqrt(n)
+		result.Lower = result.Mean - margin
+		result.Upper = result.Mean + margin
+	}
+
+	return result
+}
+
+// oneWayANOVA tests whether the group means differ. Groups with no values are
+// ignored. It returns nil when there is not enough data for the test.
+func oneWayANOVA(groups [][]float64, alpha float64) *WeekdayEffect {
+	var all []float64
+	nonEmpty := 0
+	for _, g := range groups {
+		if len(g) > 0 {
+			nonEmpty++
+			all = append(all, g...)
+		}
+	}
+
+	dfBetween := nonEmpty - 1
+	dfWithin := len(all) - nonEmpty
+	if dfBetween < 1 || dfWithin < 1 {
+		return nil
+	}
+
+	grandMean := stats.Mean(all)
+	var between, within float64
+	for _, g := range groups {
+		if len(g) == 0 {
+			continue
+		}
+		mean := stats.Mean(g)
+		between += float64(len(g)) * (mean - grandMean) * (mean - grandMean)
+		for _, v := range g {
+			within += (v - mean) * (v - mean)
+		}
+	}
+
+	// Without any variation inside the groups the F statistic is undefined.
+	if within == 0 {
+		return nil
+	}
+
+	fStatistic := (between / float64(dfBetween)) / (within / float64(dfWithin))
+	pValue := 1 - stats.FCDF(fStatistic, float64(dfBetween), float64(dfWithin))
+
+	return &WeekdayEffect{
+		FStatistic:  fStatistic,
+		DFBetween:   dfBetween,
+		DFWithin:    dfWithin,
+		PValue:      pValue,
+		Significant: pValue < alpha,
+	}
+}
@@ -0,0 +1,83 @@
+package analysis
+
+import (
+	"context"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetParameterStatistics(ctx context.Context, input GetParameterStatisticsInput) (*Statistics, error)
+	GetTrend(ctx context.Context, input GetTrendInput) (*Trend, error)
+	GetProfile(ctx context.Context, input GetProfileInput) (*Profile, error)
+	GetForecast(ctx context.Context, input GetForecastInput) (*Forecast, error)
+	GetEventStudy(ctx context.Context, input GetEventStudyInput) (*EventStudy, error)
+}
+
+type GetParameterStatisticsInput struct {
+	ParameterID uuid.UUID
+	Range       TimeRange
+	// Exclude drops the measurements taken on days within these periods.
+	Exclude []series.Period
+	// Location is the time zone in which measurements are assigned to days
+	// when matching excluded periods. Nil means UTC.
+	Location *time.Location
+}
+
+type GetTrendInput struct {
+	Series series.GetDailySeriesInput
+	Range  TimeRange
+	// Alpha is the significance level of the Mann–Kendall test. Zero means 0.05.
+	Alpha float64
+	// Penalty is the PELT penalty per changepoint. Zero means a BIC-style
+	// penalty scaled by the estimated noise level.
+	Penalty float64
+	// MinSegmentLength is the minimum number of days between changepoints.
+	// Zero means DefaultMinSegmentLength.
+	MinSegmentLength int
+}
+
+type GetProfileInput struct {
+	// Series.Location sets the time zone used for weekdays and hours.
+	Series series.GetDailySeriesInput
+	Range  TimeRange
+	// Alpha is the significance level of the weekday test and sets the
+	// confidence level of the intervals. Zero means 0.05.
+	Alpha float64
+}
+
+type GetForecastInput struct {
+	// Series describes the training data. Models need a regular daily grid, so
+	// dropped gaps are filled by linear interpolation and MaxGap is ignored.
+	Series series.GetDailySeriesInput
+	Range  TimeRange
+	Method ForecastMethod
+	// Order is used by ARIMA. Each component is limited to MaxARIMAOrder.
+	Order ARIMAOrder
+	// Horizon is the number of days to forecast. Zero means DefaultHorizon.
+	Horizon int
+	// Level is the coverage of the prediction intervals. Zero means 0.95.
+	Level float64
+}
+
+type GetEventStudyInput struct {
+	// Series describes the outcome. Gaps are always dropped, since imputed
+	// days would fake evidence.
+	Series series.GetDailySeriesInput
+	// EventDate is a calendar date in Series.Location. The event day opens
+	// the after window.
+	EventDate time.Time
+	// DaysBefore and DaysAfter are the window lengths. Zero means
+	// DefaultEventWindow.
+	DaysBefore int
+	DaysAfter  int
+	// Alpha is the significance level of the permutation test. Zero means 0.05.
+	Alpha float64
+	// Permutations is the number of label shuffles. Zero means
+	// DefaultPermutations.
+	Permutations int
+	// Seed makes the permutation test reproducible.
+	Seed uint64
+}
@@ -0,0 +1,554 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultAlpha            = 0.05
+	DefaultMinSegmentLength = 7
+	DefaultHorizon          = 14
+	DefaultLevel            = 0.95
+	MaxHorizon              = 90
+	MaxARIMAOrder           = 2
+	DefaultEventWindow      = 28
+	MaxEventWindow          = 365
+	DefaultPermutations     = 10000
+	MaxPermutations         = 100000
+
+	minTrendPoints = 4
+	// minEventDays is the number of observed days needed on each side of an
+	// event, which leaves the regression at least two degrees of freedom.
+	minEventDays = 3
+)
+
+var (
+	ErrInvalidTimeRange    = errors.New("time range start must not be after its end")
+	ErrInvalidAlpha        = errors.New("significance level must be in (0, 1)")
+	ErrNotEnoughData       = errors.New("not enough data for analysis")
+	ErrInvalidHorizon      = errors.New("forecast horizon is out of range")
+	ErrInvalidLevel        = errors.New("prediction interval level must be in (0, 1)")
+	ErrInvalidOrder        = errors.New("ARIMA order is out of range")
+	ErrInvalidWindow       = errors.New("event window must be between 1 and 365 days")
+	ErrInvalidPermutations = errors.New("number of permutations is out of range")
+)
+
+type ServiceImpl struct {
+	parameterService   parameter.Service
+	measurementService measurement.Service
+	seriesService      series.Service
+}
+
+func NewService(
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+	seriesService series.Service,
+) Service {
+	return &ServiceImpl{
+		parameterService:   parameterService,
+		measurementService: measurementService,
+		seriesService:      seriesService,
+	}
+}
+
+func (s *ServiceImpl) GetParameterStatistics(
+	ctx context.Context,
+	input GetParameterStatisticsInput,
+) (*Statistics, error) {
+	observations, err := s.listObservations(ctx, input.ParameterID, input.Range, input.Exclude, input.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	result := describe(observations)
+	result.ParameterID = input.ParameterID
+	result.Range = input.Range
+
+	return &result, nil
+}
+
+// listObservations returns the measurements within the time range, leaving
+// out those taken on excluded days in loc.
+func (s *ServiceImpl) listObservations(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	timeRange TimeRange,
+	exclude []series.Period,
+	loc *time.Location,
+) ([]observation, error) {
+	if timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	analysisParameter, err := s.parameterService.GetParameterByID(ctx, parameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if analysisParameter.IsDerived() {
+		return s.listDerivedObservations(ctx, analysisParameter.ID, timeRange, exclude, loc)
+	}
+
+	listed, err := s.measurementService.ListMeasurementsByParameter(
+		ctx, analysisParameter.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	measurements := listed.Items
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	observations := []observation{}
+	for _, m := range measurements {
+		floatMeasurement, ok := m.(*measurement.FloatMeasurement)
+		if !ok || !timeRange.Contains(m.GetTimestamp()) || excluded(m.GetTimestamp().In(loc), exclude) {
+			continue
+		}
+
+		observations = append(observations, observation{
+			Timestamp: floatMeasurement.GetTimestamp(),
+			Value:     floatMeasurement.GetValue(),
+		})
+	}
+
+	return observations, nil
+}
+
+// listDerivedObservations uses the daily values of a derived parameter as its
+// observations, since derived parameters have no measurements of their own.
+func (s *ServiceImpl) listDerivedObservations(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	timeRange TimeRange,
+	exclude []series.Period,
+	loc *time.Location,
+) ([]observation, error) {
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, series.GetDailySeriesInput{
+		ParameterID: parameterID,
+		Location:    loc,
+		Exclude:     exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	observations := []observation{}
+	for _, p := range dailySeries.Points {
+		if timeRange.Contains(p.Date) {
+			observations = append(observations, observation{
+				Timestamp: p.Date,
+				Value:     p.Value,
+			})
+		}
+	}
+
+	return observations, nil
+}
+
+func (s *ServiceImpl) GetTrend(ctx context.Context, input GetTrendInput) (*Trend, error) {
+	alpha, err := significanceLevel(input.Alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	minSegment := input.MinSegmentLength
+	if minSegment <= 0 {
+		minSegment = DefaultMinSegmentLength
+	}
+
+	points, err := s.dailyPoints(ctx, input.Series, input.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(points) < minTrendPoints {
+		return nil, ErrNotEnoughData
+	}
+
+	days := make([]float64, len(points))
+	values := make([]float64, len(points))
+	for i, p := range points {
+		days[i] = p.Date.Sub(points[0].Date).Hours() / hoursPerDay
+		values[i] = p.Value
+	}
+
+	mk := mannKendall(values)
+	direction := TrendNone
+	if mk.PValue < alpha {
+		if mk.S > 0 {
+			direction = TrendIncreasing
+		} else {
+			direction = TrendDecreasing
+		}
+	}
+
+	changepoints := []Changepoint{}
+	segmentStart := 0
+	indices := detectChangepoints(values, input.Penalty, minSegment)
+	for i, index := range indices {
+		segmentEnd := len(values)
+		if i+1 < len(indices) {
+			segmentEnd = indices[i+1]
+		}
+
+		before := stats.Mean(values[segmentStart:index])
+		after := stats.Mean(values[index:segmentEnd])
+		changepoints = append(changepoints, Changepoint{
+			Date:       points[index].Date,
+			MeanBefore: before,
+			MeanAfter:  after,
+			Shift:      after - before,
+		})
+		segmentStart = index
+	}
+
+	return &Trend{
+		ParameterID:  input.Series.ParameterID,
+		Range:        input.Range,
+		Points:       len(points),
+		Alpha:        alpha,
+		Direction:    direction,
+		MannKendall:  mk,
+		SenSlope:     senSlope(days, values, mk.Variance, alpha),
+		Changepoints: changepoints,
+	}, nil
+}
+
+func (s *ServiceImpl) GetProfile(ctx context.Context, input GetProfileInput) (*Profile, error) {
+	alpha, err := significanceLevel(input.Alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := input.Series.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	points, err := s.dailyPoints(ctx, input.Series, input.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	observations, err := s.listObservations(
+		ctx, input.Series.ParameterID, input.Range, input.Series.Exclude, input.Series.Location,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Imputed days would pull every weekday towards its neighbours, so only
+	// observed days take part in the weekday profile.
+	byWeekday := make([][]float64, daysPerWeek)
+	for _, p := range points {
+		if !p.Imputed {
+			weekday := p.Date.In(loc).Weekday()
+			byWeekday[weekday] = append(byWeekday[weekday], p.Value)
+		}
+	}
+
+	byHour := make([][]float64, hoursPerDay)
+	logging := LoggingTimes{
+		ByHour:    make([]int, hoursPerDay),
+		ByWeekday: make([]int, daysPerWeek),
+	}
+	for _, o := range observations {
+		local := o.Timestamp.In(loc)
+		byHour[local.Hour()] = append(byHour[local.Hour()], o.Value)
+		logging.ByHour[local.Hour()]++
+		logging.ByWeekday[local.Weekday()]++
+	}
+
+	for hour, count := range logging.ByHour {
+		if count > logging.ByHour[logging.PeakHour] {
+			logging.PeakHour = hour
+		}
+	}
+
+	weekdays := []ProfileBucket{}
+	for weekday, values := range byWeekday {
+		if len(values) > 0 {
+			weekdays = append(weekdays, bucket(weekday, time.Weekday(weekday).String(), values, alpha))
+		}
+	}
+
+	hours := []ProfileBucket{}
+	for hour, values := range byHour {
+		if len(values) > 0 {
+			hours = append(hours, bucket(hour, fmt.Sprintf("%02d:00", hour), values, alpha))
+		}
+	}
+
+	return &Profile{
+		ParameterID:   input.Series.ParameterID,
+		Range:         input.Range,
+		Location:      loc,
+		Alpha:         alpha,
+		Weekdays:      weekdays,
+		Hours:         hours,
+		WeekdayEffect: oneWayANOVA(byWeekday, alpha),
+		LoggingTimes:  logging,
+	}, nil
+}
+
+func (s *ServiceImpl) GetForecast(ctx context.Context, input GetForecastInput) (*Forecast, error) {
+	horizon := input.Horizon
+	if horizon == 0 {
+		horizon = DefaultHorizon
+	}
+	if horizon < 0 || horizon > MaxHorizon {
+		return nil, ErrInvalidHorizon
+	}
+
+	level := input.Level
+	if level == 0 {
+		level = DefaultLevel
+	}
+	if level < 0 || level >= 1 {
+		return nil, ErrInvalidLevel
+	}
+
+	method := input.Method
+	if method == "" {
+		method = ForecastMethodHoltWinters
+	}
+
+	var order *ARIMAOrder
+	switch method {
+	case ForecastMethodHoltWinters:
+	case ForecastMethodARIMA:
+		o := input.Order
+		if o.P < 0 || o.D < 0 || o.Q < 0 || o.P > MaxARIMAOrder || o.D > MaxARIMAOrder || o.Q > MaxARIMAOrder {
+			return nil, ErrInvalidOrder
+		}
+		order = &o
+	default:
+		return nil, fmt.Errorf("unsupported forecast method: %s", input.Method)
+	}
+
+	seriesInput := input.Series
+	if seriesInput.Gaps.Strategy == "" || seriesInput.Gaps.Strategy == series.GapStrategyDrop {
+		seriesInput.Gaps.Strategy = series.GapStrategyLinear
+	}
+	seriesInput.Gaps.MaxGap = 0
+
+	points, err := s.dailyPoints(ctx, seriesInput, input.Range)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(points) == 0 {
+		return nil, ErrNotEnoughData
+	}
+
+	values := make([]float64, len(points))
+	for i, p := range points {
+		values[i] = p.Value
+	}
+
+	var model *fittedModel
+	if method == ForecastMethodARIMA {
+		model, err = arima(values, *order, horizon)
+	} else {
+		model, err = holtWinters(values, horizon)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	z := stats.NormalQuantile(1 - (1-level)/2)
+	last := points[len(points)-1].Date
+	forecastPoints := make([]ForecastPoint, horizon)
+	for h := range horizon {
+		margin := z * math.Sqrt(model.variances[h])
+		forecastPoints[h] = ForecastPoint{
+			Date:  last.AddDate(0, 0, h+1),
+			Value: model.forecast[h],
+			Lower: model.forecast[h] - margin,
+			Upper: model.forecast[h] + margin,
+		}
+	}
+
+	return &Forecast{
+		ParameterID:  input.Series.ParameterID,
+		Method:       method,
+		Order:        order,
+		Level:        level,
+		Parameters:   model.parameters,
+		Sigma:        model.sigma,
+		TrainingFrom: points[0].Date,
+		TrainingTo:   last,
+		Points:       forecastPoints,
+	}, nil
+}
+
+func (s *ServiceImpl) GetEventStudy(ctx context.Context, input GetEventStudyInput) (*EventStudy, error) {
+	alpha, err := significanceLevel(input.Alpha)
+	if err != nil {
+		return nil, err
+	}
+
+	daysBefore, err := eventWindow(input.DaysBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	daysAfter, err := eventWindow(input.DaysAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	permutations := input.Permutations
+	if permutations == 0 {
+		permutations = DefaultPermutations
+	}
+	if permutations < 0 || permutations > MaxPermutations {
+		return nil, ErrInvalidPermutations
+	}
+
+	seriesInput := input.Series
+	seriesInput.Gaps = series.GapOptions{Strategy: series.GapStrategyDrop}
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, seriesInput)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := seriesInput.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	eventDate := time.Date(input.EventDate.Year(), input.EventDate.Month(), input.EventDate.Day(), 0, 0, 0, 0, loc)
+
+	var days, values, before, after []float64
+	for _, p := range dailySeries.Points {
+		// Rounding absorbs the hour gained or lost at daylight saving changes.
+		day := math.Round(p.Date.Sub(eventDate).Hours() / hoursPerDay)
+		if day < -float64(daysBefore) || day >= float64(daysAfter) {
+			continue
+		}
+
+		days = append(days, day)
+		values = append(values, p.Value)
+		if day < 0 {
+			before = append(before, p.Value)
+		} else {
+			after = append(after, p.Value)
+		}
+	}
+
+	if len(before) < minEventDays || len(after) < minEventDays {
+		return nil, ErrNotEnoughData
+	}
+
+	regression, ok := interruptedTimeSeries(days, values)
+	if !ok {
+		return nil, ErrNotEnoughData
+	}
+
+	rng := rand.New(rand.NewPCG(input.Seed, input.Seed))
+	// Serial correlation between neighbouring days makes the permutation
+	// test optimistic, which is why the regression is reported alongside.
+	test := PermutationTest{
+		Permutations: permutations,
+		PValue:       stats.PermutationTest(before, after, permutations, rng),
+	}
+
+	result := &EventStudy{
+		ParameterID: seriesInput.ParameterID,
+		EventDate:   eventDate,
+		Alpha:       alpha,
+		Before: EventWindow{
+			From:   eventDate.AddDate(0, 0, -daysBefore),
+			To:     eventDate.AddDate(0, 0, -1),
+			Days:   len(before),
+			Mean:   stats.Mean(before),
+			StdDev: stats.StdDev(before),
+		},
+		After: EventWindow{
+			From:   eventDate,
+			To:     eventDate.AddDate(0, 0, daysAfter-1),
+			Days:   len(after),
+			Mean:   stats.Mean(after),
+			StdDev: stats.StdDev(after),
+		},
+		EffectSize:      stats.HedgesG(before, after),
+		PermutationTest: test,
+		Regression:      regression,
+		Significant:     test.PValue < alpha,
+	}
+	result.Difference = result.After.Mean - result.Before.Mean
+
+	return result, nil
+}
+
+func eventWindow(days int) (int, error) {
+	if days == 0 {
+		return DefaultEventWindow, nil
+	}
+	if days < 0 || days > MaxEventWindow {
+		return 0, ErrInvalidWindow
+	}
+
+	return days, nil
+}
+
+// dailyPoints returns the daily series restricted to the time range.
+func (s *ServiceImpl) dailyPoints(
+	ctx context.Context,
+	input series.GetDailySeriesInput,
+	timeRange TimeRange,
+) ([]series.Point, error) {
+	if timeRange.From != nil && timeRange.To != nil && timeRange.From.After(*timeRange.To) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	points := []series.Point{}
+	for _, p := range dailySeries.Points {
+		if timeRange.Contains(p.Date) {
+			points = append(points, p)
+		}
+	}
+
+	return points, nil
+}
+
+func excluded(t time.Time, periods []series.Period) bool {
+	for _, period := range periods {
+		if period.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func significanceLevel(alpha float64) (float64, error) {
+	if alpha == 0 {
+		return DefaultAlpha, nil
+	}
+	if alpha < 0 || alpha >= 1 {
+		return 0, ErrInvalidAlpha
+	}
+
+	return alpha, nil
+}
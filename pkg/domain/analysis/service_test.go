@@ -0,0 +1,645 @@
+package analysis_test
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/analysis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetParameterStatistics(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.April, 1, 22, 0, 0, 0, time.UTC)
+	for i, v := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		// Two entries on the last day, a skipped day in between.
+		day := min(i, 6)
+		if day >= 3 {
+			day++
+		}
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, day).Add(time.Duration(i)*time.Minute), v)
+	}
+
+	result, err := analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 8, result.Count)
+	assert.InDelta(t, 5.0, result.Mean, 1e-9)
+	assert.InDelta(t, 4.5, result.Median, 1e-9)
+	assert.InDelta(t, 2.138089935, result.StdDev, 1e-9)
+	assert.InDelta(t, 2.0, result.Min, 1e-9)
+	assert.InDelta(t, 9.0, result.Max, 1e-9)
+	assert.InDelta(t, 0.427617987, result.CoefficientOfVariation, 1e-9)
+	require.Len(t, result.Percentiles, 4)
+	assert.InDelta(t, 4.0, result.Percentiles[1].Value, 1e-9)
+	assert.Equal(t, start, *result.FirstLoggedAt)
+	assert.Equal(t, 7, result.Frequency.DaysLogged)
+	assert.Equal(t, 8, result.Frequency.DaysSpanned)
+	assert.InDelta(t, 1.0, result.Frequency.EntriesPerDay, 1e-9)
+	assert.InDelta(t, 0.875, result.Frequency.LoggedDayRatio, 1e-9)
+}
+
+func TestGetParameterStatistics_ExcludePeriods(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.April, 1, 22, 0, 0, 0, time.UTC)
+	for i, v := range []float64{6, 7, 3, 2, 8} {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), v)
+	}
+
+	sick := series.Period{
+		Start: time.Date(2025, time.April, 3, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, time.April, 4, 0, 0, 0, 0, time.UTC),
+	}
+
+	result, err := analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+		Exclude:     []series.Period{sick},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Count)
+	assert.InDelta(t, 7.0, result.Mean, 1e-9)
+
+	// 22:00 UTC is the next day in Berlin, which shifts the excluded entries.
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	result, err = analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+		Exclude:     []series.Period{sick},
+		Location:    berlin,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Count)
+	assert.InDelta(t, 16.0/3, result.Mean, 1e-9)
+}
+
+func TestGetParameterStatistics_DerivedParameter(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.April, 1, 22, 0, 0, 0, time.UTC)
+	for i, v := range []float64{6, 7, 8} {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), v)
+	}
+
+	minutes, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   param.UserID,
+		Name:     "Sleep minutes",
+		DataType: parameter.DataTypeFloat,
+		Formula:  "{" + param.ID.String() + "} * 60",
+	})
+	require.NoError(t, err)
+
+	result, err := analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: minutes.ID,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, result.Count)
+	assert.InDelta(t, 420.0, result.Mean, 1e-9)
+	assert.Equal(t, 3, result.Frequency.DaysLogged)
+}
+
+func TestGetParameterStatistics_TimeRange(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.April, 1, 22, 0, 0, 0, time.UTC)
+	for i := range 10 {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), float64(i))
+	}
+
+	from := start.AddDate(0, 0, 5)
+	result, err := analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+		Range:       analysis.TimeRange{From: &from},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Count)
+	assert.InDelta(t, 7.0, result.Mean, 1e-9)
+
+	to := start
+	_, err = analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+		Range:       analysis.TimeRange{From: &from, To: &to},
+	})
+	require.ErrorIs(t, err, analysis.ErrInvalidTimeRange)
+}
+
+func TestGetParameterStatistics_NoMeasurements(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	result, err := analysisService.GetParameterStatistics(context.Background(), analysis.GetParameterStatisticsInput{
+		ParameterID: param.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Count)
+	assert.Nil(t, result.FirstLoggedAt)
+}
+
+// noise is a fixed zero-mean pattern so tests stay deterministic.
+func noise(i int) float64 {
+	pattern := []float64{0.3, -0.2, 0.1, -0.4, 0.2, 0.0, -0.1, 0.4, -0.3, 0.1}
+	return pattern[i%len(pattern)]
+}
+
+func TestGetTrend_Increasing(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.January, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 30 {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), 70+0.1*float64(i)+noise(i))
+	}
+
+	result, err := analysisService.GetTrend(context.Background(), analysis.GetTrendInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30, result.Points)
+	assert.Equal(t, analysis.TrendIncreasing, result.Direction)
+	assert.Less(t, result.MannKendall.PValue, 0.05)
+	assert.InDelta(t, 0.1, result.SenSlope.Slope, 0.03)
+	assert.LessOrEqual(t, result.SenSlope.Lower, result.SenSlope.Slope)
+	assert.GreaterOrEqual(t, result.SenSlope.Upper, result.SenSlope.Slope)
+}
+
+func TestGetTrend_StepChange(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.February, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 60 {
+		level := 62.0
+		if i >= 30 {
+			level = 56.0
+		}
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), level+noise(i))
+	}
+
+	result, err := analysisService.GetTrend(context.Background(), analysis.GetTrendInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Changepoints, 1)
+	assert.Equal(t, time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC), result.Changepoints[0].Date)
+	assert.InDelta(t, -6.0, result.Changepoints[0].Shift, 0.2)
+}
+
+func TestGetTrend_Stable(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.February, 1, 8, 0, 0, 0, time.UTC)
+	for i := range 40 {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), 60+noise(i))
+	}
+
+	result, err := analysisService.GetTrend(context.Background(), analysis.GetTrendInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, analysis.TrendNone, result.Direction)
+	assert.Empty(t, result.Changepoints)
+}
+
+func TestGetTrend_NotEnoughData(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+	domaintest.LogValue(t, measurementService, param.ID, time.Now(), 1)
+
+	_, err := analysisService.GetTrend(context.Background(), analysis.GetTrendInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+	})
+	require.ErrorIs(t, err, analysis.ErrNotEnoughData)
+}
+
+func TestGetProfile_WeekdayEffect(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 2025-06-02 is a Monday. Entries are logged at 21:30 Berlin time.
+	start := time.Date(2025, time.June, 2, 21, 30, 0, 0, berlin)
+	for i := range 56 {
+		timestamp := start.AddDate(0, 0, i)
+		value := 8.0 + noise(i)
+		if wd := timestamp.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			value += 2
+		}
+		domaintest.LogValue(t, measurementService, param.ID, timestamp.UTC(), value)
+	}
+
+	result, err := analysisService.GetProfile(context.Background(), analysis.GetProfileInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID, Location: berlin},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Weekdays, 7)
+	sunday := result.Weekdays[0]
+	assert.Equal(t, "Sunday", sunday.Label)
+	assert.Equal(t, 8, sunday.Count)
+	assert.Less(t, sunday.Lower, sunday.Mean)
+	assert.Greater(t, sunday.Upper, sunday.Mean)
+	assert.Greater(t, sunday.Mean, result.Weekdays[3].Upper)
+
+	require.NotNil(t, result.WeekdayEffect)
+	assert.True(t, result.WeekdayEffect.Significant)
+	assert.Equal(t, 6, result.WeekdayEffect.DFBetween)
+	assert.Equal(t, 49, result.WeekdayEffect.DFWithin)
+
+	require.Len(t, result.Hours, 1)
+	assert.Equal(t, 21, result.Hours[0].Key)
+	assert.Equal(t, 21, result.LoggingTimes.PeakHour)
+	assert.Equal(t, 56, result.LoggingTimes.ByHour[21])
+	assert.Equal(t, 8, result.LoggingTimes.ByWeekday[time.Monday])
+}
+
+func TestGetProfile_NoWeekdayEffect(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	start := time.Date(2025, time.June, 2, 7, 0, 0, 0, time.UTC)
+	for i := range 42 {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), 70+noise(i))
+	}
+
+	result, err := analysisService.GetProfile(context.Background(), analysis.GetProfileInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.WeekdayEffect)
+	assert.False(t, result.WeekdayEffect.Significant)
+}
+
+func TestGetForecast_HoltWinters(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	weekly := []float64{0, 1, 2, 1, 0, -2, -2}
+	start := time.Date(2025, time.May, 5, 9, 0, 0, 0, time.UTC)
+	for i := range 56 {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), 50+0.2*float64(i)+weekly[i%7])
+	}
+
+	result, err := analysisService.GetForecast(context.Background(), analysis.GetForecastInput{
+		Series:  series.GetDailySeriesInput{ParameterID: param.ID},
+		Horizon: 14,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Points, 14)
+	assert.Equal(t, analysis.ForecastMethodHoltWinters, result.Method)
+	assert.Equal(t, time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC), result.Points[0].Date)
+
+	for h, p := range result.Points {
+		i := 56 + h
+		assert.InDelta(t, 50+0.2*float64(i)+weekly[i%7], p.Value, 0.5)
+		assert.LessOrEqual(t, p.Lower, p.Value)
+		assert.GreaterOrEqual(t, p.Upper, p.Value)
+	}
+}
+
+func TestGetForecast_ARIMA(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	rng := rand.New(rand.NewSource(42))
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	value := 0.0
+	for i := range 300 {
+		value = 0.6*value + rng.NormFloat64()
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), 10+value)
+	}
+
+	result, err := analysisService.GetForecast(context.Background(), analysis.GetForecastInput{
+		Series:  series.GetDailySeriesInput{ParameterID: param.ID},
+		Method:  analysis.ForecastMethodARIMA,
+		Order:   analysis.ARIMAOrder{P: 1},
+		Horizon: 30,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.6, result.Parameters["ar1"], 0.1)
+	assert.InDelta(t, 10, result.Parameters["mean"], 0.3)
+	assert.InDelta(t, 1, result.Sigma, 0.15)
+
+	// Forecasts revert to the mean and intervals widen with the horizon.
+	last := result.Points[len(result.Points)-1]
+	assert.InDelta(t, result.Parameters["mean"], last.Value, 0.01)
+	assert.Greater(t, last.Upper-last.Lower, result.Points[0].Upper-result.Points[0].Lower)
+}
+
+func TestGetForecast_RandomWalk(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	rng := rand.New(rand.NewSource(7))
+	start := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	value := 100.0
+	for i := range 60 {
+		value += rng.NormFloat64()
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), value)
+	}
+
+	result, err := analysisService.GetForecast(context.Background(), analysis.GetForecastInput{
+		Series:  series.GetDailySeriesInput{ParameterID: param.ID},
+		Method:  analysis.ForecastMethodARIMA,
+		Order:   analysis.ARIMAOrder{D: 1},
+		Horizon: 9,
+	})
+	require.NoError(t, err)
+
+	firstWidth := result.Points[0].Upper - result.Points[0].Lower
+	for h, p := range result.Points {
+		assert.InDelta(t, value, p.Value, 1e-9)
+		assert.InDelta(t, firstWidth*math.Sqrt(float64(h+1)), p.Upper-p.Lower, 1e-9)
+	}
+}
+
+func TestGetForecast_InvalidOrder(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	_, err := analysisService.GetForecast(context.Background(), analysis.GetForecastInput{
+		Series: series.GetDailySeriesInput{ParameterID: param.ID},
+		Method: analysis.ForecastMethodARIMA,
+		Order:  analysis.ARIMAOrder{P: 3},
+	})
+	require.ErrorIs(t, err, analysis.ErrInvalidOrder)
+}
+
+func TestGetEventStudy_LevelShift(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	rng := rand.New(rand.NewSource(3))
+	event := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for day := -28; day < 28; day++ {
+		value := 6.5 + 0.3*rng.NormFloat64()
+		if day >= 0 {
+			value += 1
+		}
+		domaintest.LogValue(t, measurementService, param.ID, event.AddDate(0, 0, day).Add(7*time.Hour), value)
+	}
+
+	result, err := analysisService.GetEventStudy(context.Background(), analysis.GetEventStudyInput{
+		Series:       series.GetDailySeriesInput{ParameterID: param.ID},
+		EventDate:    event,
+		Permutations: 2000,
+		Seed:         1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 28, result.Before.Days)
+	assert.Equal(t, 28, result.After.Days)
+	assert.Equal(t, event.AddDate(0, 0, -28), result.Before.From)
+	assert.Equal(t, event.AddDate(0, 0, 27), result.After.To)
+	assert.InDelta(t, 1.0, result.Difference, 0.2)
+	assert.Greater(t, result.EffectSize, 2.0)
+	assert.True(t, result.Significant)
+	assert.InDelta(t, 1.0/2001, result.PermutationTest.PValue, 1e-9)
+	assert.InDelta(t, 1.0, result.Regression.LevelChange.Estimate, 0.4)
+	assert.Less(t, result.Regression.LevelChange.PValue, 0.01)
+	assert.Greater(t, result.Regression.SlopeChange.PValue, 0.05)
+	assert.Equal(t, 52, result.Regression.DegreesOfFreedom)
+}
+
+func TestGetEventStudy_SeparatesLevelAndSlope(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	event := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for day := -10; day < 10; day++ {
+		value := 5 + 0.1*float64(day)
+		if day >= 0 {
+			value += 2 + 0.05*float64(day)
+		}
+		domaintest.LogValue(t, measurementService, param.ID, event.AddDate(0, 0, day).Add(12*time.Hour), value)
+	}
+
+	result, err := analysisService.GetEventStudy(context.Background(), analysis.GetEventStudyInput{
+		Series:     series.GetDailySeriesInput{ParameterID: param.ID},
+		EventDate:  event,
+		DaysBefore: 10,
+		DaysAfter:  10,
+	})
+	require.NoError(t, err)
+
+	assert.InDelta(t, 5.0, result.Regression.Intercept.Estimate, 1e-9)
+	assert.InDelta(t, 0.1, result.Regression.PreSlope.Estimate, 1e-9)
+	assert.InDelta(t, 2.0, result.Regression.LevelChange.Estimate, 1e-9)
+	assert.InDelta(t, 0.05, result.Regression.SlopeChange.Estimate, 1e-9)
+	assert.InDelta(t, 1.0, result.Regression.RSquared, 1e-9)
+}
+
+func TestGetEventStudy_Errors(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	analysisService := analysis.NewService(parameterService, measurementService, seriesService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+
+	event := time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+	for day := -10; day < 2; day++ {
+		domaintest.LogValue(t, measurementService, param.ID, event.AddDate(0, 0, day), float64(day))
+	}
+
+	_, err := analysisService.GetEventStudy(context.Background(), analysis.GetEventStudyInput{
+		Series:    series.GetDailySeriesInput{ParameterID: param.ID},
+		EventDate: event,
+	})
+	require.ErrorIs(t, err, analysis.ErrNotEnoughData)
+
+	_, err = analysisService.GetEventStudy(context.Background(), analysis.GetEventStudyInput{
+		Series:     series.GetDailySeriesInput{ParameterID: param.ID},
+		EventDate:  event,
+		DaysBefore: analysis.MaxEventWindow + 1,
+	})
+	require.ErrorIs(t, err, analysis.ErrInvalidWindow)
+
+	_, err = analysisService.GetEventStudy(context.Background(), analysis.GetEventStudyInput{
+		Series:       series.GetDailySeriesInput{ParameterID: param.ID},
+		EventDate:    event,
+		Permutations: -1,
+	})
+	require.ErrorIs(t, err, analysis.ErrInvalidPermutations)
+}
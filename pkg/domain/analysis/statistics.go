@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+// DefaultPercentiles are reported by the statistics summary.
+func DefaultPercentiles() []float64 {
+	return []float64{0.05, 0.25, 0.75, 0.95}
+}
+
+type observation struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+func describe(observations []observation) Statistics {
+	result := Statistics{
+		Count:       len(observations),
+		Percentiles: []Percentile{},
+	}
+	if len(observations) == 0 {
+		return result
+	}
+
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].Timestamp.Before(observations[j].Timestamp)
+	})
+
+	values := make([]float64, len(observations))
+	days := make(map[time.Time]bool)
+	for i, o := range observations {
+		values[i] = o.Value
+		days[truncateToDay(o.Timestamp)] = true
+	}
+
+	sorted := stats.Sorted(values)
+	result.Mean = stats.Mean(values)
+	result.Median = stats.Median(values)
+	result.Min = sorted[0]
+	result.Max = sorted[len(sorted)-1]
+	if len(values) > 1 {
+		result.StdDev = stats.StdDev(values)
+	}
+	if result.Mean != 0 {
+		result.CoefficientOfVariation = result.StdDev / math.Abs(result.Mean)
+	}
+
+	for _, rank := range DefaultPercentiles() {
+		result.Percentiles = append(result.Percentiles, Percentile{
+			Rank:  rank,
+			Value: stats.Quantile(values, rank),
+		})
+	}
+
+	first := observations[0].Timestamp
+	last := observations[len(observations)-1].Timestamp
+	result.FirstLoggedAt = &first
+	result.LastLoggedAt = &last
+
+	daysSpanned := int(truncateToDay(last).Sub(truncateToDay(first)).Hours()/hoursPerDay) + 1
+	result.Frequency = LoggingFrequency{
+		DaysLogged:     len(days),
+		DaysSpanned:    daysSpanned,
+		EntriesPerDay:  float64(len(observations)) / float64(daysSpanned),
+		LoggedDayRatio: float64(len(days)) / float64(daysSpanned),
+	}
+
+	return result
+}
+
+func truncateToDay(t time.Time) time.Time {
+	utc := t.UTC()
+	return time.Date(utc.Year(), utc.Month(), utc.Day(), 0, 0, 0, 0, time.UTC)
+}
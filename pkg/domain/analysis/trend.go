@@ -0,0 +1,98 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+// mannKendall runs the Mann–Kendall test for a monotonic trend with the
+// variance corrected for ties.
+func mannKendall(values []float64) MannKendall {
+	n := len(values)
+
+	s := 0.0
+	for i := range n - 1 {
+		for j := i + 1; j < n; j++ {
+			switch {
+			case values[j] > values[i]:
+				s++
+			case values[j] < values[i]:
+				s--
+			}
+		}
+	}
+
+	ties := make(map[float64]int)
+	for _, v := range values {
+		ties[v]++
+	}
+
+	nf := float64(n)
+	variance := nf * (nf - 1) * (2*nf + 5)
+	for _, t := range ties {
+		tf := float64(t)
+		variance -= tf * (tf - 1) * (2*tf + 5)
+	}
+	variance /= 18
+
+	z := 0.0
+	if variance > 0 {
+		switch {
+		case s > 0:
+			z = (s - 1) / math.Sqrt(variance)
+		case s < 0:
+			z = (s + 1) / math.Sqrt(variance)
+		}
+	}
+
+	return MannKendall{
+		S:        s,
+		Variance: variance,
+		Z:        z,
+		PValue:   stats.TwoSidedNormalPValue(z),
+		Tau:      s / (nf * (nf - 1) / 2),
+	}
+}
+
+// senSlope estimates the median pairwise slope per day together with its
+// confidence interval derived from the Mann–Kendall variance.
+func senSlope(days, values []float64, mkVariance, alpha float64) SenSlope {
+	var slopes []float64
+	for i := range len(values) - 1 {
+		for j := i + 1; j < len(values); j++ {
+			if days[j] != days[i] {
+				slopes = append(slopes, (values[j]-values[i])/(days[j]-days[i]))
+			}
+		}
+	}
+
+	if len(slopes) == 0 {
+		return SenSlope{}
+	}
+
+	sort.Float64s(slopes)
+	slope := stats.Median(slopes)
+
+	residuals := make([]float64, len(values))
+	for i, v := range values {
+		residuals[i] = v - slope*days[i]
+	}
+
+	n := float64(len(slopes))
+	c := stats.NormalQuantile(1-alpha/2) * math.Sqrt(mkVariance)
+	lowerRank := clampRank(int(math.Round((n-c)/2))-1, len(slopes))
+	upperRank := clampRank(int(math.Round((n+c)/2)), len(slopes))
+
+	return SenSlope{
+		Slope:     slope,
+		Intercept: stats.Median(residuals),
+		Lower:     slopes[lowerRank],
+		Upper:     slopes[upperRank],
+	}
+}
+
+func clampRank(rank, length int) int {
+	return max(0, min(rank, length-1))
+}
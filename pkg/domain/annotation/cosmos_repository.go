@@ -0,0 +1,175 @@
+package annotation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName  = "correlateapp"
+	containerName = "Annotations"
+	partitionKey  = "/userId"
+)
+
+type CosmosAnnotationRepository struct {
+	client    *azcosmos.Client
+	container *azcosmos.ContainerClient
+}
+
+func NewCosmosAnnotationRepository(connectionString string) (*CosmosAnnotationRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for annotation repository: %w", err)
+	}
+
+	container, err := client.NewContainer(databaseName, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for annotation repository: %w", err)
+	}
+
+	return &CosmosAnnotationRepository{
+		client:    client,
+		container: container,
+	}, nil
+}
+
+func (r *CosmosAnnotationRepository) CreateAnnotation(
+	ctx context.Context,
+	annotation *Annotation,
+) (*Annotation, error) {
+	annotationJSON, err := json.Marshal(NewCosmosAnnotation(annotation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal annotation: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(annotation.UserID.String())
+
+	_, err = r.container.CreateItem(ctx, pk, annotationJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create annotation in Cosmos DB: %w", err)
+	}
+
+	return annotation, nil
+}
+
+func (r *CosmosAnnotationRepository) GetAnnotationByID(ctx context.Context, id uuid.UUID) (*Annotation, error) {
+	query := "SELECT * FROM annotations a WHERE a.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	annotations, err := r.queryAnnotations(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(annotations) == 0 {
+		return nil, ErrAnnotationNotFound
+	}
+
+	return annotations[0], nil
+}
+
+func (r *CosmosAnnotationRepository) ListAnnotationsByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*Annotation, error) {
+	query := "SELECT * FROM annotations a WHERE a.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryAnnotations(ctx, query, params)
+}
+
+func (r *CosmosAnnotationRepository) DeleteAnnotation(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the annotation to get its UserID (required for partition key)
+	annotation, err := r.GetAnnotationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(annotation.UserID.String())
+
+	_, err = r.container.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete annotation from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosAnnotationRepository) queryAnnotations(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Annotation, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	annotations := []*Annotation{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosAnnotation CosmosAnnotation
+			if err := json.Unmarshal(item, &cosmosAnnotation); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal annotation: %w", err)
+			}
+			annotations = append(annotations, NewAnnotation(&cosmosAnnotation))
+		}
+	}
+
+	return annotations, nil
+}
+
+type CosmosAnnotation struct {
+	ID           uuid.UUID   `json:"id"`
+	UserID       uuid.UUID   `json:"userId"`
+	ParameterIDs []uuid.UUID `json:"parameterIds,omitempty"`
+	Title        string      `json:"title"`
+	Description  string      `json:"description,omitempty"`
+	Category     string      `json:"category,omitempty"`
+	StartDate    time.Time   `json:"startDate"`
+	EndDate      time.Time   `json:"endDate"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+func NewCosmosAnnotation(annotation *Annotation) *CosmosAnnotation {
+	return &CosmosAnnotation{
+		ID:           annotation.ID,
+		UserID:       annotation.UserID,
+		ParameterIDs: annotation.ParameterIDs,
+		Title:        annotation.Title,
+		Description:  annotation.Description,
+		Category:     annotation.Category,
+		StartDate:    annotation.StartDate,
+		EndDate:      annotation.EndDate,
+		CreatedAt:    annotation.CreatedAt,
+		UpdatedAt:    annotation.UpdatedAt,
+	}
+}
+
+func NewAnnotation(cosmosAnnotation *CosmosAnnotation) *Annotation {
+	return &Annotation{
+		ID:           cosmosAnnotation.ID,
+		UserID:       cosmosAnnotation.UserID,
+		ParameterIDs: cosmosAnnotation.ParameterIDs,
+		Title:        cosmosAnnotation.Title,
+		Description:  cosmosAnnotation.Description,
+		Category:     cosmosAnnotation.Category,
+		StartDate:    cosmosAnnotation.StartDate,
+		EndDate:      cosmosAnnotation.EndDate,
+		CreatedAt:    cosmosAnnotation.CreatedAt,
+		UpdatedAt:    cosmosAnnotation.UpdatedAt,
+	}
+}
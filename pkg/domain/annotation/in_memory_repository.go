@@ -0,0 +1,67 @@
+package annotation
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu          sync.RWMutex
+	annotations map[uuid.UUID]*Annotation
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		annotations: make(map[uuid.UUID]*Annotation),
+	}
+}
+
+func (r *InMemoryRepository) CreateAnnotation(_ context.Context, annotation *Annotation) (*Annotation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.annotations[annotation.ID] = annotation
+
+	return annotation, nil
+}
+
+func (r *InMemoryRepository) GetAnnotationByID(_ context.Context, id uuid.UUID) (*Annotation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	annotation, ok := r.annotations[id]
+	if !ok {
+		return nil, ErrAnnotationNotFound
+	}
+
+	return annotation, nil
+}
+
+func (r *InMemoryRepository) ListAnnotationsByUser(_ context.Context, userID uuid.UUID) ([]*Annotation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var annotations []*Annotation
+	for _, annotation := range r.annotations {
+		if annotation.UserID == userID {
+			annotations = append(annotations, annotation)
+		}
+	}
+
+	return annotations, nil
+}
+
+func (r *InMemoryRepository) DeleteAnnotation(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.annotations[id]; !ok {
+		return ErrAnnotationNotFound
+	}
+
+	delete(r.annotations, id)
+
+	return nil
+}
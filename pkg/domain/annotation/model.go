@@ -0,0 +1,62 @@
+package annotation
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+// Annotation marks a day or a range of days on a user's timeline with an
+// event such as starting a medication, a vacation or an illness.
+type Annotation struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+	// ParameterIDs limits the annotation to these parameters. Empty means it
+	// applies to all of the user's parameters.
+	ParameterIDs []uuid.UUID
+	Title        string
+	Description  string
+	Category     string
+	// StartDate and EndDate are calendar dates stored at midnight UTC and
+	// interpreted in the user's time zone. EndDate is inclusive.
+	StartDate time.Time
+	EndDate   time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AppliesTo reports whether the annotation concerns the parameter.
+func (a *Annotation) AppliesTo(parameterID uuid.UUID) bool {
+	if len(a.ParameterIDs) == 0 {
+		return true
+	}
+
+	for _, id := range a.ParameterIDs {
+		if id == parameterID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Period returns the days the annotation covers.
+func (a *Annotation) Period() series.Period {
+	return series.Period{Start: a.StartDate, End: a.EndDate}
+}
+
+// Periods returns the days covered by the annotations, for excluding them
+// from series and analyses.
+func Periods(annotations []*Annotation) []series.Period {
+	periods := make([]series.Period, 0, len(annotations))
+	for _, a := range annotations {
+		periods = append(periods, a.Period())
+	}
+
+	return periods
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,19 @@
+package annotation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAnnotationNotFound = errors.New("annotation not found")
+)
+
+type Repository interface {
+	CreateAnnotation(ctx context.Context, annotation *Annotation) (*Annotation, error)
+	GetAnnotationByID(ctx context.Context, id uuid.UUID) (*Annotation, error)
+	ListAnnotationsByUser(ctx context.Context, userID uuid.UUID) ([]*Annotation, error)
+	DeleteAnnotation(ctx context.Context, id uuid.UUID) error
+}
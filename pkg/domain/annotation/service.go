@@ -0,0 +1,38 @@
+package annotation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateAnnotation(ctx context.Context, input CreateAnnotationInput) (*Annotation, error)
+	GetAnnotationByID(ctx context.Context, id uuid.UUID) (*Annotation, error)
+	ListAnnotationsByUser(ctx context.Context, userID uuid.UUID) ([]*Annotation, error)
+	ListOverlappingAnnotations(ctx context.Context, input ListOverlappingAnnotationsInput) ([]*Annotation, error)
+	DeleteAnnotation(ctx context.Context, id uuid.UUID) error
+}
+
+type CreateAnnotationInput struct {
+	UserID uuid.UUID
+	// ParameterIDs must belong to the user. Empty means all parameters.
+	ParameterIDs []uuid.UUID
+	Title        string
+	Description  string
+	Category     string
+	StartDate    time.Time
+	// EndDate defaults to StartDate for a single-day event.
+	EndDate *time.Time
+}
+
+type ListOverlappingAnnotationsInput struct {
+	// ParameterIDs selects the annotations that apply to any of these
+	// parameters, including those that apply to all of a user's parameters.
+	ParameterIDs []uuid.UUID
+	// From and To bound the calendar dates the annotations must overlap.
+	// Nil leaves that side open.
+	From *time.Time
+	To   *time.Time
+}
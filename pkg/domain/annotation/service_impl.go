@@ -0,0 +1,162 @@
+package annotation
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidDateRange  = errors.New("end date must not be before the start date")
+	ErrParameterNotOwned = errors.New("annotated parameters must belong to the user")
+)
+
+type ServiceImpl struct {
+	repo             Repository
+	userService      user.Service
+	parameterService parameter.Service
+}
+
+func NewService(repo Repository, userService user.Service, parameterService parameter.Service) Service {
+	return &ServiceImpl{
+		repo:             repo,
+		userService:      userService,
+		parameterService: parameterService,
+	}
+}
+
+func (s *ServiceImpl) CreateAnnotation(ctx context.Context, input CreateAnnotationInput) (*Annotation, error) {
+	if _, err := s.userService.GetUserByID(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
+	for _, parameterID := range input.ParameterIDs {
+		annotatedParameter, err := s.parameterService.GetParameterByID(ctx, parameterID)
+		if err != nil {
+			return nil, err
+		}
+
+		if annotatedParameter.UserID != input.UserID {
+			return nil, ErrParameterNotOwned
+		}
+	}
+
+	startDate := dateOf(input.StartDate)
+	endDate := startDate
+	if input.EndDate != nil {
+		endDate = dateOf(*input.EndDate)
+	}
+
+	if endDate.Before(startDate) {
+		return nil, ErrInvalidDateRange
+	}
+
+	annotation := &Annotation{
+		ID:           uuid.New(),
+		UserID:       input.UserID,
+		ParameterIDs: input.ParameterIDs,
+		Title:        input.Title,
+		Description:  input.Description,
+		Category:     input.Category,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		CreatedAt:    time.Now().UTC(),
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	return s.repo.CreateAnnotation(ctx, annotation)
+}
+
+func (s *ServiceImpl) GetAnnotationByID(ctx context.Context, id uuid.UUID) (*Annotation, error) {
+	return s.repo.GetAnnotationByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListAnnotationsByUser(ctx context.Context, userID uuid.UUID) ([]*Annotation, error) {
+	annotations, err := s.repo.ListAnnotationsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sortByStart(annotations)
+
+	return annotations, nil
+}
+
+func (s *ServiceImpl) ListOverlappingAnnotations(
+	ctx context.Context,
+	input ListOverlappingAnnotationsInput,
+) ([]*Annotation, error) {
+	userIDs := []uuid.UUID{}
+	seen := make(map[uuid.UUID]bool)
+	for _, parameterID := range input.ParameterIDs {
+		annotatedParameter, err := s.parameterService.GetParameterByID(ctx, parameterID)
+		if err != nil {
+			return nil, err
+		}
+
+		if !seen[annotatedParameter.UserID] {
+			seen[annotatedParameter.UserID] = true
+			userIDs = append(userIDs, annotatedParameter.UserID)
+		}
+	}
+
+	annotations := []*Annotation{}
+	for _, userID := range userIDs {
+		userAnnotations, err := s.repo.ListAnnotationsByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, annotation := range userAnnotations {
+			if appliesToAny(annotation, input.ParameterIDs) && overlaps(annotation, input.From, input.To) {
+				annotations = append(annotations, annotation)
+			}
+		}
+	}
+
+	sortByStart(annotations)
+
+	return annotations, nil
+}
+
+func (s *ServiceImpl) DeleteAnnotation(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteAnnotation(ctx, id)
+}
+
+func appliesToAny(annotation *Annotation, parameterIDs []uuid.UUID) bool {
+	for _, parameterID := range parameterIDs {
+		if annotation.AppliesTo(parameterID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overlaps compares calendar dates, so a bound anywhere within a day
+// includes the annotations covering that day.
+func overlaps(annotation *Annotation, from, to *time.Time) bool {
+	if from != nil && annotation.EndDate.Before(dateOf(*from)) {
+		return false
+	}
+
+	if to != nil && annotation.StartDate.After(dateOf(*to)) {
+		return false
+	}
+
+	return true
+}
+
+func sortByStart(annotations []*Annotation) {
+	sort.SliceStable(annotations, func(i, j int) bool {
+		if annotations[i].StartDate.Equal(annotations[j].StartDate) {
+			return annotations[i].CreatedAt.Before(annotations[j].CreatedAt)
+		}
+		return annotations[i].StartDate.Before(annotations[j].StartDate)
+	})
+}
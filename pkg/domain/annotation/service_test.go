@@ -0,0 +1,190 @@
+package annotation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/annotation"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(day int) time.Time {
+	return time.Date(2025, time.March, day, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCreateAnnotation(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	annotationService := annotation.NewService(annotation.NewInMemoryRepository(), userService, parameterService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	sleep := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	t.Run("single day", func(t *testing.T) {
+		created, err := annotationService.CreateAnnotation(context.Background(), annotation.CreateAnnotationInput{
+			UserID:    owner.ID,
+			Title:     "Got sick",
+			Category:  "illness",
+			StartDate: time.Date(2025, time.March, 3, 18, 30, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, date(3), created.StartDate)
+		assert.Equal(t, date(3), created.EndDate)
+		assert.Empty(t, created.ParameterIDs)
+	})
+
+	t.Run("date range for a parameter", func(t *testing.T) {
+		created, err := annotationService.CreateAnnotation(context.Background(), annotation.CreateAnnotationInput{
+			UserID:       owner.ID,
+			ParameterIDs: []uuid.UUID{sleep.ID},
+			Title:        "Vacation",
+			StartDate:    date(10),
+			EndDate:      ptr(date(17)),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, date(17), created.EndDate)
+		assert.True(t, created.AppliesTo(sleep.ID))
+		assert.False(t, created.AppliesTo(uuid.New()))
+	})
+
+	t.Run("end before start", func(t *testing.T) {
+		_, err := annotationService.CreateAnnotation(context.Background(), annotation.CreateAnnotationInput{
+			UserID:    owner.ID,
+			Title:     "Vacation",
+			StartDate: date(10),
+			EndDate:   ptr(date(9)),
+		})
+		assert.ErrorIs(t, err, annotation.ErrInvalidDateRange)
+	})
+
+	t.Run("parameter of another user", func(t *testing.T) {
+		other := domaintest.CreateUser(t, userService, user.CreateUserInput{
+			ExternalID: "0d6c3f6e-8f2d-4c51-9d0e-3c1b7b7a5e11",
+			FirstName:  "John",
+			LastName:   "Doe",
+		})
+		otherParam := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+			UserID:   other.ID,
+			Name:     "Steps",
+			DataType: parameter.DataTypeFloat,
+		})
+
+		_, err := annotationService.CreateAnnotation(context.Background(), annotation.CreateAnnotationInput{
+			UserID:       owner.ID,
+			ParameterIDs: []uuid.UUID{otherParam.ID},
+			Title:        "Vacation",
+			StartDate:    date(10),
+		})
+		assert.ErrorIs(t, err, annotation.ErrParameterNotOwned)
+	})
+}
+
+func TestListOverlappingAnnotations(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	annotationService := annotation.NewService(annotation.NewInMemoryRepository(), userService, parameterService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	sleep := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	create := func(title string, parameterIDs []uuid.UUID, start, end int) {
+		_, err := annotationService.CreateAnnotation(context.Background(), annotation.CreateAnnotationInput{
+			UserID:       owner.ID,
+			ParameterIDs: parameterIDs,
+			Title:        title,
+			StartDate:    date(start),
+			EndDate:      ptr(date(end)),
+		})
+		require.NoError(t, err)
+	}
+
+	create("Vacation", nil, 10, 17)
+	create("New pillow", []uuid.UUID{sleep.ID}, 5, 5)
+	create("Bad news", []uuid.UUID{mood.ID}, 12, 12)
+	create("Moved", nil, 25, 26)
+
+	titles := func(annotations []*annotation.Annotation) []string {
+		result := []string{}
+		for _, a := range annotations {
+			result = append(result, a.Title)
+		}
+		return result
+	}
+
+	t.Run("all dates", func(t *testing.T) {
+		annotations, err := annotationService.ListOverlappingAnnotations(
+			context.Background(),
+			annotation.ListOverlappingAnnotationsInput{ParameterIDs: []uuid.UUID{sleep.ID}},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"New pillow", "Vacation", "Moved"}, titles(annotations))
+	})
+
+	t.Run("bounded by date", func(t *testing.T) {
+		annotations, err := annotationService.ListOverlappingAnnotations(
+			context.Background(),
+			annotation.ListOverlappingAnnotationsInput{
+				ParameterIDs: []uuid.UUID{mood.ID},
+				From:         ptr(time.Date(2025, time.March, 17, 23, 0, 0, 0, time.UTC)),
+				To:           ptr(date(24)),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"Vacation"}, titles(annotations))
+	})
+
+	t.Run("several parameters", func(t *testing.T) {
+		annotations, err := annotationService.ListOverlappingAnnotations(
+			context.Background(),
+			annotation.ListOverlappingAnnotationsInput{
+				ParameterIDs: []uuid.UUID{sleep.ID, mood.ID},
+				From:         ptr(date(1)),
+				To:           ptr(date(12)),
+			},
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"New pillow", "Vacation", "Bad news"}, titles(annotations))
+	})
+
+	t.Run("unknown parameter", func(t *testing.T) {
+		_, err := annotationService.ListOverlappingAnnotations(
+			context.Background(),
+			annotation.ListOverlappingAnnotationsInput{ParameterIDs: []uuid.UUID{uuid.New()}},
+		)
+		assert.ErrorIs(t, err, parameter.ErrParameterNotFound)
+	})
+}
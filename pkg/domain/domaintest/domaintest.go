@@ -0,0 +1,84 @@
+// Package domaintest holds the fixtures the domain service tests share.
+package domaintest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateUser creates a user and fails the test if that does not work.
+func CreateUser(t *testing.T, userService user.Service, input user.CreateUserInput) *user.User {
+	t.Helper()
+
+	createdUser, err := userService.CreateUser(context.Background(), input)
+	require.NoError(t, err)
+
+	return createdUser
+}
+
+// CreateParameter creates a parameter and fails the test if that does not
+// work.
+func CreateParameter(
+	t *testing.T,
+	parameterService parameter.Service,
+	input parameter.CreateParameterInput,
+) *parameter.Parameter {
+	t.Helper()
+
+	createdParam, err := parameterService.CreateParameter(context.Background(), input)
+	require.NoError(t, err)
+
+	return createdParam
+}
+
+// LogValue records a number of the parameter and fails the test if that does
+// not work.
+func LogValue(
+	t *testing.T,
+	measurementService measurement.Service,
+	parameterID uuid.UUID,
+	timestamp time.Time,
+	value float64,
+) {
+	t.Helper()
+
+	logMeasurement(t, measurementService, parameterID, timestamp, value)
+}
+
+// LogBoolean records a yes or no of the parameter and fails the test if that
+// does not work.
+func LogBoolean(
+	t *testing.T,
+	measurementService measurement.Service,
+	parameterID uuid.UUID,
+	timestamp time.Time,
+	value bool,
+) {
+	t.Helper()
+
+	logMeasurement(t, measurementService, parameterID, timestamp, value)
+}
+
+func logMeasurement(
+	t *testing.T,
+	measurementService measurement.Service,
+	parameterID uuid.UUID,
+	timestamp time.Time,
+	value interface{},
+) {
+	t.Helper()
+
+	_, err := measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: parameterID,
+		Value:       value,
+		Timestamp:   timestamp,
+	})
+	require.NoError(t, err)
+}
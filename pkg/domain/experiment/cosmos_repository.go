@@ -0,0 +1,182 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName  = "correlateapp"
+	containerName = "Experiments"
+	partitionKey  = "/userId"
+)
+
+type CosmosExperimentRepository struct {
+	client    *azcosmos.Client
+	container *azcosmos.ContainerClient
+}
+
+func NewCosmosExperimentRepository(connectionString string) (*CosmosExperimentRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for experiment repository: %w", err)
+	}
+
+	container, err := client.NewContainer(databaseName, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for experiment repository: %w", err)
+	}
+
+	return &CosmosExperimentRepository{
+		client:    client,
+		container: container,
+	}, nil
+}
+
+func (r *CosmosExperimentRepository) CreateExperiment(ctx context.Context, experiment *Experiment) (*Experiment, error) {
+	experimentJSON, err := json.Marshal(NewCosmosExperiment(experiment))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experiment: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(experiment.UserID.String())
+
+	_, err = r.container.CreateItem(ctx, pk, experimentJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create experiment in Cosmos DB: %w", err)
+	}
+
+	return experiment, nil
+}
+
+func (r *CosmosExperimentRepository) GetExperimentByID(ctx context.Context, id uuid.UUID) (*Experiment, error) {
+	query := "SELECT * FROM experiments e WHERE e.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	experiments, err := r.queryExperiments(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(experiments) == 0 {
+		return nil, ErrExperimentNotFound
+	}
+
+	return experiments[0], nil
+}
+
+func (r *CosmosExperimentRepository) ListExperimentsByUser(ctx context.Context, userID uuid.UUID) ([]*Experiment, error) {
+	query := "SELECT * FROM experiments e WHERE e.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryExperiments(ctx, query, params)
+}
+
+func (r *CosmosExperimentRepository) DeleteExperiment(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the experiment to get its UserID (required for partition key)
+	experiment, err := r.GetExperimentByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(experiment.UserID.String())
+
+	_, err = r.container.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete experiment from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosExperimentRepository) queryExperiments(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Experiment, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	experiments := []*Experiment{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosExperiment CosmosExperiment
+			if err := json.Unmarshal(item, &cosmosExperiment); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal experiment: %w", err)
+			}
+			experiments = append(experiments, NewExperiment(&cosmosExperiment))
+		}
+	}
+
+	return experiments, nil
+}
+
+type CosmosPhase struct {
+	Kind  PhaseKind `json:"kind"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type CosmosExperiment struct {
+	ID                 uuid.UUID     `json:"id"`
+	UserID             uuid.UUID     `json:"userId"`
+	OutcomeParameterID uuid.UUID     `json:"outcomeParameterId"`
+	Name               string        `json:"name"`
+	Intervention       string        `json:"intervention"`
+	Phases             []CosmosPhase `json:"phases"`
+	WashoutDays        int           `json:"washoutDays"`
+	CreatedAt          time.Time     `json:"createdAt"`
+	UpdatedAt          time.Time     `json:"updatedAt"`
+}
+
+func NewCosmosExperiment(experiment *Experiment) *CosmosExperiment {
+	phases := make([]CosmosPhase, 0, len(experiment.Phases))
+	for _, phase := range experiment.Phases {
+		phases = append(phases, CosmosPhase(phase))
+	}
+
+	return &CosmosExperiment{
+		ID:                 experiment.ID,
+		UserID:             experiment.UserID,
+		OutcomeParameterID: experiment.OutcomeParameterID,
+		Name:               experiment.Name,
+		Intervention:       experiment.Intervention,
+		Phases:             phases,
+		WashoutDays:        experiment.WashoutDays,
+		CreatedAt:          experiment.CreatedAt,
+		UpdatedAt:          experiment.UpdatedAt,
+	}
+}
+
+func NewExperiment(cosmosExperiment *CosmosExperiment) *Experiment {
+	phases := make([]Phase, 0, len(cosmosExperiment.Phases))
+	for _, phase := range cosmosExperiment.Phases {
+		phases = append(phases, Phase(phase))
+	}
+
+	return &Experiment{
+		ID:                 cosmosExperiment.ID,
+		UserID:             cosmosExperiment.UserID,
+		OutcomeParameterID: cosmosExperiment.OutcomeParameterID,
+		Name:               cosmosExperiment.Name,
+		Intervention:       cosmosExperiment.Intervention,
+		Phases:             phases,
+		WashoutDays:        cosmosExperiment.WashoutDays,
+		CreatedAt:          cosmosExperiment.CreatedAt,
+		UpdatedAt:          cosmosExperiment.UpdatedAt,
+	}
+}
@@ -0,0 +1,67 @@
+package experiment
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu          sync.RWMutex
+	experiments map[uuid.UUID]*Experiment
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		experiments: make(map[uuid.UUID]*Experiment),
+	}
+}
+
+func (r *InMemoryRepository) CreateExperiment(_ context.Context, experiment *Experiment) (*Experiment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.experiments[experiment.ID] = experiment
+
+	return experiment, nil
+}
+
+func (r *InMemoryRepository) GetExperimentByID(_ context.Context, id uuid.UUID) (*Experiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	experiment, ok := r.experiments[id]
+	if !ok {
+		return nil, ErrExperimentNotFound
+	}
+
+	return experiment, nil
+}
+
+func (r *InMemoryRepository) ListExperimentsByUser(_ context.Context, userID uuid.UUID) ([]*Experiment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var experiments []*Experiment
+	for _, experiment := range r.experiments {
+		if experiment.UserID == userID {
+			experiments = append(experiments, experiment)
+		}
+	}
+
+	return experiments, nil
+}
+
+func (r *InMemoryRepository) DeleteExperiment(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.experiments[id]; !ok {
+		return ErrExperimentNotFound
+	}
+
+	delete(r.experiments, id)
+
+	return nil
+}
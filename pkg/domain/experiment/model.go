@@ -0,0 +1,108 @@
+package experiment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PhaseKind string
+
+const (
+	PhaseBaseline     PhaseKind = "baseline"
+	PhaseIntervention PhaseKind = "intervention"
+)
+
+// Phase covers the calendar days from Start to End inclusive. Both are
+// midnight UTC and stand for dates rather than instants.
+type Phase struct {
+	Kind  PhaseKind
+	Start time.Time
+	End   time.Time
+}
+
+type Experiment struct {
+	ID                 uuid.UUID
+	UserID             uuid.UUID
+	OutcomeParameterID uuid.UUID
+	Name               string
+	Intervention       string
+	Phases             []Phase
+	// WashoutDays at the start of every phase are left out of the analysis,
+	// so that carry-over from the previous phase does not blur the contrast.
+	WashoutDays int
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DayPhase is the phase a calendar day belongs to.
+type DayPhase struct {
+	Date       time.Time
+	Kind       PhaseKind
+	PhaseIndex int
+	Washout    bool
+}
+
+// PhaseOn returns the phase the given day belongs to. Only the day's calendar
+// date in its own location is considered.
+func (e *Experiment) PhaseOn(day time.Time) (DayPhase, bool) {
+	date := dateOf(day)
+	for i, phase := range e.Phases {
+		if date.Before(phase.Start) || date.After(phase.End) {
+			continue
+		}
+
+		return DayPhase{
+			Date:       date,
+			Kind:       phase.Kind,
+			PhaseIndex: i,
+			Washout:    date.Before(phase.Start.AddDate(0, 0, e.WashoutDays)),
+		}, true
+	}
+
+	return DayPhase{}, false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type PhaseSummary struct {
+	Phase
+	Days int
+	Mean float64
+}
+
+// RandomizationTest compares the observed difference in means with the
+// differences obtained by reassigning the condition labels. At the day level
+// labels are shuffled between days, which assumes days are exchangeable. At
+// the phase level whole phases are relabelled, which respects autocorrelation
+// within phases but can only reach p-values as small as one over the number of
+// distinct assignments.
+type RandomizationTest struct {
+	Permutations int
+	Exact        bool
+	PValue       float64
+}
+
+type Result struct {
+	ExperimentID     uuid.UUID
+	Phases           []PhaseSummary
+	BaselineDays     int
+	InterventionDays int
+	BaselineMean     float64
+	InterventionMean float64
+	// Difference is the intervention mean minus the baseline mean.
+	Difference float64
+	// EffectSize is Hedges' g, the standardised mean difference corrected
+	// for small samples.
+	EffectSize float64
+	DayTest    RandomizationTest
+	PhaseTest  RandomizationTest
+	Days       []ObservedDay
+}
+
+type ObservedDay struct {
+	DayPhase
+	Value float64
+}
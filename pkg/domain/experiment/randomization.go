@@ -0,0 +1,76 @@
+package experiment
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+// meanDifference returns the mean of the intervention days minus the mean of
+// the baseline days.
+func meanDifference(values []float64, intervention []bool) float64 {
+	var sumA, sumB float64
+	var countA, countB int
+	for i, value := range values {
+		if intervention[i] {
+			sumB += value
+			countB++
+		} else {
+			sumA += value
+			countA++
+		}
+	}
+
+	if countA == 0 || countB == 0 {
+		return 0
+	}
+
+	return sumB/float64(countB) - sumA/float64(countA)
+}
+
+// phaseRandomizationTest enumerates every way of labelling the phases with
+// the same number of intervention phases and compares the resulting
+// differences with the observed one.
+func phaseRandomizationTest(values []float64, phaseOf []int, phases []Phase) RandomizationTest {
+	interventionPhases := 0
+	for _, phase := range phases {
+		if phase.Kind == PhaseIntervention {
+			interventionPhases++
+		}
+	}
+
+	assign := func(mask uint) []bool {
+		intervention := make([]bool, len(values))
+		for i, phase := range phaseOf {
+			intervention[i] = mask&(1<<uint(phase)) != 0
+		}
+		return intervention
+	}
+
+	var actual uint
+	for i, phase := range phases {
+		if phase.Kind == PhaseIntervention {
+			actual |= 1 << uint(i)
+		}
+	}
+	observed := math.Abs(meanDifference(values, assign(actual)))
+
+	total, extreme := 0, 0
+	for mask := uint(0); mask < 1<<uint(len(phases)); mask++ {
+		if bits.OnesCount(mask) != interventionPhases {
+			continue
+		}
+
+		total++
+		if math.Abs(meanDifference(values, assign(mask))) >= observed-stats.PermutationTolerance {
+			extreme++
+		}
+	}
+
+	return RandomizationTest{
+		Permutations: total,
+		Exact:        true,
+		PValue:       float64(extreme) / float64(total),
+	}
+}
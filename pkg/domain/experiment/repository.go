@@ -0,0 +1,19 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrExperimentNotFound = errors.New("experiment not found")
+)
+
+type Repository interface {
+	CreateExperiment(ctx context.Context, experiment *Experiment) (*Experiment, error)
+	GetExperimentByID(ctx context.Context, id uuid.UUID) (*Experiment, error)
+	ListExperimentsByUser(ctx context.Context, userID uuid.UUID) ([]*Experiment, error)
+	DeleteExperiment(ctx context.Context, id uuid.UUID) error
+}
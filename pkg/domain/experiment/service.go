@@ -0,0 +1,48 @@
+package experiment
+
+import (
+	"context"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateExperiment(ctx context.Context, input CreateExperimentInput) (*Experiment, error)
+	GetExperimentByID(ctx context.Context, id uuid.UUID) (*Experiment, error)
+	ListExperimentsByUser(ctx context.Context, userID uuid.UUID) ([]*Experiment, error)
+	DeleteExperiment(ctx context.Context, id uuid.UUID) error
+	GetPhase(ctx context.Context, input GetPhaseInput) (*DayPhase, error)
+	AnalyzeExperiment(ctx context.Context, input AnalyzeExperimentInput) (*Result, error)
+}
+
+type CreateExperimentInput struct {
+	UserID             uuid.UUID
+	OutcomeParameterID uuid.UUID
+	Name               string
+	Intervention       string
+	// Design lists the phases in order, A for baseline and B for
+	// intervention, e.g. "AB" or "ABAB".
+	Design      string
+	StartDate   time.Time
+	PhaseDays   int
+	WashoutDays int
+}
+
+type GetPhaseInput struct {
+	ExperimentID uuid.UUID
+	Date         time.Time
+}
+
+type AnalyzeExperimentInput struct {
+	ExperimentID    uuid.UUID
+	Aggregation     series.Aggregation
+	Location        *time.Location
+	ExcludeOutliers bool
+	// Permutations is the number of day-level shuffles. Zero means
+	// DefaultPermutations.
+	Permutations int
+	// Seed makes the day-level test reproducible.
+	Seed uint64
+}
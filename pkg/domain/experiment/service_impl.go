@@ -0,0 +1,230 @@
+package experiment
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPermutations = 10000
+	MaxPermutations     = 100000
+	MaxPhases           = 12
+	MaxPhaseDays        = 90
+	minDaysPerCondition = 2
+)
+
+var (
+	ErrInvalidDesign       = errors.New("design must be a sequence of A and B phases containing both")
+	ErrInvalidPhaseDays    = errors.New("phase length must be between 1 and 90 days")
+	ErrInvalidWashout      = errors.New("washout must be shorter than a phase")
+	ErrInvalidPermutations = errors.New("number of permutations is out of range")
+	ErrInvalidOutcome      = errors.New("outcome must be a parameter of the same user")
+	ErrOutsideExperiment   = errors.New("date is outside the experiment")
+	ErrNotEnoughData       = errors.New("not enough observed days in each condition")
+)
+
+type ServiceImpl struct {
+	repo             Repository
+	parameterService parameter.Service
+	seriesService    series.Service
+}
+
+func NewService(
+	repo Repository,
+	parameterService parameter.Service,
+	seriesService series.Service,
+) Service {
+	return &ServiceImpl{
+		repo:             repo,
+		parameterService: parameterService,
+		seriesService:    seriesService,
+	}
+}
+
+func (s *ServiceImpl) CreateExperiment(ctx context.Context, input CreateExperimentInput) (*Experiment, error) {
+	phases, err := plan(input.Design, input.StartDate, input.PhaseDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.WashoutDays < 0 || input.WashoutDays >= input.PhaseDays {
+		return nil, ErrInvalidWashout
+	}
+
+	outcome, err := s.parameterService.GetParameterByID(ctx, input.OutcomeParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if outcome.UserID != input.UserID {
+		return nil, ErrInvalidOutcome
+	}
+
+	experiment := &Experiment{
+		ID:                 uuid.New(),
+		UserID:             input.UserID,
+		OutcomeParameterID: outcome.ID,
+		Name:               input.Name,
+		Intervention:       input.Intervention,
+		Phases:             phases,
+		WashoutDays:        input.WashoutDays,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+
+	return s.repo.CreateExperiment(ctx, experiment)
+}
+
+func (s *ServiceImpl) GetExperimentByID(ctx context.Context, id uuid.UUID) (*Experiment, error) {
+	return s.repo.GetExperimentByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListExperimentsByUser(ctx context.Context, userID uuid.UUID) ([]*Experiment, error) {
+	return s.repo.ListExperimentsByUser(ctx, userID)
+}
+
+func (s *ServiceImpl) DeleteExperiment(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteExperiment(ctx, id)
+}
+
+func (s *ServiceImpl) GetPhase(ctx context.Context, input GetPhaseInput) (*DayPhase, error) {
+	experiment, err := s.repo.GetExperimentByID(ctx, input.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	date := input.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	dayPhase, ok := experiment.PhaseOn(date)
+	if !ok {
+		return nil, ErrOutsideExperiment
+	}
+
+	return &dayPhase, nil
+}
+
+func (s *ServiceImpl) AnalyzeExperiment(ctx context.Context, input AnalyzeExperimentInput) (*Result, error) {
+	permutations := input.Permutations
+	if permutations == 0 {
+		permutations = DefaultPermutations
+	}
+	if permutations < 0 || permutations > MaxPermutations {
+		return nil, ErrInvalidPermutations
+	}
+
+	experiment, err := s.repo.GetExperimentByID(ctx, input.ExperimentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only observed days take part; imputed values would fake evidence.
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, series.GetDailySeriesInput{
+		ParameterID:     experiment.OutcomeParameterID,
+		Aggregation:     input.Aggregation,
+		Gaps:            series.GapOptions{Strategy: series.GapStrategyDrop},
+		Location:        input.Location,
+		ExcludeOutliers: input.ExcludeOutliers,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		ExperimentID: experiment.ID,
+		Days:         []ObservedDay{},
+	}
+
+	var values, baseline, intervention []float64
+	var phaseOf []int
+	byPhase := make([][]float64, len(experiment.Phases))
+	for _, p := range dailySeries.Points {
+		dayPhase, ok := experiment.PhaseOn(p.Date)
+		if !ok {
+			continue
+		}
+
+		result.Days = append(result.Days, ObservedDay{DayPhase: dayPhase, Value: p.Value})
+		if dayPhase.Washout {
+			continue
+		}
+
+		values = append(values, p.Value)
+		phaseOf = append(phaseOf, dayPhase.PhaseIndex)
+		byPhase[dayPhase.PhaseIndex] = append(byPhase[dayPhase.PhaseIndex], p.Value)
+		if dayPhase.Kind == PhaseIntervention {
+			intervention = append(intervention, p.Value)
+		} else {
+			baseline = append(baseline, p.Value)
+		}
+	}
+
+	if len(baseline) < minDaysPerCondition || len(intervention) < minDaysPerCondition {
+		return nil, ErrNotEnoughData
+	}
+
+	for i, phase := range experiment.Phases {
+		summary := PhaseSummary{Phase: phase, Days: len(byPhase[i])}
+		if len(byPhase[i]) > 0 {
+			summary.Mean = stats.Mean(byPhase[i])
+		}
+		result.Phases = append(result.Phases, summary)
+	}
+
+	rng := rand.New(rand.NewPCG(input.Seed, input.Seed))
+
+	result.BaselineDays = len(baseline)
+	result.InterventionDays = len(intervention)
+	result.BaselineMean = stats.Mean(baseline)
+	result.InterventionMean = stats.Mean(intervention)
+	result.Difference = result.InterventionMean - result.BaselineMean
+	result.EffectSize = stats.HedgesG(baseline, intervention)
+	result.DayTest = RandomizationTest{
+		Permutations: permutations,
+		PValue:       stats.PermutationTest(baseline, intervention, permutations, rng),
+	}
+	result.PhaseTest = phaseRandomizationTest(values, phaseOf, experiment.Phases)
+
+	return result, nil
+}
+
+// plan lays out consecutive phases of equal length from the design.
+func plan(design string, start time.Time, phaseDays int) ([]Phase, error) {
+	design = strings.ToUpper(strings.TrimSpace(design))
+	if len(design) < 2 || len(design) > MaxPhases ||
+		strings.Trim(design, "AB") != "" || !strings.Contains(design, "A") || !strings.Contains(design, "B") {
+		return nil, ErrInvalidDesign
+	}
+
+	if phaseDays < 1 || phaseDays > MaxPhaseDays {
+		return nil, ErrInvalidPhaseDays
+	}
+
+	phases := make([]Phase, 0, len(design))
+	day := dateOf(start)
+	for _, letter := range design {
+		kind := PhaseBaseline
+		if letter == 'B' {
+			kind = PhaseIntervention
+		}
+
+		phases = append(phases, Phase{
+			Kind:  kind,
+			Start: day,
+			End:   day.AddDate(0, 0, phaseDays-1),
+		})
+		day = day.AddDate(0, 0, phaseDays)
+	}
+
+	return phases, nil
+}
@@ -0,0 +1,194 @@
+package experiment_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/experiment"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+
+func createExperiment(
+	t *testing.T,
+	experimentService experiment.Service,
+	outcome *parameter.Parameter,
+	design string,
+) *experiment.Experiment {
+	t.Helper()
+
+	created, err := experimentService.CreateExperiment(context.Background(), experiment.CreateExperimentInput{
+		UserID:             outcome.UserID,
+		OutcomeParameterID: outcome.ID,
+		Name:               "Caffeine cut-off",
+		Intervention:       "No caffeine after 2pm",
+		Design:             design,
+		StartDate:          start.Add(15 * time.Hour),
+		PhaseDays:          7,
+		WashoutDays:        1,
+	})
+	require.NoError(t, err)
+
+	return created
+}
+
+func TestCreateExperiment_Phases(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	experimentService := experiment.NewService(experiment.NewInMemoryRepository(), parameterService, seriesService)
+	outcome := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep quality",
+		DataType: parameter.DataTypeFloat,
+	})
+	created := createExperiment(t, experimentService, outcome, "abab")
+
+	require.Len(t, created.Phases, 4)
+	assert.Equal(t, experiment.PhaseBaseline, created.Phases[0].Kind)
+	assert.Equal(t, start, created.Phases[0].Start)
+	assert.Equal(t, start.AddDate(0, 0, 6), created.Phases[0].End)
+	assert.Equal(t, experiment.PhaseIntervention, created.Phases[3].Kind)
+	assert.Equal(t, start.AddDate(0, 0, 27), created.Phases[3].End)
+
+	dayPhase, err := experimentService.GetPhase(context.Background(), experiment.GetPhaseInput{
+		ExperimentID: created.ID,
+		Date:         start.AddDate(0, 0, 7).Add(20 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, experiment.PhaseIntervention, dayPhase.Kind)
+	assert.Equal(t, 1, dayPhase.PhaseIndex)
+	assert.True(t, dayPhase.Washout)
+
+	_, err = experimentService.GetPhase(context.Background(), experiment.GetPhaseInput{
+		ExperimentID: created.ID,
+		Date:         start.AddDate(0, 0, 28),
+	})
+	require.ErrorIs(t, err, experiment.ErrOutsideExperiment)
+}
+
+func TestCreateExperiment_InvalidInput(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	experimentService := experiment.NewService(experiment.NewInMemoryRepository(), parameterService, seriesService)
+	outcome := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep quality",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	tests := []struct {
+		name        string
+		design      string
+		phaseDays   int
+		washoutDays int
+		userID      uuid.UUID
+		expected    error
+	}{
+		{"Single condition", "AA", 7, 0, outcome.UserID, experiment.ErrInvalidDesign},
+		{"Unknown phase", "ABC", 7, 0, outcome.UserID, experiment.ErrInvalidDesign},
+		{"Empty phases", "AB", 0, 0, outcome.UserID, experiment.ErrInvalidPhaseDays},
+		{"Washout too long", "AB", 7, 7, outcome.UserID, experiment.ErrInvalidWashout},
+		{"Someone else's outcome", "AB", 7, 0, uuid.New(), experiment.ErrInvalidOutcome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := experimentService.CreateExperiment(context.Background(), experiment.CreateExperimentInput{
+				UserID:             tt.userID,
+				OutcomeParameterID: outcome.ID,
+				Design:             tt.design,
+				StartDate:          start,
+				PhaseDays:          tt.phaseDays,
+				WashoutDays:        tt.washoutDays,
+			})
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestAnalyzeExperiment(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	experimentService := experiment.NewService(experiment.NewInMemoryRepository(), parameterService, seriesService)
+	outcome := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep quality",
+		DataType: parameter.DataTypeFloat,
+	})
+	created := createExperiment(t, experimentService, outcome, "ABAB")
+
+	noise := []float64{0.1, -0.2, 0.3, 0, -0.1, 0.2, -0.3}
+	for day := range 28 {
+		level := 5.0
+		if (day/7)%2 == 1 {
+			level = 7.0
+		}
+		domaintest.LogValue(t, measurementService, outcome.ID, start.AddDate(0, 0, day).Add(8*time.Hour), level+noise[day%7])
+	}
+	// Outside the experiment, ignored.
+	domaintest.LogValue(t, measurementService, outcome.ID, start.AddDate(0, 0, -1), 100)
+
+	result, err := experimentService.AnalyzeExperiment(context.Background(), experiment.AnalyzeExperimentInput{
+		ExperimentID: created.ID,
+		Permutations: 2000,
+		Seed:         42,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Days, 28)
+	assert.True(t, result.Days[0].Washout)
+	assert.Equal(t, 12, result.BaselineDays)
+	assert.Equal(t, 12, result.InterventionDays)
+	assert.InDelta(t, 2.0, result.Difference, 1e-9)
+	assert.Greater(t, result.EffectSize, 5.0)
+	require.Len(t, result.Phases, 4)
+	assert.Equal(t, 6, result.Phases[1].Days)
+	assert.InDelta(t, 7.0+(-0.2+0.3-0.1+0.2-0.3)/6, result.Phases[1].Mean, 1e-9)
+
+	assert.Equal(t, 2000, result.DayTest.Permutations)
+	assert.InDelta(t, 1.0/2001.0, result.DayTest.PValue, 1e-12)
+
+	// Of the six ways to label two of four phases, ABAB and its mirror BABA
+	// are equally extreme.
+	assert.True(t, result.PhaseTest.Exact)
+	assert.Equal(t, 6, result.PhaseTest.Permutations)
+	assert.InDelta(t, 2.0/6.0, result.PhaseTest.PValue, 1e-12)
+}
+
+func TestAnalyzeExperiment_NotEnoughData(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	experimentService := experiment.NewService(experiment.NewInMemoryRepository(), parameterService, seriesService)
+	outcome := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep quality",
+		DataType: parameter.DataTypeFloat,
+	})
+	created := createExperiment(t, experimentService, outcome, "AB")
+
+	for day := range 7 {
+		domaintest.LogValue(t, measurementService, outcome.ID, start.AddDate(0, 0, day), 5)
+	}
+
+	_, err := experimentService.AnalyzeExperiment(context.Background(), experiment.AnalyzeExperimentInput{
+		ExperimentID: created.ID,
+	})
+	require.ErrorIs(t, err, experiment.ErrNotEnoughData)
+}
@@ -0,0 +1,188 @@
+package goal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName  = "correlateapp"
+	containerName = "Goals"
+	partitionKey  = "/userId"
+)
+
+type CosmosGoalRepository struct {
+	client    *azcosmos.Client
+	container *azcosmos.ContainerClient
+}
+
+func NewCosmosGoalRepository(connectionString string) (*CosmosGoalRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for goal repository: %w", err)
+	}
+
+	container, err := client.NewContainer(databaseName, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for goal repository: %w", err)
+	}
+
+	return &CosmosGoalRepository{
+		client:    client,
+		container: container,
+	}, nil
+}
+
+func (r *CosmosGoalRepository) CreateGoal(ctx context.Context, goal *Goal) (*Goal, error) {
+	goalJSON, err := json.Marshal(NewCosmosGoal(goal))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal goal: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(goal.UserID.String())
+
+	_, err = r.container.CreateItem(ctx, pk, goalJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create goal in Cosmos DB: %w", err)
+	}
+
+	return goal, nil
+}
+
+func (r *CosmosGoalRepository) GetGoalByID(ctx context.Context, id uuid.UUID) (*Goal, error) {
+	query := "SELECT * FROM goals g WHERE g.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	goals, err := r.queryGoals(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(goals) == 0 {
+		return nil, ErrGoalNotFound
+	}
+
+	return goals[0], nil
+}
+
+func (r *CosmosGoalRepository) ListGoalsByUser(ctx context.Context, userID uuid.UUID) ([]*Goal, error) {
+	query := "SELECT * FROM goals g WHERE g.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryGoals(ctx, query, params)
+}
+
+func (r *CosmosGoalRepository) ListGoalsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Goal, error) {
+	query := "SELECT * FROM goals g WHERE g.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+
+	return r.queryGoals(ctx, query, params)
+}
+
+func (r *CosmosGoalRepository) DeleteGoal(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the goal to get its UserID (required for partition key)
+	goal, err := r.GetGoalByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(goal.UserID.String())
+
+	_, err = r.container.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosGoalRepository) queryGoals(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Goal, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	goals := []*Goal{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosGoal CosmosGoal
+			if err := json.Unmarshal(item, &cosmosGoal); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal goal: %w", err)
+			}
+			goals = append(goals, NewGoal(&cosmosGoal))
+		}
+	}
+
+	return goals, nil
+}
+
+type CosmosGoal struct {
+	ID          uuid.UUID          `json:"id"`
+	UserID      uuid.UUID          `json:"userId"`
+	ParameterID uuid.UUID          `json:"parameterId"`
+	Kind        Kind               `json:"kind"`
+	Target      float64            `json:"target"`
+	Min         *float64           `json:"min,omitempty"`
+	Max         *float64           `json:"max,omitempty"`
+	Period      Period             `json:"period,omitempty"`
+	Aggregation series.Aggregation `json:"aggregation,omitempty"`
+	StartDate   time.Time          `json:"startDate"`
+	Deadline    *time.Time         `json:"deadline,omitempty"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+}
+
+func NewCosmosGoal(goal *Goal) *CosmosGoal {
+	return &CosmosGoal{
+		ID:          goal.ID,
+		UserID:      goal.UserID,
+		ParameterID: goal.ParameterID,
+		Kind:        goal.Kind,
+		Target:      goal.Target,
+		Min:         goal.Min,
+		Max:         goal.Max,
+		Period:      goal.Period,
+		Aggregation: goal.Aggregation,
+		StartDate:   goal.StartDate,
+		Deadline:    goal.Deadline,
+		CreatedAt:   goal.CreatedAt,
+		UpdatedAt:   goal.UpdatedAt,
+	}
+}
+
+func NewGoal(cosmosGoal *CosmosGoal) *Goal {
+	return &Goal{
+		ID:          cosmosGoal.ID,
+		UserID:      cosmosGoal.UserID,
+		ParameterID: cosmosGoal.ParameterID,
+		Kind:        cosmosGoal.Kind,
+		Target:      cosmosGoal.Target,
+		Min:         cosmosGoal.Min,
+		Max:         cosmosGoal.Max,
+		Period:      cosmosGoal.Period,
+		Aggregation: cosmosGoal.Aggregation,
+		StartDate:   cosmosGoal.StartDate,
+		Deadline:    cosmosGoal.Deadline,
+		CreatedAt:   cosmosGoal.CreatedAt,
+		UpdatedAt:   cosmosGoal.UpdatedAt,
+	}
+}
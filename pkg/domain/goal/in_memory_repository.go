@@ -0,0 +1,81 @@
+package goal
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu    sync.RWMutex
+	goals map[uuid.UUID]*Goal
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		goals: make(map[uuid.UUID]*Goal),
+	}
+}
+
+func (r *InMemoryRepository) CreateGoal(_ context.Context, goal *Goal) (*Goal, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.goals[goal.ID] = goal
+
+	return goal, nil
+}
+
+func (r *InMemoryRepository) GetGoalByID(_ context.Context, id uuid.UUID) (*Goal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	goal, ok := r.goals[id]
+	if !ok {
+		return nil, ErrGoalNotFound
+	}
+
+	return goal, nil
+}
+
+func (r *InMemoryRepository) ListGoalsByUser(_ context.Context, userID uuid.UUID) ([]*Goal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var goals []*Goal
+	for _, goal := range r.goals {
+		if goal.UserID == userID {
+			goals = append(goals, goal)
+		}
+	}
+
+	return goals, nil
+}
+
+func (r *InMemoryRepository) ListGoalsByParameter(_ context.Context, parameterID uuid.UUID) ([]*Goal, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var goals []*Goal
+	for _, goal := range r.goals {
+		if goal.ParameterID == parameterID {
+			goals = append(goals, goal)
+		}
+	}
+
+	return goals, nil
+}
+
+func (r *InMemoryRepository) DeleteGoal(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.goals[id]; !ok {
+		return ErrGoalNotFound
+	}
+
+	delete(r.goals, id)
+
+	return nil
+}
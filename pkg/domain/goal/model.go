@@ -0,0 +1,96 @@
+package goal
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindTarget asks for the parameter to reach a value by a deadline.
+	KindTarget Kind = "target"
+	// KindRange asks for each period's value to stay within bounds.
+	KindRange Kind = "range"
+	// KindTotal asks for the values logged in each period to add up to a target.
+	KindTotal Kind = "total"
+)
+
+type Period string
+
+const (
+	PeriodDay  Period = "day"
+	PeriodWeek Period = "week"
+)
+
+type Status string
+
+const (
+	StatusAchieved Status = "achieved"
+	StatusOnTrack  Status = "on_track"
+	StatusOffTrack Status = "off_track"
+	StatusNoData   Status = "no_data"
+)
+
+type Goal struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ParameterID uuid.UUID
+	Kind        Kind
+	// Target is the value to reach for target goals and the total to
+	// accumulate per period for total goals.
+	Target float64
+	// Min and Max bound range goals. Either may be nil for a one-sided range.
+	Min *float64
+	Max *float64
+	// Period is the length of a range or total period.
+	Period Period
+	// Aggregation reduces the measurements of a day, and the days of a
+	// period, to one value for target and range goals.
+	Aggregation series.Aggregation
+	// StartDate and Deadline are calendar dates stored at midnight UTC and
+	// interpreted in the user's time zone.
+	StartDate time.Time
+	Deadline  *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type PeriodProgress struct {
+	Start time.Time
+	End   time.Time
+	Value float64
+	// Days is the number of days in the period with data.
+	Days int
+	Met  bool
+}
+
+type Progress struct {
+	GoalID uuid.UUID
+	Kind   Kind
+	AsOf   time.Time
+	Status Status
+	// Current is the latest daily value for target goals and the value of
+	// the current period for range and total goals.
+	Current float64
+	// Percent is the share of the way to the target, of the current
+	// period's total, or of periods kept in range, between 0 and 1.
+	Percent float64
+	// SlopePerDay is the fitted daily change of a target goal.
+	SlopePerDay float64
+	// ProjectedCompletion is when the target or the period total is expected
+	// to be reached at the current pace, if it is being approached at all.
+	ProjectedCompletion *time.Time
+	Periods             []PeriodProgress
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// inLocation returns midnight of the calendar date d in loc.
+func inLocation(d time.Time, loc *time.Location) time.Time {
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, 0, 0, 0, loc)
+}
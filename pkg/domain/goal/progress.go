@@ -0,0 +1,281 @@
+package goal
+
+import (
+	"math"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+// evaluateTarget compares the latest daily value with the target and
+// projects the date it will be reached by a least-squares line through the
+// days since the goal started.
+func evaluateTarget(goal *Goal, points []series.Point, deadline time.Time) Progress {
+	progress := Progress{Status: StatusNoData}
+	if len(points) == 0 {
+		return progress
+	}
+
+	first := points[0]
+	last := points[len(points)-1]
+	progress.Current = last.Value
+
+	increasing := goal.Target >= first.Value
+	reached := func(value float64) bool {
+		if increasing {
+			return value >= goal.Target
+		}
+		return value <= goal.Target
+	}
+
+	progress.Percent = 1
+	if distance := goal.Target - first.Value; distance != 0 {
+		progress.Percent = clamp((last.Value-first.Value)/distance, 0, 1)
+	}
+
+	if reached(last.Value) {
+		for _, p := range points {
+			if reached(p.Value) {
+				date := p.Date
+				progress.ProjectedCompletion = &date
+				break
+			}
+		}
+		progress.Status = StatusAchieved
+		return progress
+	}
+
+	progress.Status = StatusOffTrack
+	if len(points) < 2 {
+		return progress
+	}
+
+	days := make([]float64, len(points))
+	values := make([]float64, len(points))
+	for i, p := range points {
+		days[i] = daysBetween(first.Date, p.Date)
+		values[i] = p.Value
+	}
+
+	slope, intercept := leastSquares(days, values)
+	progress.SlopePerDay = slope
+	if slope == 0 || (slope > 0) != increasing {
+		return progress
+	}
+
+	fitted := intercept + slope*days[len(days)-1]
+	remaining := max(0, math.Ceil((goal.Target-fitted)/slope))
+	projected := last.Date.AddDate(0, 0, int(remaining))
+	progress.ProjectedCompletion = &projected
+
+	if !projected.After(deadline) {
+		progress.Status = StatusOnTrack
+	}
+
+	return progress
+}
+
+// evaluatePeriods splits the days from start to today into periods and
+// checks each of them against a range or total goal. The status follows the
+// current period.
+func evaluatePeriods(goal *Goal, points []series.Point, start, today time.Time) Progress {
+	byDay := make(map[string]series.Point, len(points))
+	for _, p := range points {
+		byDay[p.Date.Format(dateLayout)] = p
+	}
+
+	progress := Progress{Periods: []PeriodProgress{}}
+	for periodStart := startOfPeriod(start, goal.Period); !periodStart.After(today); {
+		next := nextPeriod(periodStart, goal.Period)
+
+		var values []float64
+		for day := periodStart; day.Before(next) && !day.After(today); day = day.AddDate(0, 0, 1) {
+			if day.Before(start) {
+				continue
+			}
+			if p, ok := byDay[day.Format(dateLayout)]; ok {
+				values = append(values, p.Value)
+			}
+		}
+
+		period := PeriodProgress{
+			Start: periodStart,
+			End:   next.AddDate(0, 0, -1),
+			Days:  len(values),
+		}
+		if len(values) > 0 {
+			period.Value = combine(values, goal.aggregation())
+		}
+		period.Met = goal.meets(period)
+
+		progress.Periods = append(progress.Periods, period)
+		periodStart = next
+	}
+
+	withData := 0
+	met := 0
+	for _, p := range progress.Periods {
+		if p.Days > 0 {
+			withData++
+		}
+		if p.Met {
+			met++
+		}
+	}
+	if withData == 0 {
+		progress.Status = StatusNoData
+		return progress
+	}
+
+	current := progress.Periods[len(progress.Periods)-1]
+	progress.Current = current.Value
+
+	if goal.Kind == KindTotal {
+		progress.Percent = 1
+		if goal.Target > 0 {
+			progress.Percent = clamp(current.Value/goal.Target, 0, 1)
+		}
+		progress.Status, progress.ProjectedCompletion = pace(goal, current, today)
+		return progress
+	}
+
+	progress.Percent = float64(met) / float64(withData)
+
+	// A period without data yet is judged by the last one that has some.
+	judged := current
+	for i := len(progress.Periods) - 1; i >= 0 && judged.Days == 0; i-- {
+		judged = progress.Periods[i]
+	}
+
+	progress.Status = StatusOffTrack
+	if judged.Met {
+		progress.Status = StatusOnTrack
+	}
+
+	return progress
+}
+
+// pace extrapolates the running total of the current period at its average
+// daily rate so far.
+func pace(goal *Goal, current PeriodProgress, today time.Time) (Status, *time.Time) {
+	if current.Met {
+		return StatusAchieved, nil
+	}
+	if current.Value <= 0 {
+		return StatusOffTrack, nil
+	}
+
+	elapsed := daysBetween(current.Start, today) + 1
+	rate := current.Value / elapsed
+	projected := current.Start.AddDate(0, 0, int(math.Ceil(goal.Target/rate))-1)
+
+	if projected.After(current.End) {
+		return StatusOffTrack, &projected
+	}
+
+	return StatusOnTrack, &projected
+}
+
+func (g *Goal) aggregation() series.Aggregation {
+	if g.Kind == KindTotal {
+		return series.AggregationSum
+	}
+	if g.Aggregation == "" {
+		return series.AggregationMean
+	}
+
+	return g.Aggregation
+}
+
+func (g *Goal) meets(period PeriodProgress) bool {
+	if period.Days == 0 {
+		return false
+	}
+
+	switch g.Kind {
+	case KindTotal:
+		return period.Value >= g.Target
+	case KindRange:
+		if g.Min != nil && period.Value < *g.Min {
+			return false
+		}
+		if g.Max != nil && period.Value > *g.Max {
+			return false
+		}
+		return true
+	case KindTarget:
+		return false
+	default:
+		return false
+	}
+}
+
+// combine reduces the daily values of a period. Daily sums and counts add
+// up, minima and maxima carry over and means are averaged per day.
+func combine(values []float64, agg series.Aggregation) float64 {
+	switch agg {
+	case series.AggregationSum, series.AggregationCount:
+		return stats.Sum(values)
+	case series.AggregationMin:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = min(result, v)
+		}
+		return result
+	case series.AggregationMax:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = max(result, v)
+		}
+		return result
+	case series.AggregationMean:
+		return stats.Mean(values)
+	default:
+		return stats.Mean(values)
+	}
+}
+
+func leastSquares(x, y []float64) (float64, float64) {
+	meanX := stats.Mean(x)
+	meanY := stats.Mean(y)
+
+	var sxx, sxy float64
+	for i := range x {
+		sxx += (x[i] - meanX) * (x[i] - meanX)
+		sxy += (x[i] - meanX) * (y[i] - meanY)
+	}
+	if sxx == 0 {
+		return 0, meanY
+	}
+
+	slope := sxy / sxx
+	return slope, meanY - slope*meanX
+}
+
+func startOfPeriod(day time.Time, period Period) time.Time {
+	if period == PeriodWeek {
+		// Weeks start on Monday.
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	}
+
+	return day
+}
+
+func nextPeriod(start time.Time, period Period) time.Time {
+	if period == PeriodWeek {
+		return start.AddDate(0, 0, 7)
+	}
+
+	return start.AddDate(0, 0, 1)
+}
+
+// daysBetween counts calendar days, which stays exact across DST changes.
+func daysBetween(from, to time.Time) float64 {
+	return math.Round(to.Sub(from).Hours() / 24)
+}
+
+func clamp(value, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, value))
+}
@@ -0,0 +1,20 @@
+package goal
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGoalNotFound = errors.New("goal not found")
+)
+
+type Repository interface {
+	CreateGoal(ctx context.Context, goal *Goal) (*Goal, error)
+	GetGoalByID(ctx context.Context, id uuid.UUID) (*Goal, error)
+	ListGoalsByUser(ctx context.Context, userID uuid.UUID) ([]*Goal, error)
+	ListGoalsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Goal, error)
+	DeleteGoal(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package goal
+
+import (
+	"context"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateGoal(ctx context.Context, input CreateGoalInput) (*Goal, error)
+	GetGoalByID(ctx context.Context, id uuid.UUID) (*Goal, error)
+	ListGoalsByUser(ctx context.Context, userID uuid.UUID) ([]*Goal, error)
+	ListGoalsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Goal, error)
+	DeleteGoal(ctx context.Context, id uuid.UUID) error
+	GetProgress(ctx context.Context, input GetProgressInput) (*Progress, error)
+}
+
+type CreateGoalInput struct {
+	ParameterID uuid.UUID
+	Kind        Kind
+	Target      float64
+	Min         *float64
+	Max         *float64
+	Period      Period
+	Aggregation series.Aggregation
+	// StartDate defaults to today in the user's time zone.
+	StartDate time.Time
+	Deadline  *time.Time
+}
+
+type GetProgressInput struct {
+	GoalID uuid.UUID
+	// AsOf determines which day is today. Zero means now.
+	AsOf time.Time
+}
@@ -0,0 +1,182 @@
+package goal
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrInvalidKind     = errors.New("goal kind must be target, range or total")
+	ErrInvalidPeriod   = errors.New("period must be day or week")
+	ErrInvalidRange    = errors.New("range goals need a minimum or a maximum, and the minimum must not exceed the maximum")
+	ErrInvalidTotal    = errors.New("total goals need a positive target")
+	ErrInvalidDeadline = errors.New("target goals need a deadline after the start date")
+)
+
+type ServiceImpl struct {
+	repo             Repository
+	userService      user.Service
+	parameterService parameter.Service
+	seriesService    series.Service
+}
+
+func NewService(
+	repo Repository,
+	userService user.Service,
+	parameterService parameter.Service,
+	seriesService series.Service,
+) Service {
+	return &ServiceImpl{
+		repo:             repo,
+		userService:      userService,
+		parameterService: parameterService,
+		seriesService:    seriesService,
+	}
+}
+
+func (s *ServiceImpl) CreateGoal(ctx context.Context, input CreateGoalInput) (*Goal, error) {
+	goalParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userService.GetUserByID(ctx, goalParameter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := dateOf(input.StartDate)
+	if input.StartDate.IsZero() {
+		startDate = dateOf(owner.Day(time.Now()))
+	}
+
+	goal := &Goal{
+		ID:          uuid.New(),
+		UserID:      goalParameter.UserID,
+		ParameterID: goalParameter.ID,
+		Kind:        input.Kind,
+		Target:      input.Target,
+		Min:         input.Min,
+		Max:         input.Max,
+		Period:      input.Period,
+		Aggregation: input.Aggregation,
+		StartDate:   startDate,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	if input.Deadline != nil {
+		deadline := dateOf(*input.Deadline)
+		goal.Deadline = &deadline
+	}
+
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateGoal(ctx, goal)
+}
+
+func (s *ServiceImpl) GetGoalByID(ctx context.Context, id uuid.UUID) (*Goal, error) {
+	return s.repo.GetGoalByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListGoalsByUser(ctx context.Context, userID uuid.UUID) ([]*Goal, error) {
+	return s.repo.ListGoalsByUser(ctx, userID)
+}
+
+func (s *ServiceImpl) ListGoalsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Goal, error) {
+	return s.repo.ListGoalsByParameter(ctx, parameterID)
+}
+
+func (s *ServiceImpl) DeleteGoal(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteGoal(ctx, id)
+}
+
+func (s *ServiceImpl) GetProgress(ctx context.Context, input GetProgressInput) (*Progress, error) {
+	goal, err := s.repo.GetGoalByID(ctx, input.GoalID)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := s.userService.GetUserByID(ctx, goal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	asOf := input.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	loc := owner.Location()
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, series.GetDailySeriesInput{
+		ParameterID: goal.ParameterID,
+		Aggregation: goal.aggregation(),
+		Gaps:        series.GapOptions{Strategy: series.GapStrategyDrop},
+		Location:    loc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	today := owner.Day(asOf)
+	start := inLocation(goal.StartDate, loc)
+
+	var points []series.Point
+	for _, p := range dailySeries.Points {
+		if !p.Date.Before(start) && !p.Date.After(today) {
+			points = append(points, p)
+		}
+	}
+
+	var progress Progress
+	switch goal.Kind {
+	case KindTarget:
+		progress = evaluateTarget(goal, points, inLocation(*goal.Deadline, loc))
+	case KindRange, KindTotal:
+		progress = evaluatePeriods(goal, points, start, today)
+	}
+
+	progress.GoalID = goal.ID
+	progress.Kind = goal.Kind
+	progress.AsOf = asOf
+
+	return &progress, nil
+}
+
+func validateGoal(goal *Goal) error {
+	switch goal.Kind {
+	case KindTarget:
+		if goal.Deadline == nil || !goal.Deadline.After(goal.StartDate) {
+			return ErrInvalidDeadline
+		}
+		return nil
+	case KindRange:
+		if goal.Min == nil && goal.Max == nil {
+			return ErrInvalidRange
+		}
+		if goal.Min != nil && goal.Max != nil && *goal.Min > *goal.Max {
+			return ErrInvalidRange
+		}
+	case KindTotal:
+		if goal.Target <= 0 {
+			return ErrInvalidTotal
+		}
+	default:
+		return ErrInvalidKind
+	}
+
+	if goal.Period != PeriodDay && goal.Period != PeriodWeek {
+		return ErrInvalidPeriod
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package goal_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/goal"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(day, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestTargetGoalProgress(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	goalService := goal.NewService(goal.NewInMemoryRepository(), userService, parameterService, seriesService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	weight := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	for day := 1; day <= 10; day++ {
+		domaintest.LogValue(t, measurementService, weight.ID, date(day, 8), 80-0.5*float64(day-1))
+	}
+
+	ctx := context.Background()
+	onTrack, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: weight.ID,
+		Kind:        goal.KindTarget,
+		Target:      72,
+		StartDate:   date(1, 0),
+		Deadline:    ptr(date(31, 0)),
+	})
+	require.NoError(t, err)
+
+	progress, err := goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: onTrack.ID, AsOf: date(10, 12)})
+	require.NoError(t, err)
+
+	assert.Equal(t, goal.StatusOnTrack, progress.Status)
+	assert.InDelta(t, 75.5, progress.Current, 1e-9)
+	assert.InDelta(t, 0.5625, progress.Percent, 1e-9)
+	assert.InDelta(t, -0.5, progress.SlopePerDay, 1e-9)
+	require.NotNil(t, progress.ProjectedCompletion)
+	assert.Equal(t, "2025-03-17", progress.ProjectedCompletion.Format("2006-01-02"))
+
+	tooSoon, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: weight.ID,
+		Kind:        goal.KindTarget,
+		Target:      72,
+		StartDate:   date(1, 0),
+		Deadline:    ptr(date(15, 0)),
+	})
+	require.NoError(t, err)
+
+	progress, err = goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: tooSoon.ID, AsOf: date(10, 12)})
+	require.NoError(t, err)
+	assert.Equal(t, goal.StatusOffTrack, progress.Status)
+
+	reached, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: weight.ID,
+		Kind:        goal.KindTarget,
+		Target:      77,
+		StartDate:   date(1, 0),
+		Deadline:    ptr(date(15, 0)),
+	})
+	require.NoError(t, err)
+
+	progress, err = goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: reached.ID, AsOf: date(10, 12)})
+	require.NoError(t, err)
+	assert.Equal(t, goal.StatusAchieved, progress.Status)
+	require.NotNil(t, progress.ProjectedCompletion)
+	assert.Equal(t, "2025-03-07", progress.ProjectedCompletion.Format("2006-01-02"))
+}
+
+func TestTotalGoalProgress(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	goalService := goal.NewService(goal.NewInMemoryRepository(), userService, parameterService, seriesService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	water := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Water",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	// March 3rd 2025 is a Monday.
+	domaintest.LogValue(t, measurementService, water.ID, date(3, 9), 1)
+	domaintest.LogValue(t, measurementService, water.ID, date(3, 15), 1)
+	domaintest.LogValue(t, measurementService, water.ID, date(4, 9), 2)
+
+	ctx := context.Background()
+	weekly, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: water.ID,
+		Kind:        goal.KindTotal,
+		Target:      10,
+		Period:      goal.PeriodWeek,
+		StartDate:   date(3, 0),
+	})
+	require.NoError(t, err)
+
+	progress, err := goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: weekly.ID, AsOf: date(5, 12)})
+	require.NoError(t, err)
+
+	assert.Equal(t, goal.StatusOffTrack, progress.Status)
+	assert.InDelta(t, 4, progress.Current, 1e-9)
+	require.NotNil(t, progress.ProjectedCompletion)
+	assert.Equal(t, "2025-03-10", progress.ProjectedCompletion.Format("2006-01-02"))
+
+	domaintest.LogValue(t, measurementService, water.ID, date(5, 9), 2)
+
+	progress, err = goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: weekly.ID, AsOf: date(5, 12)})
+	require.NoError(t, err)
+
+	assert.Equal(t, goal.StatusOnTrack, progress.Status)
+	assert.InDelta(t, 0.6, progress.Percent, 1e-9)
+	require.NotNil(t, progress.ProjectedCompletion)
+	assert.Equal(t, "2025-03-07", progress.ProjectedCompletion.Format("2006-01-02"))
+	require.Len(t, progress.Periods, 1)
+	assert.Equal(t, "2025-03-09", progress.Periods[0].End.Format("2006-01-02"))
+}
+
+func TestRangeGoalProgress(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	goalService := goal.NewService(goal.NewInMemoryRepository(), userService, parameterService, seriesService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	sleep := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	domaintest.LogValue(t, measurementService, sleep.ID, date(3, 7), 8)
+	domaintest.LogValue(t, measurementService, sleep.ID, date(4, 7), 6)
+	domaintest.LogValue(t, measurementService, sleep.ID, date(5, 7), 7.5)
+
+	ctx := context.Background()
+	daily, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: sleep.ID,
+		Kind:        goal.KindRange,
+		Min:         ptr(7.0),
+		Max:         ptr(9.0),
+		Period:      goal.PeriodDay,
+		StartDate:   date(3, 0),
+	})
+	require.NoError(t, err)
+
+	// Today has no entry yet, so the status follows yesterday.
+	progress, err := goalService.GetProgress(ctx, goal.GetProgressInput{GoalID: daily.ID, AsOf: date(6, 12)})
+	require.NoError(t, err)
+
+	assert.Equal(t, goal.StatusOnTrack, progress.Status)
+	assert.InDelta(t, 2.0/3.0, progress.Percent, 1e-9)
+	assert.Nil(t, progress.ProjectedCompletion)
+	require.Len(t, progress.Periods, 4)
+	assert.True(t, progress.Periods[0].Met)
+	assert.False(t, progress.Periods[1].Met)
+	assert.Equal(t, 0, progress.Periods[3].Days)
+}
+
+func TestCreateGoalValidation(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	goalService := goal.NewService(goal.NewInMemoryRepository(), userService, parameterService, seriesService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	sleep := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	_, err := goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: sleep.ID,
+		Kind:        goal.KindRange,
+		Min:         ptr(9.0),
+		Max:         ptr(7.0),
+		Period:      goal.PeriodDay,
+	})
+	require.ErrorIs(t, err, goal.ErrInvalidRange)
+
+	_, err = goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: sleep.ID,
+		Kind:        goal.KindTarget,
+		Target:      8,
+	})
+	require.ErrorIs(t, err, goal.ErrInvalidDeadline)
+
+	_, err = goalService.CreateGoal(ctx, goal.CreateGoalInput{
+		ParameterID: sleep.ID,
+		Kind:        goal.KindTotal,
+		Target:      50,
+		Period:      "month",
+	})
+	require.ErrorIs(t, err, goal.ErrInvalidPeriod)
+
+	goals, err := goalService.ListGoalsByParameter(ctx, sleep.ID)
+	require.NoError(t, err)
+	assert.Empty(t, goals)
+}
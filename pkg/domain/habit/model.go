@@ -0,0 +1,53 @@
+package habit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DayStatus string
+
+const (
+	DayStatusHit  DayStatus = "hit"
+	DayStatusMiss DayStatus = "miss"
+	// DayStatusPending marks today when it is scheduled but nothing has been
+	// logged yet. It neither extends nor breaks a streak.
+	DayStatusPending     DayStatus = "pending"
+	DayStatusUnscheduled DayStatus = "unscheduled"
+)
+
+type Day struct {
+	Date      time.Time
+	Status    DayStatus
+	Scheduled bool
+}
+
+type Streak struct {
+	Length int
+	Start  *time.Time
+	End    *time.Time
+}
+
+type PeriodRate struct {
+	Start     time.Time
+	Hits      int
+	Scheduled int
+	Rate      float64
+}
+
+// Streaks summarises a boolean parameter as a habit. A day is a hit when at
+// least one true value was logged on it. Only scheduled days count towards
+// streaks and completion rates.
+type Streaks struct {
+	ParameterID    uuid.UUID
+	Schedule       []time.Weekday
+	Current        Streak
+	Longest        Streak
+	Hits           int
+	Scheduled      int
+	CompletionRate float64
+	Weekly         []PeriodRate
+	Monthly        []PeriodRate
+	Calendar       []Day
+}
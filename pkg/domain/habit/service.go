@@ -0,0 +1,18 @@
+package habit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetStreaks(ctx context.Context, input GetStreaksInput) (*Streaks, error)
+}
+
+type GetStreaksInput struct {
+	ParameterID uuid.UUID
+	// AsOf determines which day is today. Zero means now.
+	AsOf time.Time
+}
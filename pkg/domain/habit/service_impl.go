@@ -0,0 +1,110 @@
+package habit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+)
+
+var (
+	ErrNotBooleanParameter = errors.New("streaks are only available for boolean parameters")
+)
+
+type ServiceImpl struct {
+	userService        user.Service
+	parameterService   parameter.Service
+	measurementService measurement.Service
+}
+
+func NewService(
+	userService user.Service,
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+) Service {
+	return &ServiceImpl{
+		userService:        userService,
+		parameterService:   parameterService,
+		measurementService: measurementService,
+	}
+}
+
+func (s *ServiceImpl) GetStreaks(ctx context.Context, input GetStreaksInput) (*Streaks, error) {
+	habitParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if habitParameter.DataType != parameter.DataTypeBoolean {
+		return nil, ErrNotBooleanParameter
+	}
+
+	owner, err := s.userService.GetUserByID(ctx, habitParameter.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, err := s.measurementService.ListMeasurementsByParameter(
+		ctx, habitParameter.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	measurements := listed.Items
+
+	asOf := input.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+
+	// Days are computed in the user's time zone and shifted by their day
+	// start hour, so a check-in at 1am can still count for the evening before.
+	today := owner.Day(asOf)
+	start := owner.Day(habitParameter.CreatedAt)
+	hits := make(map[string]bool)
+	for _, m := range measurements {
+		booleanMeasurement, ok := m.(*measurement.BooleanMeasurement)
+		if !ok || m.GetTimestamp().After(asOf) {
+			continue
+		}
+
+		day := owner.Day(m.GetTimestamp())
+		if day.Before(start) {
+			start = day
+		}
+		if booleanMeasurement.GetValue() {
+			hits[day.Format(dateLayout)] = true
+		}
+	}
+
+	// Re-anchor on today's location so that every day shares one *Location.
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, today.Location())
+	days := calendar(start, today, hits, habitParameter.IsScheduled)
+
+	result := &Streaks{
+		ParameterID: habitParameter.ID,
+		Schedule:    habitParameter.Schedule,
+		Current:     currentStreak(days),
+		Longest:     longestStreak(days),
+		Weekly:      completionRates(days, startOfWeek),
+		Monthly:     completionRates(days, startOfMonth),
+		Calendar:    days,
+	}
+
+	for _, day := range days {
+		if !day.Scheduled || day.Status == DayStatusPending {
+			continue
+		}
+		result.Scheduled++
+		if day.Status == DayStatusHit {
+			result.Hits++
+		}
+	}
+	result.CompletionRate = ratio(result.Hits, result.Scheduled)
+
+	return result, nil
+}
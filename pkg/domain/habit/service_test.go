@@ -0,0 +1,139 @@
+package habit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/habit"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(day, hour int) time.Time {
+	return time.Date(2025, time.March, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestGetStreaks(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	habitService := habit.NewService(userService, parameterService, measurementService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID:   "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:    "John",
+		LastName:     "Doe",
+		DayStartHour: 4,
+	})
+	workout := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Workout",
+		DataType: parameter.DataTypeBoolean,
+		Schedule: []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+	})
+
+	// March 3rd 2025 is a Monday.
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(3, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(5, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(7, 20), false)
+	// Before the user's 4am day start, so this counts for Monday the 10th.
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(11, 2), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(12, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(13, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(14, 20), true)
+
+	streaks, err := habitService.GetStreaks(context.Background(), habit.GetStreaksInput{
+		ParameterID: workout.ID,
+		AsOf:        date(17, 12),
+	})
+	require.NoError(t, err)
+
+	require.Len(t, streaks.Calendar, 15)
+	assert.Equal(t, habit.DayStatusHit, streaks.Calendar[0].Status)
+	assert.Equal(t, habit.DayStatusMiss, streaks.Calendar[4].Status)
+	assert.Equal(t, habit.DayStatusUnscheduled, streaks.Calendar[5].Status)
+	assert.Equal(t, habit.DayStatusHit, streaks.Calendar[7].Status)
+	assert.Equal(t, habit.DayStatusUnscheduled, streaks.Calendar[8].Status)
+	assert.Equal(t, habit.DayStatusHit, streaks.Calendar[10].Status)
+	assert.False(t, streaks.Calendar[10].Scheduled)
+	assert.Equal(t, habit.DayStatusPending, streaks.Calendar[14].Status)
+
+	// Today is still pending, so the streak carries over from last week.
+	assert.Equal(t, 3, streaks.Current.Length)
+	assert.Equal(t, date(10, 0), *streaks.Current.Start)
+	assert.Equal(t, date(14, 0), *streaks.Current.End)
+	assert.Equal(t, 3, streaks.Longest.Length)
+
+	assert.Equal(t, 5, streaks.Hits)
+	assert.Equal(t, 6, streaks.Scheduled)
+	assert.InDelta(t, 5.0/6.0, streaks.CompletionRate, 1e-9)
+
+	require.Len(t, streaks.Weekly, 3)
+	assert.Equal(t, date(3, 0), streaks.Weekly[0].Start)
+	assert.Equal(t, 2, streaks.Weekly[0].Hits)
+	assert.Equal(t, 3, streaks.Weekly[0].Scheduled)
+	assert.InDelta(t, 1.0, streaks.Weekly[1].Rate, 1e-9)
+	assert.Equal(t, 0, streaks.Weekly[2].Scheduled)
+
+	require.Len(t, streaks.Monthly, 1)
+	assert.Equal(t, date(1, 0), streaks.Monthly[0].Start)
+}
+
+func TestGetStreaks_MissBreaksCurrentStreak(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	habitService := habit.NewService(userService, parameterService, measurementService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID:   "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:    "John",
+		LastName:     "Doe",
+		DayStartHour: 4,
+	})
+	workout := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Workout",
+		DataType: parameter.DataTypeBoolean,
+	})
+
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(3, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(4, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(5, 20), true)
+	domaintest.LogBoolean(t, measurementService, workout.ID, date(7, 20), true)
+
+	streaks, err := habitService.GetStreaks(context.Background(), habit.GetStreaksInput{
+		ParameterID: workout.ID,
+		AsOf:        date(9, 12),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, streaks.Current.Length)
+	assert.Nil(t, streaks.Current.Start)
+	assert.Equal(t, 3, streaks.Longest.Length)
+	assert.Equal(t, date(3, 0), *streaks.Longest.Start)
+}
+
+func TestGetStreaks_NotBoolean(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	habitService := habit.NewService(userService, parameterService, measurementService)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID:   "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:    "John",
+		LastName:     "Doe",
+		DayStartHour: 4,
+	})
+	weight := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Workout",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	_, err := habitService.GetStreaks(context.Background(), habit.GetStreaksInput{ParameterID: weight.ID})
+	require.ErrorIs(t, err, habit.ErrNotBooleanParameter)
+}
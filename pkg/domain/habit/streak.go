@@ -0,0 +1,130 @@
+package habit
+
+import (
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// calendar lists every day from start to today inclusive. Both are midnight
+// in the user's location.
+func calendar(start, today time.Time, hits map[string]bool, isScheduled func(time.Weekday) bool) []Day {
+	days := []Day{}
+	for day := start; !day.After(today); day = day.AddDate(0, 0, 1) {
+		scheduled := isScheduled(day.Weekday())
+
+		status := DayStatusUnscheduled
+		switch {
+		case hits[day.Format(dateLayout)]:
+			status = DayStatusHit
+		case scheduled && day.Equal(today):
+			status = DayStatusPending
+		case scheduled:
+			status = DayStatusMiss
+		}
+
+		days = append(days, Day{
+			Date:      day,
+			Status:    status,
+			Scheduled: scheduled,
+		})
+	}
+
+	return days
+}
+
+func currentStreak(days []Day) Streak {
+	var streak Streak
+	for i := len(days) - 1; i >= 0; i-- {
+		day := days[i]
+		if !day.Scheduled || day.Status == DayStatusPending {
+			continue
+		}
+		if day.Status != DayStatusHit {
+			break
+		}
+
+		date := day.Date
+		if streak.End == nil {
+			streak.End = &date
+		}
+		streak.Start = &date
+		streak.Length++
+	}
+
+	return streak
+}
+
+// longestStreak returns the longest run of scheduled hits, preferring the most
+// recent one on ties.
+func longestStreak(days []Day) Streak {
+	var longest, run Streak
+	for _, day := range days {
+		if !day.Scheduled || day.Status == DayStatusPending {
+			continue
+		}
+		if day.Status != DayStatusHit {
+			run = Streak{}
+			continue
+		}
+
+		date := day.Date
+		if run.Start == nil {
+			run.Start = &date
+		}
+		run.End = &date
+		run.Length++
+
+		if run.Length >= longest.Length {
+			longest = run
+		}
+	}
+
+	return longest
+}
+
+// completionRates groups scheduled days into periods identified by their
+// first day and reports the share of hits in each. Pending days are left out.
+func completionRates(days []Day, periodStart func(time.Time) time.Time) []PeriodRate {
+	rates := []PeriodRate{}
+	for _, day := range days {
+		start := periodStart(day.Date)
+		if len(rates) == 0 || !rates[len(rates)-1].Start.Equal(start) {
+			rates = append(rates, PeriodRate{Start: start})
+		}
+
+		if !day.Scheduled || day.Status == DayStatusPending {
+			continue
+		}
+
+		rate := &rates[len(rates)-1]
+		rate.Scheduled++
+		if day.Status == DayStatusHit {
+			rate.Hits++
+		}
+	}
+
+	for i := range rates {
+		rates[i].Rate = ratio(rates[i].Hits, rates[i].Scheduled)
+	}
+
+	return rates
+}
+
+// startOfWeek returns the Monday of the week the day falls into.
+func startOfWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func startOfMonth(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+}
+
+func ratio(hits, scheduled int) float64 {
+	if scheduled == 0 {
+		return 0
+	}
+
+	return float64(hits) / float64(scheduled)
+}
@@ -0,0 +1,276 @@
+package hypothesis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName            = "correlateapp"
+	hypothesisContainerName = "Hypotheses"
+	evaluationContainerName = "HypothesisEvaluations"
+	partitionKey            = "/userId"
+)
+
+type CosmosHypothesisRepository struct {
+	client              *azcosmos.Client
+	hypothesisContainer *azcosmos.ContainerClient
+	evaluationContainer *azcosmos.ContainerClient
+}
+
+func NewCosmosHypothesisRepository(connectionString string) (*CosmosHypothesisRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for hypothesis repository: %w", err)
+	}
+
+	hypothesisContainer, err := client.NewContainer(databaseName, hypothesisContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for hypotheses: %w", err)
+	}
+
+	evaluationContainer, err := client.NewContainer(databaseName, evaluationContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for hypothesis evaluations: %w", err)
+	}
+
+	return &CosmosHypothesisRepository{
+		client:              client,
+		hypothesisContainer: hypothesisContainer,
+		evaluationContainer: evaluationContainer,
+	}, nil
+}
+
+func (r *CosmosHypothesisRepository) CreateHypothesis(
+	ctx context.Context,
+	hypothesis *Hypothesis,
+) (*Hypothesis, error) {
+	hypothesisJSON, err := json.Marshal(NewCosmosHypothesis(hypothesis))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hypothesis: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(hypothesis.UserID.String())
+
+	_, err = r.hypothesisContainer.CreateItem(ctx, pk, hypothesisJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hypothesis in Cosmos DB: %w", err)
+	}
+
+	return hypothesis, nil
+}
+
+func (r *CosmosHypothesisRepository) GetHypothesisByID(ctx context.Context, id uuid.UUID) (*Hypothesis, error) {
+	query := "SELECT * FROM hypotheses h WHERE h.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	hypotheses, err := r.queryHypotheses(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hypotheses) == 0 {
+		return nil, ErrHypothesisNotFound
+	}
+
+	return hypotheses[0], nil
+}
+
+func (r *CosmosHypothesisRepository) ListHypotheses(ctx context.Context) ([]*Hypothesis, error) {
+	return r.queryHypotheses(ctx, "SELECT * FROM hypotheses h", nil)
+}
+
+func (r *CosmosHypothesisRepository) ListHypothesesByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*Hypothesis, error) {
+	query := "SELECT * FROM hypotheses h WHERE h.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryHypotheses(ctx, query, params)
+}
+
+func (r *CosmosHypothesisRepository) UpdateHypothesis(
+	ctx context.Context,
+	hypothesis *Hypothesis,
+) (*Hypothesis, error) {
+	hypothesisJSON, err := json.Marshal(NewCosmosHypothesis(hypothesis))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hypothesis: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(hypothesis.UserID.String())
+
+	_, err = r.hypothesisContainer.ReplaceItem(ctx, pk, hypothesis.ID.String(), hypothesisJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update hypothesis in Cosmos DB: %w", err)
+	}
+
+	return hypothesis, nil
+}
+
+func (r *CosmosHypothesisRepository) DeleteHypothesis(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the hypothesis to get its UserID (required for partition key)
+	hypothesis, err := r.GetHypothesisByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(hypothesis.UserID.String())
+
+	evaluations, err := r.ListEvaluationsByHypothesis(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, evaluation := range evaluations {
+		_, err = r.evaluationContainer.DeleteItem(ctx, pk, evaluation.ID.String(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete hypothesis evaluation from Cosmos DB: %w", err)
+		}
+	}
+
+	_, err = r.hypothesisContainer.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete hypothesis from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosHypothesisRepository) CreateEvaluation(
+	ctx context.Context,
+	evaluation *Evaluation,
+) (*Evaluation, error) {
+	evaluationJSON, err := json.Marshal(NewCosmosEvaluation(evaluation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hypothesis evaluation: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(evaluation.UserID.String())
+
+	_, err = r.evaluationContainer.CreateItem(ctx, pk, evaluationJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hypothesis evaluation in Cosmos DB: %w", err)
+	}
+
+	return evaluation, nil
+}
+
+func (r *CosmosHypothesisRepository) ListEvaluationsByHypothesis(
+	ctx context.Context,
+	hypothesisID uuid.UUID,
+) ([]*Evaluation, error) {
+	query := "SELECT * FROM evaluations e WHERE e.hypothesisId = @hypothesisID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@hypothesisID", Value: hypothesisID.String()},
+	}
+
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.evaluationContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	evaluations := []*Evaluation{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosEvaluation CosmosEvaluation
+			if err := json.Unmarshal(item, &cosmosEvaluation); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal hypothesis evaluation: %w", err)
+			}
+			evaluations = append(evaluations, NewEvaluation(&cosmosEvaluation))
+		}
+	}
+
+	return evaluations, nil
+}
+
+func (r *CosmosHypothesisRepository) queryHypotheses(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Hypothesis, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.hypothesisContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	hypotheses := []*Hypothesis{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosHypothesis CosmosHypothesis
+			if err := json.Unmarshal(item, &cosmosHypothesis); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal hypothesis: %w", err)
+			}
+			hypotheses = append(hypotheses, NewHypothesis(&cosmosHypothesis))
+		}
+	}
+
+	return hypotheses, nil
+}
+
+type CosmosHypothesis struct {
+	ID                uuid.UUID          `json:"id"`
+	UserID            uuid.UUID          `json:"userId"`
+	CauseParameterID  uuid.UUID          `json:"causeParameterId"`
+	EffectParameterID uuid.UUID          `json:"effectParameterId"`
+	Statement         string             `json:"statement"`
+	ExpectedDirection Direction          `json:"expectedDirection"`
+	MinLagDays        int                `json:"minLagDays"`
+	MaxLagDays        int                `json:"maxLagDays"`
+	Aggregation       series.Aggregation `json:"aggregation,omitempty"`
+	Verdict           Verdict            `json:"verdict,omitempty"`
+	LastEvaluatedAt   *time.Time         `json:"lastEvaluatedAt,omitempty"`
+	CreatedAt         time.Time          `json:"createdAt"`
+	UpdatedAt         time.Time          `json:"updatedAt"`
+}
+
+func NewCosmosHypothesis(hypothesis *Hypothesis) *CosmosHypothesis {
+	cosmosHypothesis := CosmosHypothesis(*hypothesis)
+	return &cosmosHypothesis
+}
+
+func NewHypothesis(cosmosHypothesis *CosmosHypothesis) *Hypothesis {
+	hypothesis := Hypothesis(*cosmosHypothesis)
+	return &hypothesis
+}
+
+type CosmosEvaluation struct {
+	ID           uuid.UUID `json:"id"`
+	HypothesisID uuid.UUID `json:"hypothesisId"`
+	UserID       uuid.UUID `json:"userId"`
+	EvaluatedAt  time.Time `json:"evaluatedAt"`
+	Days         int       `json:"days"`
+	Effect       float64   `json:"effect"`
+	Lower        float64   `json:"lower"`
+	Upper        float64   `json:"upper"`
+	PValue       float64   `json:"pValue"`
+	Verdict      Verdict   `json:"verdict"`
+	Changed      bool      `json:"changed"`
+}
+
+func NewCosmosEvaluation(evaluation *Evaluation) *CosmosEvaluation {
+	cosmosEvaluation := CosmosEvaluation(*evaluation)
+	return &cosmosEvaluation
+}
+
+func NewEvaluation(cosmosEvaluation *CosmosEvaluation) *Evaluation {
+	evaluation := Evaluation(*cosmosEvaluation)
+	return &evaluation
+}
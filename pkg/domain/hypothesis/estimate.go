@@ -0,0 +1,106 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const dateLayout = "2006-01-02"
+
+// maxCorrelation keeps the Fisher transform finite for perfectly correlated
+// data.
+const maxCorrelation = 1 - 1e-12
+
+type estimate struct {
+	days   int
+	effect float64
+	lower  float64
+	upper  float64
+	pValue float64
+	// defined is false when either series has no variance.
+	defined bool
+}
+
+// pairDays pairs each effect day with the mean cause over the days minLag to
+// maxLag before it. Effect days without any cause value in that range are
+// left out.
+func pairDays(cause, effect []series.Point, minLag, maxLag int) ([]float64, []float64) {
+	causeByDate := make(map[string]float64, len(cause))
+	for _, p := range cause {
+		causeByDate[p.Date.Format(dateLayout)] = p.Value
+	}
+
+	var xs, ys []float64
+	for _, p := range effect {
+		var sum float64
+		count := 0
+		for lag := minLag; lag <= maxLag; lag++ {
+			if value, ok := causeByDate[p.Date.AddDate(0, 0, -lag).Format(dateLayout)]; ok {
+				sum += value
+				count++
+			}
+		}
+		if count == 0 {
+			continue
+		}
+
+		xs = append(xs, sum/float64(count))
+		ys = append(ys, p.Value)
+	}
+
+	return xs, ys
+}
+
+// correlate estimates the Pearson correlation with a Fisher z interval at
+// the given level and a two-sided t-test p-value.
+func correlate(xs, ys []float64, level float64) estimate {
+	n := len(xs)
+	result := estimate{days: n}
+	if n < minPairs {
+		return result
+	}
+
+	meanX, meanY := stats.Mean(xs), stats.Mean(ys)
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return result
+	}
+
+	r := math.Max(-maxCorrelation, math.Min(maxCorrelation, sxy/math.Sqrt(sxx*syy)))
+	z := math.Atanh(r)
+	margin := stats.NormalQuantile(1-(1-level)/2) / math.Sqrt(float64(n-3))
+	t := r * math.Sqrt(float64(n-2)/(1-r*r))
+
+	result.effect = r
+	result.lower = math.Tanh(z - margin)
+	result.upper = math.Tanh(z + margin)
+	result.pValue = 2 * (1 - stats.StudentTCDF(math.Abs(t), float64(n-2)))
+	result.defined = true
+
+	return result
+}
+
+func (e estimate) verdict(expected Direction) Verdict {
+	if !e.defined {
+		return VerdictInsufficientData
+	}
+
+	positive := e.lower > 0
+	negative := e.upper < 0
+	switch {
+	case !positive && !negative:
+		return VerdictInconclusive
+	case positive == (expected == DirectionPositive):
+		return VerdictSupported
+	default:
+		return VerdictContradicted
+	}
+}
@@ -0,0 +1,125 @@
+package hypothesis
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu          sync.RWMutex
+	hypotheses  map[uuid.UUID]*Hypothesis
+	evaluations map[uuid.UUID]*Evaluation
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		hypotheses:  make(map[uuid.UUID]*Hypothesis),
+		evaluations: make(map[uuid.UUID]*Evaluation),
+	}
+}
+
+func (r *InMemoryRepository) CreateHypothesis(_ context.Context, hypothesis *Hypothesis) (*Hypothesis, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.hypotheses[hypothesis.ID] = hypothesis
+
+	return hypothesis, nil
+}
+
+func (r *InMemoryRepository) GetHypothesisByID(_ context.Context, id uuid.UUID) (*Hypothesis, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hypothesis, ok := r.hypotheses[id]
+	if !ok {
+		return nil, ErrHypothesisNotFound
+	}
+
+	return hypothesis, nil
+}
+
+func (r *InMemoryRepository) ListHypotheses(_ context.Context) ([]*Hypothesis, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hypotheses := make([]*Hypothesis, 0, len(r.hypotheses))
+	for _, hypothesis := range r.hypotheses {
+		hypotheses = append(hypotheses, hypothesis)
+	}
+
+	return hypotheses, nil
+}
+
+func (r *InMemoryRepository) ListHypothesesByUser(_ context.Context, userID uuid.UUID) ([]*Hypothesis, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var hypotheses []*Hypothesis
+	for _, hypothesis := range r.hypotheses {
+		if hypothesis.UserID == userID {
+			hypotheses = append(hypotheses, hypothesis)
+		}
+	}
+
+	return hypotheses, nil
+}
+
+func (r *InMemoryRepository) UpdateHypothesis(_ context.Context, hypothesis *Hypothesis) (*Hypothesis, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.hypotheses[hypothesis.ID]; !ok {
+		return nil, ErrHypothesisNotFound
+	}
+
+	r.hypotheses[hypothesis.ID] = hypothesis
+
+	return hypothesis, nil
+}
+
+func (r *InMemoryRepository) DeleteHypothesis(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.hypotheses[id]; !ok {
+		return ErrHypothesisNotFound
+	}
+
+	delete(r.hypotheses, id)
+	for evaluationID, evaluation := range r.evaluations {
+		if evaluation.HypothesisID == id {
+			delete(r.evaluations, evaluationID)
+		}
+	}
+
+	return nil
+}
+
+func (r *InMemoryRepository) CreateEvaluation(_ context.Context, evaluation *Evaluation) (*Evaluation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.evaluations[evaluation.ID] = evaluation
+
+	return evaluation, nil
+}
+
+func (r *InMemoryRepository) ListEvaluationsByHypothesis(
+	_ context.Context,
+	hypothesisID uuid.UUID,
+) ([]*Evaluation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var evaluations []*Evaluation
+	for _, evaluation := range r.evaluations {
+		if evaluation.HypothesisID == hypothesisID {
+			evaluations = append(evaluations, evaluation)
+		}
+	}
+
+	return evaluations, nil
+}
@@ -0,0 +1,74 @@
+package hypothesis
+
+import (
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Direction string
+
+const (
+	DirectionPositive Direction = "positive"
+	DirectionNegative Direction = "negative"
+)
+
+type Verdict string
+
+const (
+	// VerdictInsufficientData means there are too few paired days, or one
+	// of the parameters never varies.
+	VerdictInsufficientData Verdict = "insufficient_data"
+	// VerdictInconclusive means the interval still includes no effect.
+	VerdictInconclusive Verdict = "inconclusive"
+	// VerdictSupported means the interval lies entirely on the expected side
+	// of zero.
+	VerdictSupported Verdict = "supported"
+	// VerdictContradicted means the interval lies entirely on the opposite
+	// side of zero.
+	VerdictContradicted Verdict = "contradicted"
+)
+
+// Hypothesis states that the cause parameter moves the effect parameter in
+// the expected direction within a range of days.
+type Hypothesis struct {
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	CauseParameterID  uuid.UUID
+	EffectParameterID uuid.UUID
+	Statement         string
+	ExpectedDirection Direction
+	// MinLagDays and MaxLagDays bound how many days after the cause the
+	// effect is expected. Each effect day is paired with the mean cause over
+	// that range of earlier days.
+	MinLagDays  int
+	MaxLagDays  int
+	Aggregation series.Aggregation
+	// Verdict and LastEvaluatedAt describe the latest evaluation. Verdict is
+	// empty until the hypothesis was evaluated once.
+	Verdict         Verdict
+	LastEvaluatedAt *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Evaluation is one entry of a hypothesis' history. A new entry is recorded
+// only when the data behind the estimate changed.
+type Evaluation struct {
+	ID           uuid.UUID
+	HypothesisID uuid.UUID
+	UserID       uuid.UUID
+	EvaluatedAt  time.Time
+	// Days is the number of paired days.
+	Days int
+	// Effect is the Pearson correlation between the lagged cause and the
+	// effect, with its confidence interval at Level.
+	Effect  float64
+	Lower   float64
+	Upper   float64
+	PValue  float64
+	Verdict Verdict
+	// Changed reports whether the verdict differs from the previous entry.
+	Changed bool
+}
@@ -0,0 +1,25 @@
+package hypothesis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrHypothesisNotFound = errors.New("hypothesis not found")
+)
+
+type Repository interface {
+	CreateHypothesis(ctx context.Context, hypothesis *Hypothesis) (*Hypothesis, error)
+	GetHypothesisByID(ctx context.Context, id uuid.UUID) (*Hypothesis, error)
+	ListHypotheses(ctx context.Context) ([]*Hypothesis, error)
+	ListHypothesesByUser(ctx context.Context, userID uuid.UUID) ([]*Hypothesis, error)
+	UpdateHypothesis(ctx context.Context, hypothesis *Hypothesis) (*Hypothesis, error)
+	// DeleteHypothesis also deletes the hypothesis' evaluations.
+	DeleteHypothesis(ctx context.Context, id uuid.UUID) error
+
+	CreateEvaluation(ctx context.Context, evaluation *Evaluation) (*Evaluation, error)
+	ListEvaluationsByHypothesis(ctx context.Context, hypothesisID uuid.UUID) ([]*Evaluation, error)
+}
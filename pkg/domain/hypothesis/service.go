@@ -0,0 +1,36 @@
+package hypothesis
+
+import (
+	"context"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateHypothesis(ctx context.Context, input CreateHypothesisInput) (*Hypothesis, error)
+	GetHypothesisByID(ctx context.Context, id uuid.UUID) (*Hypothesis, error)
+	ListHypothesesByUser(ctx context.Context, userID uuid.UUID) ([]*Hypothesis, error)
+	DeleteHypothesis(ctx context.Context, id uuid.UUID) error
+	// ListEvaluations returns the history of a hypothesis, oldest first.
+	ListEvaluations(ctx context.Context, hypothesisID uuid.UUID) ([]*Evaluation, error)
+	// EvaluateHypothesis estimates the effect as of now and returns the latest
+	// history entry, which is new only if the estimate changed.
+	EvaluateHypothesis(ctx context.Context, id uuid.UUID, now time.Time) (*Evaluation, error)
+	// EvaluateHypotheses re-evaluates every hypothesis and returns the new
+	// history entries. It is meant to be called on a schedule. A hypothesis
+	// that fails to evaluate, for example because one of its parameters was
+	// deleted, is logged and skipped.
+	EvaluateHypotheses(ctx context.Context, now time.Time) ([]*Evaluation, error)
+}
+
+type CreateHypothesisInput struct {
+	CauseParameterID  uuid.UUID
+	EffectParameterID uuid.UUID
+	Statement         string
+	ExpectedDirection Direction
+	MinLagDays        int
+	MaxLagDays        int
+	Aggregation       series.Aggregation
+}
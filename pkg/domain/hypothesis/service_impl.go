@@ -0,0 +1,253 @@
+package hypothesis
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+)
+
+const (
+	MaxLagDays = 14
+	// Level is the coverage of the effect intervals.
+	Level = 0.95
+
+	minPairs = 10
+	// estimateTolerance ignores floating point noise when deciding whether a
+	// re-evaluation changed the estimate.
+	estimateTolerance = 1e-9
+)
+
+var (
+	ErrInvalidParameters = errors.New("cause and effect must be different parameters of the same user")
+	ErrInvalidDirection  = errors.New("expected direction must be positive or negative")
+	ErrInvalidLag        = errors.New("lags must satisfy 0 <= min <= max <= 14 days")
+)
+
+type ServiceImpl struct {
+	repo             Repository
+	userService      user.Service
+	parameterService parameter.Service
+	seriesService    series.Service
+}
+
+func NewService(
+	repo Repository,
+	userService user.Service,
+	parameterService parameter.Service,
+	seriesService series.Service,
+) Service {
+	return &ServiceImpl{
+		repo:             repo,
+		userService:      userService,
+		parameterService: parameterService,
+		seriesService:    seriesService,
+	}
+}
+
+func (s *ServiceImpl) CreateHypothesis(ctx context.Context, input CreateHypothesisInput) (*Hypothesis, error) {
+	switch input.ExpectedDirection {
+	case DirectionPositive, DirectionNegative:
+	default:
+		return nil, ErrInvalidDirection
+	}
+
+	if input.MinLagDays < 0 || input.MinLagDays > input.MaxLagDays || input.MaxLagDays > MaxLagDays {
+		return nil, ErrInvalidLag
+	}
+
+	cause, err := s.parameterService.GetParameterByID(ctx, input.CauseParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	effect, err := s.parameterService.GetParameterByID(ctx, input.EffectParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cause.ID == effect.ID || cause.UserID != effect.UserID {
+		return nil, ErrInvalidParameters
+	}
+
+	hypothesis := &Hypothesis{
+		ID:                uuid.New(),
+		UserID:            cause.UserID,
+		CauseParameterID:  cause.ID,
+		EffectParameterID: effect.ID,
+		Statement:         input.Statement,
+		ExpectedDirection: input.ExpectedDirection,
+		MinLagDays:        input.MinLagDays,
+		MaxLagDays:        input.MaxLagDays,
+		Aggregation:       input.Aggregation,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	return s.repo.CreateHypothesis(ctx, hypothesis)
+}
+
+func (s *ServiceImpl) GetHypothesisByID(ctx context.Context, id uuid.UUID) (*Hypothesis, error) {
+	return s.repo.GetHypothesisByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListHypothesesByUser(ctx context.Context, userID uuid.UUID) ([]*Hypothesis, error) {
+	return s.repo.ListHypothesesByUser(ctx, userID)
+}
+
+func (s *ServiceImpl) DeleteHypothesis(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteHypothesis(ctx, id)
+}
+
+func (s *ServiceImpl) ListEvaluations(ctx context.Context, hypothesisID uuid.UUID) ([]*Evaluation, error) {
+	if _, err := s.repo.GetHypothesisByID(ctx, hypothesisID); err != nil {
+		return nil, err
+	}
+
+	evaluations, err := s.repo.ListEvaluationsByHypothesis(ctx, hypothesisID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(evaluations, func(i, j int) bool {
+		return evaluations[i].EvaluatedAt.Before(evaluations[j].EvaluatedAt)
+	})
+
+	return evaluations, nil
+}
+
+func (s *ServiceImpl) EvaluateHypothesis(ctx context.Context, id uuid.UUID, now time.Time) (*Evaluation, error) {
+	hypothesis, err := s.repo.GetHypothesisByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation, _, err := s.evaluate(ctx, hypothesis, now)
+	return evaluation, err
+}
+
+func (s *ServiceImpl) EvaluateHypotheses(ctx context.Context, now time.Time) ([]*Evaluation, error) {
+	hypotheses, err := s.repo.ListHypotheses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := []*Evaluation{}
+	for _, hypothesis := range hypotheses {
+		evaluation, isNew, err := s.evaluate(ctx, hypothesis, now)
+		if err != nil {
+			log.Printf("failed to evaluate hypothesis %s: %v", hypothesis.ID, err)
+			continue
+		}
+		if isNew {
+			recorded = append(recorded, evaluation)
+		}
+	}
+
+	return recorded, nil
+}
+
+// evaluate estimates the effect from the data as of now. It records a new
+// history entry, and reports true, only when the estimate differs from the
+// latest one.
+func (s *ServiceImpl) evaluate(
+	ctx context.Context,
+	hypothesis *Hypothesis,
+	now time.Time,
+) (*Evaluation, bool, error) {
+	owner, err := s.userService.GetUserByID(ctx, hypothesis.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	cause, err := s.dailyPoints(ctx, hypothesis, hypothesis.CauseParameterID, owner.Location(), now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	effect, err := s.dailyPoints(ctx, hypothesis, hypothesis.EffectParameterID, owner.Location(), now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	xs, ys := pairDays(cause, effect, hypothesis.MinLagDays, hypothesis.MaxLagDays)
+	result := correlate(xs, ys, Level)
+
+	history, err := s.ListEvaluations(ctx, hypothesis.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var previous *Evaluation
+	if len(history) > 0 {
+		previous = history[len(history)-1]
+		if previous.Days == result.days && math.Abs(previous.Effect-result.effect) < estimateTolerance {
+			return previous, false, nil
+		}
+	}
+
+	evaluation := &Evaluation{
+		ID:           uuid.New(),
+		HypothesisID: hypothesis.ID,
+		UserID:       hypothesis.UserID,
+		EvaluatedAt:  now,
+		Days:         result.days,
+		Effect:       result.effect,
+		Lower:        result.lower,
+		Upper:        result.upper,
+		PValue:       result.pValue,
+		Verdict:      result.verdict(hypothesis.ExpectedDirection),
+	}
+	evaluation.Changed = previous == nil || previous.Verdict != evaluation.Verdict
+
+	if _, err := s.repo.CreateEvaluation(ctx, evaluation); err != nil {
+		return nil, false, err
+	}
+
+	// Work on a copy so that readers of the stored hypothesis never see a
+	// half-updated value.
+	updated := *hypothesis
+	updated.Verdict = evaluation.Verdict
+	updated.LastEvaluatedAt = &now
+	updated.UpdatedAt = time.Now()
+	if _, err := s.repo.UpdateHypothesis(ctx, &updated); err != nil {
+		return nil, false, err
+	}
+
+	return evaluation, true, nil
+}
+
+// dailyPoints returns the observed days of a parameter up to now.
+func (s *ServiceImpl) dailyPoints(
+	ctx context.Context,
+	hypothesis *Hypothesis,
+	parameterID uuid.UUID,
+	loc *time.Location,
+	now time.Time,
+) ([]series.Point, error) {
+	dailySeries, err := s.seriesService.GetDailySeries(ctx, series.GetDailySeriesInput{
+		ParameterID: parameterID,
+		Aggregation: hypothesis.Aggregation,
+		Gaps:        series.GapOptions{Strategy: series.GapStrategyDrop},
+		Location:    loc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	points := []series.Point{}
+	for _, p := range dailySeries.Points {
+		if !p.Date.After(now) {
+			points = append(points, p)
+		}
+	}
+
+	return points, nil
+}
@@ -0,0 +1,306 @@
+package hypothesis_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/hypothesis"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// logSteps logs steps for the days and mood driven by the previous day's
+// steps with the given sign.
+func logSteps(
+	t *testing.T,
+	measurementService measurement.Service,
+	steps, mood *parameter.Parameter,
+	from, to int,
+	sign float64,
+	seed int64,
+) {
+	t.Helper()
+
+	rng := rand.New(rand.NewSource(seed))
+	previous := 0.0
+	for day := from; day < to; day++ {
+		value := 8000 + 3000*rng.NormFloat64()
+		domaintest.LogValue(t, measurementService, steps.ID, start.AddDate(0, 0, day), value)
+		if day > from {
+			moodValue := 5 + sign*(previous-8000)/2000 + 0.5*rng.NormFloat64()
+			domaintest.LogValue(t, measurementService, mood.ID, start.AddDate(0, 0, day), moodValue)
+		}
+		previous = value
+	}
+}
+
+func TestCreateHypothesis_Validation(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	hypothesisService := hypothesis.NewService(
+		hypothesis.NewInMemoryRepository(), userService, parameterService, seriesService,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	steps := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	other, err := userService.CreateUser(context.Background(), user.CreateUserInput{
+		ExternalID: "0d6c3f6e-8f2d-4c51-9d0e-3c1b7b7a5e11",
+		FirstName:  "Jane",
+		LastName:   "Doe",
+	})
+	require.NoError(t, err)
+	foreign := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   other.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	tests := []struct {
+		name  string
+		input hypothesis.CreateHypothesisInput
+		err   error
+	}{
+		{
+			name: "same parameter",
+			input: hypothesis.CreateHypothesisInput{
+				CauseParameterID: steps.ID, EffectParameterID: steps.ID, ExpectedDirection: hypothesis.DirectionPositive,
+			},
+			err: hypothesis.ErrInvalidParameters,
+		},
+		{
+			name: "another user's parameter",
+			input: hypothesis.CreateHypothesisInput{
+				CauseParameterID: steps.ID, EffectParameterID: foreign.ID, ExpectedDirection: hypothesis.DirectionPositive,
+			},
+			err: hypothesis.ErrInvalidParameters,
+		},
+		{
+			name: "unknown direction",
+			input: hypothesis.CreateHypothesisInput{
+				CauseParameterID: steps.ID, EffectParameterID: mood.ID, ExpectedDirection: "up",
+			},
+			err: hypothesis.ErrInvalidDirection,
+		},
+		{
+			name: "lag range reversed",
+			input: hypothesis.CreateHypothesisInput{
+				CauseParameterID: steps.ID, EffectParameterID: mood.ID, ExpectedDirection: hypothesis.DirectionPositive,
+				MinLagDays: 2, MaxLagDays: 1,
+			},
+			err: hypothesis.ErrInvalidLag,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := hypothesisService.CreateHypothesis(context.Background(), tt.input)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestEvaluateHypothesis_History(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	hypothesisService := hypothesis.NewService(
+		hypothesis.NewInMemoryRepository(), userService, parameterService, seriesService,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	steps := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	created, err := hypothesisService.CreateHypothesis(context.Background(), hypothesis.CreateHypothesisInput{
+		CauseParameterID:  steps.ID,
+		EffectParameterID: mood.ID,
+		Statement:         "More steps, better mood",
+		ExpectedDirection: hypothesis.DirectionPositive,
+		MinLagDays:        0,
+		MaxLagDays:        1,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, created.Verdict)
+
+	logSteps(t, measurementService, steps, mood, 0, 60, 1, 1)
+
+	// Early on there are too few paired days.
+	early, err := hypothesisService.EvaluateHypothesis(context.Background(), created.ID, start.AddDate(0, 0, 5))
+	require.NoError(t, err)
+	assert.Equal(t, hypothesis.VerdictInsufficientData, early.Verdict)
+	assert.True(t, early.Changed)
+
+	later, err := hypothesisService.EvaluateHypothesis(context.Background(), created.ID, start.AddDate(0, 0, 60))
+	require.NoError(t, err)
+	assert.Equal(t, hypothesis.VerdictSupported, later.Verdict)
+	assert.True(t, later.Changed)
+	assert.Equal(t, 59, later.Days)
+	assert.Greater(t, later.Effect, 0.3)
+	assert.Less(t, later.Lower, later.Effect)
+	assert.Greater(t, later.Upper, later.Effect)
+	assert.Less(t, later.PValue, 0.01)
+
+	// Nothing new was logged, so no entry is added.
+	again, err := hypothesisService.EvaluateHypothesis(context.Background(), created.ID, start.AddDate(0, 0, 61))
+	require.NoError(t, err)
+	assert.Equal(t, later.ID, again.ID)
+
+	history, err := hypothesisService.ListEvaluations(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, early.ID, history[0].ID)
+
+	stored, err := hypothesisService.GetHypothesisByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hypothesis.VerdictSupported, stored.Verdict)
+	assert.Equal(t, start.AddDate(0, 0, 60), *stored.LastEvaluatedAt)
+}
+
+func TestEvaluateHypotheses_EvidenceFallsApart(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	hypothesisService := hypothesis.NewService(
+		hypothesis.NewInMemoryRepository(), userService, parameterService, seriesService,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	steps := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+
+	created, err := hypothesisService.CreateHypothesis(context.Background(), hypothesis.CreateHypothesisInput{
+		CauseParameterID:  steps.ID,
+		EffectParameterID: mood.ID,
+		ExpectedDirection: hypothesis.DirectionPositive,
+		MinLagDays:        1,
+		MaxLagDays:        1,
+	})
+	require.NoError(t, err)
+
+	logSteps(t, measurementService, steps, mood, 0, 30, 1, 2)
+
+	recorded, err := hypothesisService.EvaluateHypotheses(context.Background(), start.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, hypothesis.VerdictSupported, recorded[0].Verdict)
+
+	recorded, err = hypothesisService.EvaluateHypotheses(context.Background(), start.AddDate(0, 0, 31))
+	require.NoError(t, err)
+	assert.Empty(t, recorded)
+
+	// A long stretch with the opposite relationship overturns the evidence.
+	logSteps(t, measurementService, steps, mood, 30, 150, -1, 3)
+
+	recorded, err = hypothesisService.EvaluateHypotheses(context.Background(), start.AddDate(0, 0, 150))
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, hypothesis.VerdictContradicted, recorded[0].Verdict)
+	assert.True(t, recorded[0].Changed)
+
+	history, err := hypothesisService.ListEvaluations(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+}
+
+func TestEvaluateHypotheses_SkipsDeletedParameter(t *testing.T) {
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	hypothesisService := hypothesis.NewService(
+		hypothesis.NewInMemoryRepository(), userService, parameterService, seriesService,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	steps := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	sleep := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	for _, cause := range []*parameter.Parameter{sleep, steps} {
+		_, err := hypothesisService.CreateHypothesis(ctx, hypothesis.CreateHypothesisInput{
+			CauseParameterID:  cause.ID,
+			EffectParameterID: mood.ID,
+			ExpectedDirection: hypothesis.DirectionPositive,
+			MinLagDays:        1,
+			MaxLagDays:        1,
+		})
+		require.NoError(t, err)
+	}
+
+	logSteps(t, measurementService, steps, mood, 0, 30, 1, 2)
+	require.NoError(t, parameterService.DeleteParameter(ctx, sleep.ID, ""))
+
+	recorded, err := hypothesisService.EvaluateHypotheses(ctx, start.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	require.Len(t, recorded, 1)
+	assert.Equal(t, hypothesis.VerdictSupported, recorded[0].Verdict)
+}
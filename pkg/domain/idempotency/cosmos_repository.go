@@ -0,0 +1,166 @@
+package idempotency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/internal/cosmosutil"
+)
+
+const (
+	databaseName  = "correlateapp"
+	containerName = "IdempotencyKeys"
+	partitionKey  = "/id"
+)
+
+// CosmosIdempotencyRepository stores records with a per-item ttl, so Cosmos
+// DB removes them once they expire. The container needs time to live turned
+// on, with no default expiry.
+type CosmosIdempotencyRepository struct {
+	client    *azcosmos.Client
+	container *azcosmos.ContainerClient
+}
+
+func NewCosmosIdempotencyRepository(connectionString string) (*CosmosIdempotencyRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for idempotency repository: %w", err)
+	}
+
+	container, err := client.NewContainer(databaseName, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for idempotency repository: %w", err)
+	}
+
+	return &CosmosIdempotencyRepository{
+		client:    client,
+		container: container,
+	}, nil
+}
+
+func (r *CosmosIdempotencyRepository) CreateRecord(ctx context.Context, record *Record) (*Record, error) {
+	recordJSON, err := json.Marshal(NewCosmosRecord(record))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(record.ID)
+
+	_, err = r.container.CreateItem(ctx, pk, recordJSON, nil)
+	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusConflict) {
+			return nil, ErrRecordExists
+		}
+		return nil, fmt.Errorf("failed to create idempotency record in Cosmos DB: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *CosmosIdempotencyRepository) GetRecordByID(ctx context.Context, id string) (*Record, error) {
+	pk := azcosmos.NewPartitionKeyString(id)
+
+	resp, err := r.container.ReadItem(ctx, pk, id, nil)
+	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to read idempotency record from Cosmos DB: %w", err)
+	}
+
+	var cosmosRecord CosmosRecord
+	if err := json.Unmarshal(resp.Value, &cosmosRecord); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+
+	return NewRecord(&cosmosRecord), nil
+}
+
+func (r *CosmosIdempotencyRepository) UpdateRecord(ctx context.Context, record *Record) (*Record, error) {
+	recordJSON, err := json.Marshal(NewCosmosRecord(record))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(record.ID)
+
+	_, err = r.container.ReplaceItem(ctx, pk, record.ID, recordJSON, nil)
+	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, fmt.Errorf("failed to update idempotency record in Cosmos DB: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *CosmosIdempotencyRepository) DeleteRecord(ctx context.Context, id string) error {
+	pk := azcosmos.NewPartitionKeyString(id)
+
+	_, err := r.container.DeleteItem(ctx, pk, id, nil)
+	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return ErrRecordNotFound
+		}
+		return fmt.Errorf("failed to delete idempotency record from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+type CosmosRecord struct {
+	ID          string    `json:"id"`
+	Fingerprint string    `json:"fingerprint"`
+	Status      Status    `json:"status"`
+	StatusCode  int       `json:"statusCode,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	ETag        string    `json:"etag,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	ExpiresAt   time.Time `json:"expiresAt"`
+	// TTL is the number of seconds Cosmos DB keeps the item after its last
+	// write.
+	TTL int `json:"ttl"`
+}
+
+func NewCosmosRecord(record *Record) *CosmosRecord {
+	// The ttl runs from the record's last update, as told by the service's
+	// clock.
+	ttl := int(math.Ceil(record.ExpiresAt.Sub(record.UpdatedAt).Seconds()))
+
+	return &CosmosRecord{
+		ID:          record.ID,
+		Fingerprint: record.Fingerprint,
+		Status:      record.Status,
+		StatusCode:  record.StatusCode,
+		ContentType: record.ContentType,
+		ETag:        record.ETag,
+		Body:        record.Body,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+		ExpiresAt:   record.ExpiresAt,
+		TTL:         max(ttl, 1),
+	}
+}
+
+func NewRecord(cosmosRecord *CosmosRecord) *Record {
+	return &Record{
+		ID:          cosmosRecord.ID,
+		Fingerprint: cosmosRecord.Fingerprint,
+		Status:      cosmosRecord.Status,
+		StatusCode:  cosmosRecord.StatusCode,
+		ContentType: cosmosRecord.ContentType,
+		ETag:        cosmosRecord.ETag,
+		Body:        cosmosRecord.Body,
+		CreatedAt:   cosmosRecord.CreatedAt,
+		UpdatedAt:   cosmosRecord.UpdatedAt,
+		ExpiresAt:   cosmosRecord.ExpiresAt,
+	}
+}
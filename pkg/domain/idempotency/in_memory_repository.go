@@ -0,0 +1,67 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+)
+
+type InMemoryRepository struct {
+	mu      sync.RWMutex
+	records map[string]*Record
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		records: make(map[string]*Record),
+	}
+}
+
+func (r *InMemoryRepository) CreateRecord(_ context.Context, record *Record) (*Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[record.ID]; ok {
+		return nil, ErrRecordExists
+	}
+	r.records[record.ID] = record
+
+	return record, nil
+}
+
+func (r *InMemoryRepository) GetRecordByID(_ context.Context, id string) (*Record, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, ok := r.records[id]
+	if !ok {
+		return nil, ErrRecordNotFound
+	}
+
+	copied := *record
+
+	return &copied, nil
+}
+
+func (r *InMemoryRepository) UpdateRecord(_ context.Context, record *Record) (*Record, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[record.ID]; !ok {
+		return nil, ErrRecordNotFound
+	}
+	r.records[record.ID] = record
+
+	return record, nil
+}
+
+func (r *InMemoryRepository) DeleteRecord(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.records[id]; !ok {
+		return ErrRecordNotFound
+	}
+	delete(r.records, id)
+
+	return nil
+}
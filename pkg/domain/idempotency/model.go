@@ -0,0 +1,35 @@
+package idempotency
+
+import "time"
+
+type Status string
+
+const (
+	// StatusInProgress marks a key whose first request is still being handled.
+	StatusInProgress Status = "inProgress"
+	// StatusCompleted marks a key whose response is stored for replay.
+	StatusCompleted Status = "completed"
+)
+
+// Record is what is remembered about an idempotency key: the request that
+// first used it and, once that request finished, its response.
+type Record struct {
+	// ID identifies the key within the route it was sent to.
+	ID string
+	// Fingerprint is a hash of the request body, so that a key reused for a
+	// different request is caught.
+	Fingerprint string
+	Status      Status
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// ExpiresAt is when the key may be used afresh.
+	ExpiresAt time.Time
+}
+
+func (r *Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
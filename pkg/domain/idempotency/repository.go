@@ -0,0 +1,20 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrRecordNotFound = errors.New("idempotency record not found")
+	ErrRecordExists   = errors.New("idempotency record already exists")
+)
+
+type Repository interface {
+	// CreateRecord stores the record, failing with ErrRecordExists when a
+	// record with its ID is already stored.
+	CreateRecord(ctx context.Context, record *Record) (*Record, error)
+	GetRecordByID(ctx context.Context, id string) (*Record, error)
+	UpdateRecord(ctx context.Context, record *Record) (*Record, error)
+	DeleteRecord(ctx context.Context, id string) error
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import "context"
+
+type Service interface {
+	// Begin claims the key for a request. A new key returns an in-progress
+	// record and the request should be handled. A key completed by the same
+	// request returns the completed record, whose response should be replayed.
+	Begin(ctx context.Context, input BeginInput) (*Record, error)
+	// Complete stores the response of the request that claimed the key.
+	Complete(ctx context.Context, input CompleteInput) (*Record, error)
+	// Release gives up a claimed key, so that a retry handles the request
+	// again. It is meant for requests that failed in a way worth retrying.
+	Release(ctx context.Context, scope, key string) error
+}
+
+type BeginInput struct {
+	// Scope separates keys sent to different routes, such as
+	// "POST /api/users".
+	Scope string
+	Key   string
+	// Request is the request body.
+	Request []byte
+}
+
+type CompleteInput struct {
+	Scope       string
+	Key         string
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+}
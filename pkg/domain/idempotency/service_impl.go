@@ -0,0 +1,135 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/clock"
+)
+
+const (
+	// DefaultTTL is how long a completed response is kept for replay.
+	DefaultTTL   = 24 * time.Hour
+	MaxKeyLength = 255
+	// claimTimeout bounds how long a key stays claimed by a request that
+	// never completes, such as one cut off by a restart.
+	claimTimeout = time.Minute
+)
+
+var (
+	ErrInvalidKey    = errors.New("idempotency key must be between 1 and 255 characters")
+	ErrKeyInProgress = errors.New("a request with this idempotency key is still in progress")
+	ErrKeyReused     = errors.New("idempotency key was already used for a different request")
+)
+
+type ServiceImpl struct {
+	repo  Repository
+	ttl   time.Duration
+	clock clock.Clock
+}
+
+func NewService(repo Repository, ttl time.Duration, clock clock.Clock) Service {
+	return &ServiceImpl{
+		repo:  repo,
+		ttl:   ttl,
+		clock: clock,
+	}
+}
+
+func (s *ServiceImpl) Begin(ctx context.Context, input BeginInput) (*Record, error) {
+	if len(input.Key) == 0 || len(input.Key) > MaxKeyLength {
+		return nil, ErrInvalidKey
+	}
+
+	id := recordID(input.Scope, input.Key)
+	fingerprint := hash(input.Request)
+	now := s.clock.Now()
+
+	existing, err := s.repo.GetRecordByID(ctx, id)
+	switch {
+	case errors.Is(err, ErrRecordNotFound):
+	case err != nil:
+		return nil, err
+	case !existing.Expired(now):
+		return resume(existing, fingerprint)
+	default:
+		// The store removes expired records in the background, so one may
+		// still be around.
+		if err := s.repo.DeleteRecord(ctx, id); err != nil && !errors.Is(err, ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	record, err := s.repo.CreateRecord(ctx, &Record{
+		ID:          id,
+		Fingerprint: fingerprint,
+		Status:      StatusInProgress,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(claimTimeout),
+	})
+	if errors.Is(err, ErrRecordExists) {
+		// Another request with the key claimed it since it was read.
+		return nil, ErrKeyInProgress
+	}
+
+	return record, err
+}
+
+func (s *ServiceImpl) Complete(ctx context.Context, input CompleteInput) (*Record, error) {
+	record, err := s.repo.GetRecordByID(ctx, recordID(input.Scope, input.Key))
+	if err != nil {
+		return nil, err
+	}
+
+	record.Status = StatusCompleted
+	record.StatusCode = input.StatusCode
+	record.ContentType = input.ContentType
+	record.ETag = input.ETag
+	record.Body = input.Body
+	record.UpdatedAt = s.clock.Now()
+	record.ExpiresAt = record.UpdatedAt.Add(s.ttl)
+
+	return s.repo.UpdateRecord(ctx, record)
+}
+
+func (s *ServiceImpl) Release(ctx context.Context, scope, key string) error {
+	err := s.repo.DeleteRecord(ctx, recordID(scope, key))
+	if errors.Is(err, ErrRecordNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// resume decides what a request carrying an already claimed key gets.
+func resume(record *Record, fingerprint string) (*Record, error) {
+	if record.Fingerprint != fingerprint {
+		return nil, ErrKeyReused
+	}
+
+	switch record.Status {
+	case StatusCompleted:
+		return record, nil
+	case StatusInProgress:
+		return nil, ErrKeyInProgress
+	default:
+		return nil, fmt.Errorf("unknown idempotency record status: %s", record.Status)
+	}
+}
+
+// recordID hashes the scope and key, which keeps client-chosen keys out of
+// document IDs, where characters such as "/" are not allowed.
+func recordID(scope, key string) string {
+	return hash([]byte(scope + "\n" + key))
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
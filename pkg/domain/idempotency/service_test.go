@@ -0,0 +1,127 @@
+package idempotency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newService() (idempotency.Service, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+
+	return idempotency.NewService(idempotency.NewInMemoryRepository(), time.Hour, clock), clock
+}
+
+func TestBegin_ReplaysCompletedResponse(t *testing.T) {
+	ctx := context.Background()
+	idempotencyService, _ := newService()
+
+	input := idempotency.BeginInput{Scope: "POST /api/measurements", Key: "abc", Request: []byte(`{"value":1}`)}
+
+	record, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusInProgress, record.Status)
+
+	_, err = idempotencyService.Begin(ctx, input)
+	require.ErrorIs(t, err, idempotency.ErrKeyInProgress)
+
+	_, err = idempotencyService.Complete(ctx, idempotency.CompleteInput{
+		Scope:       input.Scope,
+		Key:         input.Key,
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"1"}`),
+	})
+	require.NoError(t, err)
+
+	replayed, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusCompleted, replayed.Status)
+	assert.Equal(t, 201, replayed.StatusCode)
+	assert.Equal(t, "application/json", replayed.ContentType)
+	assert.JSONEq(t, `{"id":"1"}`, string(replayed.Body))
+
+	other, err := idempotencyService.Begin(ctx, idempotency.BeginInput{
+		Scope:   "POST /api/parameters",
+		Key:     input.Key,
+		Request: input.Request,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusInProgress, other.Status, "keys are scoped to their route")
+}
+
+func TestBegin_RejectsReusedKey(t *testing.T) {
+	ctx := context.Background()
+	idempotencyService, _ := newService()
+
+	input := idempotency.BeginInput{Scope: "POST /api/users", Key: "abc", Request: []byte(`{"firstName":"A"}`)}
+	_, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+
+	input.Request = []byte(`{"firstName":"B"}`)
+	_, err = idempotencyService.Begin(ctx, input)
+	require.ErrorIs(t, err, idempotency.ErrKeyReused)
+
+	_, err = idempotencyService.Begin(ctx, idempotency.BeginInput{Scope: input.Scope})
+	require.ErrorIs(t, err, idempotency.ErrInvalidKey)
+}
+
+func TestBegin_Expiry(t *testing.T) {
+	ctx := context.Background()
+	idempotencyService, clock := newService()
+
+	input := idempotency.BeginInput{Scope: "POST /api/measurements", Key: "abc", Request: []byte(`{}`)}
+	_, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+
+	// A claim whose request never completed lapses after a short while.
+	clock.now = clock.now.Add(2 * time.Minute)
+	_, err = idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+
+	completed, err := idempotencyService.Complete(ctx, idempotency.CompleteInput{
+		Scope:      input.Scope,
+		Key:        input.Key,
+		StatusCode: 201,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3600, idempotency.NewCosmosRecord(completed).TTL, "the ttl follows the service's clock")
+
+	clock.now = clock.now.Add(59 * time.Minute)
+	replayed, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusCompleted, replayed.Status)
+
+	clock.now = clock.now.Add(time.Minute)
+	fresh, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusInProgress, fresh.Status)
+}
+
+func TestRelease(t *testing.T) {
+	ctx := context.Background()
+	idempotencyService, _ := newService()
+
+	input := idempotency.BeginInput{Scope: "POST /api/measurements", Key: "abc", Request: []byte(`{}`)}
+	_, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+
+	require.NoError(t, idempotencyService.Release(ctx, input.Scope, input.Key))
+	require.NoError(t, idempotencyService.Release(ctx, input.Scope, input.Key))
+
+	record, err := idempotencyService.Begin(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, idempotency.StatusInProgress, record.Status)
+}
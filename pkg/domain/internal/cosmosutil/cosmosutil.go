@@ -0,0 +1,28 @@
+// Package cosmosutil holds the Cosmos DB helpers the domain repositories share.
+package cosmosutil
+
+import (
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+)
+
+// IfMatchOptions makes a write conditional on the item still having the
+// ETag. An empty ETag writes unconditionally.
+func IfMatchOptions(etag string) *azcosmos.ItemOptions {
+	if etag == "" {
+		return nil
+	}
+
+	ifMatch := azcore.ETag(etag)
+
+	return &azcosmos.ItemOptions{IfMatchEtag: &ifMatch}
+}
+
+// HasStatus reports whether Cosmos DB answered the request with the status.
+func HasStatus(err error, statusCode int) bool {
+	var responseErr *azcore.ResponseError
+
+	return errors.As(err, &responseErr) && responseErr.StatusCode == statusCode
+}
@@ -5,9 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/internal/cosmosutil"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
@@ -15,8 +21,13 @@ const (
 	databaseName  = "correlateapp"
 	containerName = "Measurements"
 	partitionKey  = "/parameterId"
+	// maxBatchOperations is the Cosmos DB limit on operations in one
+	// transactional batch.
+	maxBatchOperations = 100
 )
 
+var ErrBatchRolledBack = errors.New("not created because another measurement in its batch failed")
+
 type CosmosMeasurementRepository struct {
 	client    *azcosmos.Client
 	container *azcosmos.ContainerClient
@@ -58,18 +69,198 @@ func (r *CosmosMeasurementRepository) CreateMeasurement(
 	return measurement, nil
 }
 
+// CreateMeasurements writes the measurements in transactional batches, one
+// per parameter partition and at most maxBatchOperations at a time. A batch
+// commits or fails as a whole, so when one item fails every other item of its
+// batch fails with ErrBatchRolledBack.
+func (r *CosmosMeasurementRepository) CreateMeasurements(ctx context.Context, measurements []Measurement) []error {
+	errs := make([]error, len(measurements))
+
+	var parameterIDs []uuid.UUID
+	partitions := make(map[uuid.UUID][]int)
+	for i, measurement := range measurements {
+		parameterID := measurement.GetParameterID()
+		if _, ok := partitions[parameterID]; !ok {
+			parameterIDs = append(parameterIDs, parameterID)
+		}
+		partitions[parameterID] = append(partitions[parameterID], i)
+	}
+
+	for _, parameterID := range parameterIDs {
+		indexes := partitions[parameterID]
+		for start := 0; start < len(indexes); start += maxBatchOperations {
+			end := min(start+maxBatchOperations, len(indexes))
+			r.executeBatch(ctx, parameterID, measurements, indexes[start:end], errs)
+		}
+	}
+
+	return errs
+}
+
+// executeBatch writes the measurements at the indexes in one transactional
+// batch and records the outcome of each in errs.
+func (r *CosmosMeasurementRepository) executeBatch(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	measurements []Measurement,
+	indexes []int,
+	errs []error,
+) {
+	batch := r.container.NewTransactionalBatch(azcosmos.NewPartitionKeyString(parameterID.String()))
+	// batched holds the indexes that made it into the batch, in operation
+	// order, since items that fail to marshal are left out.
+	batched := make([]int, 0, len(indexes))
+	for _, i := range indexes {
+		measurementJSON, err := json.Marshal(NewCosmosMeasurement(measurements[i]))
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to marshal measurement: %w", err)
+			continue
+		}
+		batch.CreateItem(measurementJSON, nil)
+		batched = append(batched, i)
+	}
+	if len(batched) == 0 {
+		return
+	}
+
+	resp, err := r.container.ExecuteTransactionalBatch(ctx, batch, nil)
+	if err != nil {
+		for _, i := range batched {
+			errs[i] = fmt.Errorf("failed to create measurement in Cosmos DB: %w", err)
+		}
+		return
+	}
+	if resp.Success {
+		return
+	}
+
+	for j, i := range batched {
+		if j < len(resp.OperationResults) && resp.OperationResults[j].StatusCode != http.StatusFailedDependency {
+			errs[i] = fmt.Errorf(
+				"failed to create measurement in Cosmos DB: status %d",
+				resp.OperationResults[j].StatusCode,
+			)
+			continue
+		}
+		errs[i] = ErrBatchRolledBack
+	}
+}
+
 func (r *CosmosMeasurementRepository) ListMeasurementsByUser(
 	ctx context.Context,
 	userID uuid.UUID,
-) ([]Measurement, error) {
-	query := "SELECT * FROM measurements m WHERE m.userId = @userID"
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	where := " WHERE m.userId = @userID"
 	params := []azcosmos.QueryParameter{
 		{Name: "@userID", Value: userID.String()},
 	}
+	where, params = withFilter(where, params, options.Filter)
 
-	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	if options.Sort == (Sort{}) {
+		query := selectMeasurements(options) + where
+		return r.queryMeasurements(ctx, azcosmos.NewPartitionKey(), query, params, options, page)
+	}
+
+	return r.listSorted(ctx, userID, where, params, options, page)
+}
+
+// listSorted merges the sorted listings of each of the user's parameter
+// partitions, because the SDK cannot order a query across partitions. Each
+// partition is read up to the end of the page and the cursor is an offset.
+func (r *CosmosMeasurementRepository) listSorted(
+	ctx context.Context,
+	userID uuid.UUID,
+	where string,
+	params []azcosmos.QueryParameter,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	offset, err := page.Offset()
+	if err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+	// One item beyond the page tells whether another page follows.
+	want := 0
+	if page.Limit > 0 {
+		want = offset + page.Limit + 1
+	}
+
+	parameterIDs, err := r.parameterIDsOf(ctx, userID)
+	if err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+
+	// The merge compares the sortable fields, so they are loaded whatever the
+	// projection and left out again afterwards.
+	merging := options
+	if len(merging.Fields) > 0 {
+		merging.Fields = append(slices.Clone(merging.Fields), FieldTimestamp, FieldValue, FieldCreatedAt)
+	}
+	query := selectMeasurements(merging) + where + orderMeasurements(options.Sort)
+
+	merged := []Measurement{}
+	for _, parameterID := range parameterIDs {
+		pk := azcosmos.NewPartitionKeyString(parameterID)
+		partition, readErr := r.readMeasurements(ctx, pk, query, params, merging, want)
+		if readErr != nil {
+			return pagination.Result[Measurement]{}, readErr
+		}
+		merged = append(merged, partition...)
+	}
+
+	slices.SortFunc(merged, func(a, b Measurement) int {
+		return compareMeasurements(a, b, options.Sort)
+	})
+	for i, measurement := range merged {
+		merged[i] = options.project(measurement)
+	}
+
+	return pagination.Slice(merged, page)
+}
+
+// parameterIDsOf returns the parameters the user has measurements of, which
+// are the partitions holding them.
+func (r *CosmosMeasurementRepository) parameterIDsOf(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	query := "SELECT DISTINCT VALUE m.parameterId FROM measurements m WHERE m.userId = @userID"
+	queryOptions := &azcosmos.QueryOptions{
+		QueryParameters: []azcosmos.QueryParameter{{Name: "@userID", Value: userID.String()}},
+	}
 	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
 
+	parameterIDs := []string{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var parameterID string
+			if err := json.Unmarshal(item, &parameterID); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal parameter ID: %w", err)
+			}
+			parameterIDs = append(parameterIDs, parameterID)
+		}
+	}
+
+	return parameterIDs, nil
+}
+
+// readMeasurements runs a query and returns the first limit measurements that
+// match the options, or all of them when limit is zero.
+func (r *CosmosMeasurementRepository) readMeasurements(
+	ctx context.Context,
+	pk azcosmos.PartitionKey,
+	query string,
+	params []azcosmos.QueryParameter,
+	options ListOptions,
+	limit int,
+) ([]Measurement, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.container.NewQueryItemsPager(query, pk, queryOptions)
+
 	measurements := []Measurement{}
 	for pager.More() {
 		resp, nextPageErr := pager.NextPage(ctx)
@@ -78,11 +269,17 @@ func (r *CosmosMeasurementRepository) ListMeasurementsByUser(
 		}
 
 		for _, item := range resp.Items {
-			var cosmosMeasurement CosmosMeasurement
-			if err := json.Unmarshal(item, &cosmosMeasurement); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal measurement: %w", err)
+			measurement, err := decodeMeasurement(item, options)
+			if err != nil {
+				return nil, err
+			}
+			if !options.Matches(measurement) {
+				continue
+			}
+			measurements = append(measurements, measurement)
+			if len(measurements) == limit {
+				return measurements, nil
 			}
-			measurements = append(measurements, NewMeasurement(&cosmosMeasurement))
 		}
 	}
 
@@ -92,32 +289,71 @@ func (r *CosmosMeasurementRepository) ListMeasurementsByUser(
 func (r *CosmosMeasurementRepository) ListMeasurementsByParameter(
 	ctx context.Context,
 	parameterID uuid.UUID,
-) ([]Measurement, error) {
-	query := "SELECT * FROM measurements m WHERE m.parameterId = @parameterID"
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	query := selectMeasurements(options) + " WHERE m.parameterId = @parameterID"
 	params := []azcosmos.QueryParameter{
 		{Name: "@parameterID", Value: parameterID.String()},
 	}
+	query, params = withFilter(query, params, options.Filter)
+	query += orderMeasurements(options.Sort)
 
-	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
-	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+	pk := azcosmos.NewPartitionKeyString(parameterID.String())
 
-	measurements := []Measurement{}
+	return r.queryMeasurements(ctx, pk, query, params, options, page)
+}
+
+// queryMeasurements runs a listing query. A limited listing stops after one
+// Cosmos DB page and hands its continuation token back as the next cursor, so
+// a page may hold fewer items than the limit.
+func (r *CosmosMeasurementRepository) queryMeasurements(
+	ctx context.Context,
+	pk azcosmos.PartitionKey,
+	query string,
+	params []azcosmos.QueryParameter,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	queryOptions := &azcosmos.QueryOptions{
+		QueryParameters: params,
+		PageSizeHint:    page.SizeHint(),
+	}
+	if page.Cursor != "" {
+		token, err := pagination.DecodeCursor(page.Cursor)
+		if err != nil {
+			return pagination.Result[Measurement]{}, err
+		}
+		queryOptions.ContinuationToken = &token
+	}
+	pager := r.container.NewQueryItemsPager(query, pk, queryOptions)
+
+	result := pagination.Result[Measurement]{Items: []Measurement{}}
 	for pager.More() {
 		resp, nextPageErr := pager.NextPage(ctx)
 		if nextPageErr != nil {
-			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+			return pagination.Result[Measurement]{}, fmt.Errorf("query failed: %w", nextPageErr)
 		}
 
 		for _, item := range resp.Items {
-			var cosmosMeasurement CosmosMeasurement
-			if err := json.Unmarshal(item, &cosmosMeasurement); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal measurement: %w", err)
+			measurement, err := decodeMeasurement(item, options)
+			if err != nil {
+				return pagination.Result[Measurement]{}, err
+			}
+			if options.Matches(measurement) {
+				result.Items = append(result.Items, measurement)
 			}
-			measurements = append(measurements, NewMeasurement(&cosmosMeasurement))
+		}
+
+		if page.Limit > 0 {
+			if resp.ContinuationToken != nil {
+				result.Next = pagination.EncodeCursor(*resp.ContinuationToken)
+			}
+			break
 		}
 	}
 
-	return measurements, nil
+	return result, nil
 }
 
 func (r *CosmosMeasurementRepository) GetMeasurementByID(
@@ -169,6 +405,326 @@ func (r *CosmosMeasurementRepository) DeleteMeasurement(
 	return nil
 }
 
+// CountMeasurementsByParameter counts the matching measurements in the
+// parameter's partition without reading them.
+func (r *CosmosMeasurementRepository) CountMeasurementsByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	filter Filter,
+) (int, error) {
+	query := "SELECT VALUE COUNT(1) FROM measurements m WHERE m.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+	query, params = withFilter(query, params, filter)
+
+	pk := azcosmos.NewPartitionKeyString(parameterID.String())
+	pager := r.container.NewQueryItemsPager(query, pk, &azcosmos.QueryOptions{QueryParameters: params})
+
+	count := 0
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return 0, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var partial int
+			if err := json.Unmarshal(item, &partial); err != nil {
+				return 0, fmt.Errorf("failed to unmarshal measurement count: %w", err)
+			}
+			count += partial
+		}
+	}
+
+	return count, nil
+}
+
+// DeleteMeasurementsByParameter walks the parameter's partition one page at
+// a time and deletes each page in a transactional batch. Only the required
+// fields are read. An interrupted run leaves whole pages deleted, so
+// running it again picks up where it stopped.
+func (r *CosmosMeasurementRepository) DeleteMeasurementsByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	filter Filter,
+) (int, error) {
+	pk := azcosmos.NewPartitionKeyString(parameterID.String())
+
+	deleted := 0
+	page := pagination.Page{Limit: maxBatchOperations}
+	for {
+		matching, err := r.matchingByParameter(ctx, parameterID, filter, page)
+		if err != nil {
+			return deleted, err
+		}
+
+		count, err := r.deleteMeasurements(ctx, pk, matching.Items)
+		deleted += count
+		if err != nil {
+			return deleted, err
+		}
+
+		if matching.Next == "" {
+			return deleted, nil
+		}
+		page.Cursor = matching.Next
+	}
+}
+
+// matchingByParameter lists the parameter's measurements that match the
+// filter, loading only the required fields.
+func (r *CosmosMeasurementRepository) matchingByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	filter Filter,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	options := ListOptions{Filter: filter, Fields: []Field{FieldID}}
+
+	query := selectMeasurements(options) + " WHERE m.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+	query, params = withFilter(query, params, options.Filter)
+
+	pk := azcosmos.NewPartitionKeyString(parameterID.String())
+
+	return r.queryMeasurements(ctx, pk, query, params, options, page)
+}
+
+// deleteMeasurements deletes the measurements of one partition in a single
+// transactional batch. A measurement deleted since it was read fails the
+// whole batch, so on failure they are deleted one by one instead and those
+// already gone are skipped.
+func (r *CosmosMeasurementRepository) deleteMeasurements(
+	ctx context.Context,
+	pk azcosmos.PartitionKey,
+	measurements []Measurement,
+) (int, error) {
+	if len(measurements) == 0 {
+		return 0, nil
+	}
+
+	batch := r.container.NewTransactionalBatch(pk)
+	for _, measurement := range measurements {
+		batch.DeleteItem(measurement.GetID().String(), nil)
+	}
+	resp, err := r.container.ExecuteTransactionalBatch(ctx, batch, nil)
+	if err == nil && resp.Success {
+		return len(measurements), nil
+	}
+
+	deleted := 0
+	for _, measurement := range measurements {
+		_, err := r.container.DeleteItem(ctx, pk, measurement.GetID().String(), nil)
+		if err != nil {
+			if cosmosutil.HasStatus(err, http.StatusNotFound) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete measurement from Cosmos DB: %w", err)
+		}
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// BackfillEpochFields rewrites the measurements stored before the epoch fields
+// existed, which range filters and sorted listings rely on. It returns how many
+// measurements it rewrote.
+func (r *CosmosMeasurementRepository) BackfillEpochFields(ctx context.Context) (int, error) {
+	query := "SELECT * FROM measurements m WHERE NOT IS_DEFINED(m.timestampMicros) OR NOT IS_DEFINED(m.createdAtMicros)"
+	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), nil)
+
+	backfilled := 0
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return backfilled, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			measurement, err := decodeMeasurement(item, ListOptions{})
+			if err != nil {
+				return backfilled, err
+			}
+			if measurement == nil {
+				continue
+			}
+
+			measurementJSON, err := json.Marshal(NewCosmosMeasurement(measurement))
+			if err != nil {
+				return backfilled, fmt.Errorf("failed to marshal measurement: %w", err)
+			}
+			pk := azcosmos.NewPartitionKeyString(measurement.GetParameterID().String())
+			_, err = r.container.ReplaceItem(ctx, pk, measurement.GetID().String(), measurementJSON, nil)
+			if err != nil {
+				return backfilled, fmt.Errorf("failed to backfill measurement in Cosmos DB: %w", err)
+			}
+			backfilled++
+		}
+	}
+
+	return backfilled, nil
+}
+
+// selectMeasurements returns the SELECT clause of a listing. The field names
+// match the document properties, so projections are pushed down to Cosmos DB.
+func selectMeasurements(options ListOptions) string {
+	if len(options.Fields) == 0 {
+		return "SELECT * FROM measurements m"
+	}
+
+	columns := make([]string, 0, len(allFields))
+	for _, field := range allFields {
+		if options.loads(field) {
+			columns = append(columns, "m."+string(field))
+		}
+	}
+
+	return "SELECT " + strings.Join(columns, ", ") + " FROM measurements m"
+}
+
+// orderMeasurements returns the ORDER BY clause of a listing. Times are
+// ordered by their epoch fields, because the stored strings keep the offset
+// they were recorded with and do not sort as instants.
+func orderMeasurements(sort Sort) string {
+	if sort == (Sort{}) {
+		return ""
+	}
+
+	column := string(sort.Field)
+	switch sort.Field {
+	case SortByTimestamp:
+		column = "timestampMicros"
+	case SortByCreatedAt, "":
+		column = "createdAtMicros"
+	case SortByValue:
+	}
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+
+	return " ORDER BY m." + column + " " + direction
+}
+
+// decodeMeasurement decodes a listed document, which holds only the fields
+// the options load.
+func decodeMeasurement(item []byte, options ListOptions) (Measurement, error) {
+	var cosmosMeasurement CosmosMeasurement
+	if err := json.Unmarshal(item, &cosmosMeasurement); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal measurement: %w", err)
+	}
+	if !options.loads(FieldValue) {
+		cosmosMeasurement.Value = zeroValue(cosmosMeasurement.Type)
+	}
+
+	return NewMeasurement(&cosmosMeasurement), nil
+}
+
+// zeroValue stands in for a value left out by a projection.
+func zeroValue(dataType DataType) interface{} {
+	switch dataType {
+	case DataTypeFloat:
+		return 0.0
+	case DataTypeBoolean:
+		return false
+	default:
+		return nil
+	}
+}
+
+// withFilter narrows a query to the filter. Callers still check the filter
+// on the results, because epoch fields drop sub-microsecond precision.
+func withFilter(
+	query string,
+	params []azcosmos.QueryParameter,
+	filter Filter,
+) (string, []azcosmos.QueryParameter) {
+	query, params = withTimestampRange(query, params, filter)
+	if filter.Predicate == nil {
+		return query, params
+	}
+
+	predicate := filter.Predicate
+	var clauses []string
+	if len(predicate.converted) == 0 {
+		clauses, params = conditionsSQL(predicate.conditions, "@predicate", params)
+		return query + " AND " + strings.Join(clauses, " AND "), params
+	}
+
+	// Parameters stored in another unit than the operands are written in are
+	// matched against their own converted conditions.
+	ids := slices.SortedFunc(maps.Keys(predicate.converted), func(a, b uuid.UUID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	names := make([]string, len(ids))
+	groups := make([]string, 0, len(ids)+1)
+	for i, id := range ids {
+		names[i] = fmt.Sprintf("@converted%d", i)
+		params = append(params, azcosmos.QueryParameter{Name: names[i], Value: id.String()})
+		clauses, params = conditionsSQL(predicate.converted[id], names[i]+"_", params)
+		groups = append(groups, "(m.parameterId = "+names[i]+" AND "+strings.Join(clauses, " AND ")+")")
+	}
+	clauses, params = conditionsSQL(predicate.conditions, "@predicate", params)
+	groups = append(groups,
+		"(m.parameterId NOT IN ("+strings.Join(names, ", ")+") AND "+strings.Join(clauses, " AND ")+")")
+
+	return query + " AND (" + strings.Join(groups, " OR ") + ")", params
+}
+
+// conditionsSQL turns each condition into parameterized SQL; operands are
+// never spliced into the query text. Parameter names start with the prefix.
+func conditionsSQL(
+	conditions []condition,
+	prefix string,
+	params []azcosmos.QueryParameter,
+) ([]string, []azcosmos.QueryParameter) {
+	clauses := make([]string, 0, len(conditions))
+	for i, c := range conditions {
+		names := make([]string, len(c.operands))
+		for j, operand := range c.operands {
+			names[j] = fmt.Sprintf("%s%d_%d", prefix, i, j)
+			params = append(params, azcosmos.QueryParameter{Name: names[j], Value: operand})
+		}
+
+		column := "m." + string(c.field)
+		switch c.operator {
+		case operatorEqual, operatorNotEqual, operatorLess, operatorLessEqual, operatorGreater, operatorGreaterEqual:
+			clauses = append(clauses, column+" "+string(c.operator)+" "+names[0])
+		case operatorBetween:
+			clauses = append(clauses, column+" BETWEEN "+names[0]+" AND "+names[1])
+		case operatorIn:
+			clauses = append(clauses, column+" IN ("+strings.Join(names, ", ")+")")
+		case operatorContains:
+			clauses = append(clauses, "CONTAINS("+column+", "+names[0]+", true)")
+		}
+	}
+
+	return clauses, params
+}
+
+// withTimestampRange narrows a query to the filter's bounds. The bounds are
+// compared with the timestamp's epoch field, so they hold across offsets.
+func withTimestampRange(
+	query string,
+	params []azcosmos.QueryParameter,
+	filter Filter,
+) (string, []azcosmos.QueryParameter) {
+	if filter.From != nil {
+		query += " AND m.timestampMicros >= @from"
+		params = append(params, azcosmos.QueryParameter{Name: "@from", Value: filter.From.UnixMicro()})
+	}
+	if filter.To != nil {
+		query += " AND m.timestampMicros <= @to"
+		params = append(params, azcosmos.QueryParameter{Name: "@to", Value: filter.To.UnixMicro()})
+	}
+
+	return query, params
+}
+
 type CosmosMeasurement struct {
 	Type        DataType    `json:"type"`
 	ID          uuid.UUID   `json:"id"`
@@ -179,6 +735,11 @@ type CosmosMeasurement struct {
 	CreatedAt   time.Time   `json:"createdAt"`
 	UpdatedAt   time.Time   `json:"updatedAt"`
 	Value       interface{} `json:"value"`
+	// TimestampMicros and CreatedAtMicros hold the times as microseconds
+	// since the Unix epoch, which Cosmos DB orders and compares as instants.
+	// Older documents get them from BackfillEpochFields.
+	TimestampMicros int64 `json:"timestampMicros"`
+	CreatedAtMicros int64 `json:"createdAtMicros"`
 }
 
 func NewCosmosMeasurement(m Measurement) *CosmosMeasurement {
@@ -195,6 +756,27 @@ func NewCosmosMeasurement(m Measurement) *CosmosMeasurement {
 				CreatedAt:   m.GetCreatedAt(),
 				UpdatedAt:   m.GetUpdatedAt(),
 				Value:       floatMeas.Value,
+
+				TimestampMicros: m.GetTimestamp().UnixMicro(),
+				CreatedAtMicros: m.GetCreatedAt().UnixMicro(),
+			}
+		}
+		return nil
+	case DataTypeBoolean:
+		if booleanMeas, ok := m.(*BooleanMeasurement); ok {
+			return &CosmosMeasurement{
+				Type:        m.GetType(),
+				ID:          m.GetID(),
+				UserID:      m.GetUserID(),
+				ParameterID: m.GetParameterID(),
+				Timestamp:   m.GetTimestamp(),
+				Notes:       m.GetNotes(),
+				CreatedAt:   m.GetCreatedAt(),
+				UpdatedAt:   m.GetUpdatedAt(),
+				Value:       booleanMeas.Value,
+
+				TimestampMicros: m.GetTimestamp().UnixMicro(),
+				CreatedAtMicros: m.GetCreatedAt().UnixMicro(),
 			}
 		}
 		return nil
@@ -222,6 +804,23 @@ func NewMeasurement(m *CosmosMeasurement) Measurement {
 			}
 		}
 		return nil // Return nil if type assertion fails
+	case DataTypeBoolean:
+		if value, ok := m.Value.(bool); ok {
+			return &BooleanMeasurement{
+				BaseMeasurement: BaseMeasurement{
+					Type:        m.Type,
+					ID:          m.ID,
+					UserID:      m.UserID,
+					ParameterID: m.ParameterID,
+					Timestamp:   m.Timestamp,
+					Notes:       m.Notes,
+					CreatedAt:   m.CreatedAt,
+					UpdatedAt:   m.UpdatedAt,
+				},
+				Value: value,
+			}
+		}
+		return nil
 	default:
 		return nil
 	}
@@ -0,0 +1,39 @@
+package measurement_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosMeasurement_LegacyDocumentGetsEpochFields(t *testing.T) {
+	// Stored before the epoch fields existed, with the offset it was logged in.
+	legacy := []byte(`{
+		"type": "float",
+		"id": "0b6f4d4e-8d1b-4f5e-9d36-1f7e4bb5a001",
+		"userId": "0b6f4d4e-8d1b-4f5e-9d36-1f7e4bb5a002",
+		"parameterId": "0b6f4d4e-8d1b-4f5e-9d36-1f7e4bb5a003",
+		"timestamp": "2024-03-01T08:30:00+02:00",
+		"notes": "",
+		"createdAt": "2024-03-01T06:31:00.5Z",
+		"updatedAt": "2024-03-01T06:31:00.5Z",
+		"value": 72.4
+	}`)
+
+	var stored measurement.CosmosMeasurement
+	require.NoError(t, json.Unmarshal(legacy, &stored))
+	assert.Zero(t, stored.TimestampMicros)
+
+	m := measurement.NewMeasurement(&stored)
+	require.NotNil(t, m)
+
+	backfilled := measurement.NewCosmosMeasurement(m)
+	require.NotNil(t, backfilled)
+	assert.Equal(t, time.Date(2024, time.March, 1, 6, 30, 0, 0, time.UTC).UnixMicro(), backfilled.TimestampMicros)
+	assert.Equal(t, time.Date(2024, time.March, 1, 6, 31, 0, 500000000, time.UTC).UnixMicro(), backfilled.CreatedAtMicros)
+	assert.InDelta(t, 72.4, backfilled.Value, 0.0001)
+}
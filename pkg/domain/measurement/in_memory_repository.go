@@ -3,8 +3,10 @@ package measurement
 import (
 	"context"
 	"errors"
+	"slices"
 	"sync"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
@@ -33,35 +35,62 @@ func (repo *InMemoryRepository) CreateMeasurement(_ context.Context, measurement
 	return measurement, nil
 }
 
-func (repo *InMemoryRepository) ListMeasurementsByUser(_ context.Context, userID uuid.UUID) ([]Measurement, error) {
-	repo.mu.RLock()
-	defer repo.mu.RUnlock()
+func (repo *InMemoryRepository) CreateMeasurements(_ context.Context, measurements []Measurement) []error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
 
-	var results []Measurement
-	for _, measurement := range repo.measurements {
-		if measurement.GetUserID() == userID {
-			results = append(results, measurement)
-		}
+	for _, measurement := range measurements {
+		repo.measurements[measurement.GetID()] = measurement
 	}
 
-	return results, nil
+	return make([]error, len(measurements))
+}
+
+func (repo *InMemoryRepository) ListMeasurementsByUser(
+	_ context.Context,
+	userID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	return repo.list(func(m Measurement) bool {
+		return m.GetUserID() == userID
+	}, options, page)
 }
 
 func (repo *InMemoryRepository) ListMeasurementsByParameter(
 	_ context.Context,
 	parameterID uuid.UUID,
-) ([]Measurement, error) {
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	return repo.list(func(m Measurement) bool {
+		return m.GetParameterID() == parameterID
+	}, options, page)
+}
+
+func (repo *InMemoryRepository) list(
+	match func(Measurement) bool,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
 	repo.mu.RLock()
 	defer repo.mu.RUnlock()
 
-	var results []Measurement
+	results := []Measurement{}
 	for _, measurement := range repo.measurements {
-		if measurement.GetParameterID() == parameterID {
+		if match(measurement) && options.Matches(measurement) {
 			results = append(results, measurement)
 		}
 	}
 
-	return results, nil
+	slices.SortFunc(results, func(a, b Measurement) int {
+		return compareMeasurements(a, b, options.Sort)
+	})
+	for i, measurement := range results {
+		results[i] = options.project(measurement)
+	}
+
+	return pagination.Slice(results, page)
 }
 
 func (repo *InMemoryRepository) DeleteMeasurement(_ context.Context, id uuid.UUID) error {
@@ -76,3 +105,40 @@ func (repo *InMemoryRepository) DeleteMeasurement(_ context.Context, id uuid.UUI
 
 	return nil
 }
+
+func (repo *InMemoryRepository) CountMeasurementsByParameter(
+	_ context.Context,
+	parameterID uuid.UUID,
+	filter Filter,
+) (int, error) {
+	repo.mu.RLock()
+	defer repo.mu.RUnlock()
+
+	count := 0
+	for _, measurement := range repo.measurements {
+		if measurement.GetParameterID() == parameterID && filter.Matches(measurement) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (repo *InMemoryRepository) DeleteMeasurementsByParameter(
+	_ context.Context,
+	parameterID uuid.UUID,
+	filter Filter,
+) (int, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	deleted := 0
+	for id, measurement := range repo.measurements {
+		if measurement.GetParameterID() == parameterID && filter.Matches(measurement) {
+			delete(repo.measurements, id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
@@ -1,6 +1,9 @@
 package measurement
 
 import (
+	"cmp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,10 +12,177 @@ import (
 type DataType string
 
 const (
-	DataTypeFloat DataType = "float"
-	// MeasurementTypeBoolean MeasurementType = "boolean".
+	DataTypeFloat   DataType = "float"
+	DataTypeBoolean DataType = "boolean"
 )
 
+// Filter narrows a measurement listing. Nil bounds leave that side open.
+type Filter struct {
+	// From and To bound the timestamp, both inclusive.
+	From *time.Time
+	To   *time.Time
+	// Predicate, when set, must hold for the value and notes.
+	Predicate *Predicate
+}
+
+// Matches reports whether the measurement passes the filter.
+func (f Filter) Matches(m Measurement) bool {
+	timestamp := m.GetTimestamp()
+	if f.From != nil && timestamp.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && timestamp.After(*f.To) {
+		return false
+	}
+	if f.Predicate != nil && !f.Predicate.Matches(m) {
+		return false
+	}
+
+	return true
+}
+
+// SortField names the attribute a measurement listing is ordered by.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "createdAt"
+	SortByTimestamp SortField = "timestamp"
+	SortByValue     SortField = "value"
+)
+
+// Sort orders a measurement listing. The zero Sort is creation order.
+type Sort struct {
+	Field      SortField
+	Descending bool
+}
+
+// Field names a stored measurement attribute that a listing can load.
+type Field string
+
+const (
+	FieldID          Field = "id"
+	FieldType        Field = "type"
+	FieldUserID      Field = "userId"
+	FieldParameterID Field = "parameterId"
+	FieldTimestamp   Field = "timestamp"
+	FieldNotes       Field = "notes"
+	FieldValue       Field = "value"
+	FieldCreatedAt   Field = "createdAt"
+	FieldUpdatedAt   Field = "updatedAt"
+)
+
+var allFields = []Field{
+	FieldID, FieldType, FieldUserID, FieldParameterID, FieldTimestamp,
+	FieldNotes, FieldValue, FieldCreatedAt, FieldUpdatedAt,
+}
+
+// requiredFields are loaded whatever the projection, because measurements
+// cannot be identified, filtered or shown in their display unit without them.
+var requiredFields = []Field{FieldID, FieldType, FieldUserID, FieldParameterID, FieldTimestamp}
+
+// ListOptions narrows, orders and projects a measurement listing.
+type ListOptions struct {
+	Filter
+	Sort Sort
+	// Fields lists the attributes to load. Empty loads all of them; the
+	// others are left at their zero value.
+	Fields []Field
+}
+
+// loads reports whether the projection loads the field. Fields read by the
+// predicate are loaded so that it can be checked after the query.
+func (o ListOptions) loads(field Field) bool {
+	return len(o.Fields) == 0 || slices.Contains(requiredFields, field) || slices.Contains(o.Fields, field) ||
+		o.Predicate.uses(field)
+}
+
+// project returns a copy of the measurement holding only the loaded fields.
+func (o ListOptions) project(m Measurement) Measurement {
+	if len(o.Fields) == 0 {
+		return m
+	}
+
+	var base *BaseMeasurement
+	var projected Measurement
+	switch typed := m.(type) {
+	case *FloatMeasurement:
+		c := *typed
+		if !o.loads(FieldValue) {
+			c.Value = 0
+		}
+		base, projected = &c.BaseMeasurement, &c
+	case *BooleanMeasurement:
+		c := *typed
+		if !o.loads(FieldValue) {
+			c.Value = false
+		}
+		base, projected = &c.BaseMeasurement, &c
+	default:
+		return m
+	}
+
+	if !o.loads(FieldNotes) {
+		base.Notes = ""
+	}
+	if !o.loads(FieldCreatedAt) {
+		base.CreatedAt = time.Time{}
+	}
+	if !o.loads(FieldUpdatedAt) {
+		base.UpdatedAt = time.Time{}
+	}
+
+	return projected
+}
+
+// compareMeasurements orders two measurements by the sort field, falling back
+// to creation order and then the ID so that the order is total. Booleans
+// sort before numbers, as they do in Cosmos DB.
+func compareMeasurements(a, b Measurement, sort Sort) int {
+	var byField int
+	switch sort.Field {
+	case SortByTimestamp:
+		byField = a.GetTimestamp().Compare(b.GetTimestamp())
+	case SortByValue:
+		byField = compareValues(a, b)
+	case SortByCreatedAt, "":
+		byField = a.GetCreatedAt().Compare(b.GetCreatedAt())
+	}
+	if sort.Descending {
+		byField = -byField
+	}
+	if byField != 0 {
+		return byField
+	}
+
+	if byTime := a.GetCreatedAt().Compare(b.GetCreatedAt()); byTime != 0 {
+		return byTime
+	}
+	return strings.Compare(a.GetID().String(), b.GetID().String())
+}
+
+func compareValues(a, b Measurement) int {
+	rank := func(m Measurement) (int, float64) {
+		switch typed := m.(type) {
+		case *BooleanMeasurement:
+			if typed.Value {
+				return 0, 1
+			}
+			return 0, 0
+		case *FloatMeasurement:
+			return 1, typed.Value
+		default:
+			return -1, 0
+		}
+	}
+
+	rankA, valueA := rank(a)
+	rankB, valueB := rank(b)
+	if rankA != rankB {
+		return cmp.Compare(rankA, rankB)
+	}
+	return cmp.Compare(valueA, valueB)
+}
+
 type BaseMeasurement struct {
 	Type        DataType
 	ID          uuid.UUID
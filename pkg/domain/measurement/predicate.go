@@ -0,0 +1,513 @@
+package measurement
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidPredicate = errors.New("invalid predicate")
+)
+
+const (
+	// MaxPredicateLength bounds the source of a predicate.
+	MaxPredicateLength = 500
+	// maxConditions bounds the conditions joined by "and".
+	maxConditions = 10
+	// maxInValues bounds the list of an "in" condition.
+	maxInValues = 50
+)
+
+// operator compares a measurement attribute with the operands of a condition.
+type operator string
+
+const (
+	operatorEqual        operator = "="
+	operatorNotEqual     operator = "!="
+	operatorLess         operator = "<"
+	operatorLessEqual    operator = "<="
+	operatorGreater      operator = ">"
+	operatorGreaterEqual operator = ">="
+	operatorBetween      operator = "between"
+	operatorIn           operator = "in"
+	operatorContains     operator = "contains"
+)
+
+// condition is one comparison of a predicate. Operands are float64 or bool
+// for the value and a string for the notes.
+type condition struct {
+	field    Field
+	operator operator
+	operands []interface{}
+}
+
+// Predicate is a conjunction of conditions on the value and notes of a
+// measurement, parsed from a small grammar:
+//
+//	predicate = condition { "and" condition }
+//	condition = "value" ( "=" | "!=" | "<" | "<=" | ">" | ">=" ) literal
+//	          | "value" "between" number "and" number
+//	          | "value" "in" "(" literal { "," literal } ")"
+//	          | "notes" "contains" string
+//	literal   = number | "true" | "false"
+//
+// Keywords are case-insensitive and strings are double-quoted. Booleans only
+// support equality, and "contains" ignores case.
+type Predicate struct {
+	source     string
+	conditions []condition
+	// converted holds the conditions of parameters whose values are stored
+	// in another unit than the operands are written in.
+	converted map[uuid.UUID][]condition
+}
+
+// ParsePredicate parses and validates a predicate.
+func ParsePredicate(source string) (*Predicate, error) {
+	if len(source) > MaxPredicateLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidPredicate, MaxPredicateLength)
+	}
+
+	tokens, err := tokenizePredicate(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &predicateParser{tokens: tokens}
+	predicate := &Predicate{source: source}
+	for {
+		c, conditionErr := p.parseCondition()
+		if conditionErr != nil {
+			return nil, conditionErr
+		}
+		predicate.conditions = append(predicate.conditions, c)
+
+		if !p.keyword("and") {
+			break
+		}
+	}
+
+	if t := p.peek(); t.kind != predicateEOF {
+		return nil, p.unexpected(t)
+	}
+	if len(predicate.conditions) > maxConditions {
+		return nil, fmt.Errorf("%w: more than %d conditions", ErrInvalidPredicate, maxConditions)
+	}
+
+	return predicate, nil
+}
+
+func (p *Predicate) String() string {
+	return p.source
+}
+
+// ConvertFor returns a copy of the predicate whose number operands are
+// converted for the measurements of the parameter. Operands are written in
+// the unit values are shown in, and convert maps them to the stored unit.
+func (p *Predicate) ConvertFor(parameterID uuid.UUID, convert func(float64) (float64, error)) (*Predicate, error) {
+	conditions := make([]condition, len(p.conditions))
+	for i, c := range p.conditions {
+		conditions[i] = c
+		if c.field != FieldValue {
+			continue
+		}
+
+		conditions[i].operands = make([]interface{}, len(c.operands))
+		for j, operand := range c.operands {
+			number, ok := operand.(float64)
+			if !ok {
+				conditions[i].operands[j] = operand
+				continue
+			}
+			stored, err := convert(number)
+			if err != nil {
+				return nil, err
+			}
+			conditions[i].operands[j] = stored
+		}
+	}
+
+	converted := make(map[uuid.UUID][]condition, len(p.converted)+1)
+	for id, existing := range p.converted {
+		converted[id] = existing
+	}
+	converted[parameterID] = conditions
+
+	return &Predicate{source: p.source, conditions: p.conditions, converted: converted}, nil
+}
+
+// Matches reports whether the measurement meets every condition. A value of
+// a different type than the operands never matches.
+func (p *Predicate) Matches(m Measurement) bool {
+	for _, c := range p.conditionsFor(m.GetParameterID()) {
+		if !c.matches(m) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// conditionsFor returns the conditions that apply to the measurements of the
+// parameter.
+func (p *Predicate) conditionsFor(parameterID uuid.UUID) []condition {
+	if conditions, ok := p.converted[parameterID]; ok {
+		return conditions
+	}
+
+	return p.conditions
+}
+
+// uses reports whether a condition reads the field. A nil predicate reads
+// nothing.
+func (p *Predicate) uses(field Field) bool {
+	if p == nil {
+		return false
+	}
+
+	return slices.ContainsFunc(p.conditions, func(c condition) bool {
+		return c.field == field
+	})
+}
+
+func (c condition) matches(m Measurement) bool {
+	if c.field == FieldNotes {
+		needle, _ := c.operands[0].(string)
+		return strings.Contains(strings.ToLower(m.GetNotes()), strings.ToLower(needle))
+	}
+
+	switch typed := m.(type) {
+	case *FloatMeasurement:
+		return c.matchesNumber(typed.Value)
+	case *BooleanMeasurement:
+		return c.matchesBoolean(typed.Value)
+	default:
+		return false
+	}
+}
+
+func (c condition) matchesNumber(value float64) bool {
+	operands := make([]float64, 0, len(c.operands))
+	for _, operand := range c.operands {
+		number, ok := operand.(float64)
+		if !ok {
+			return false
+		}
+		operands = append(operands, number)
+	}
+
+	switch c.operator {
+	case operatorEqual:
+		return value == operands[0]
+	case operatorNotEqual:
+		return value != operands[0]
+	case operatorLess:
+		return value < operands[0]
+	case operatorLessEqual:
+		return value <= operands[0]
+	case operatorGreater:
+		return value > operands[0]
+	case operatorGreaterEqual:
+		return value >= operands[0]
+	case operatorBetween:
+		return value >= operands[0] && value <= operands[1]
+	case operatorIn:
+		return slices.Contains(operands, value)
+	case operatorContains:
+		return false
+	default:
+		return false
+	}
+}
+
+func (c condition) matchesBoolean(value bool) bool {
+	operands := make([]bool, 0, len(c.operands))
+	for _, operand := range c.operands {
+		boolean, ok := operand.(bool)
+		if !ok {
+			return false
+		}
+		operands = append(operands, boolean)
+	}
+
+	switch c.operator {
+	case operatorEqual:
+		return value == operands[0]
+	case operatorNotEqual:
+		return value != operands[0]
+	case operatorIn:
+		return slices.Contains(operands, value)
+	case operatorLess, operatorLessEqual, operatorGreater, operatorGreaterEqual, operatorBetween, operatorContains:
+		return false
+	default:
+		return false
+	}
+}
+
+type predicateTokenKind int
+
+const (
+	predicateEOF predicateTokenKind = iota
+	predicateNumber
+	predicateString
+	predicateWord
+	predicateOperator
+	predicateLeftParen
+	predicateRightParen
+	predicateComma
+)
+
+type predicateToken struct {
+	kind     predicateTokenKind
+	text     string
+	position int
+}
+
+func tokenizePredicate(source string) ([]predicateToken, error) {
+	var tokens []predicateToken
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.' || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, predicateToken{kind: predicateNumber, text: string(runes[start:i]), position: start})
+		case r == '"':
+			start := i
+			var text strings.Builder
+			for i++; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				text.WriteRune(runes[i])
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string at position %d", ErrInvalidPredicate, start)
+			}
+			tokens = append(tokens, predicateToken{kind: predicateString, text: text.String(), position: start})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, predicateToken{
+				kind:     predicateWord,
+				text:     strings.ToLower(string(runes[start:i])),
+				position: start,
+			})
+		case strings.ContainsRune("=!<>", r):
+			start := i
+			i++
+			if i < len(runes) && runes[i] == '=' && r != '=' {
+				i++
+			}
+			text := string(runes[start:i])
+			if text == "!" {
+				return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidPredicate, r, start)
+			}
+			tokens = append(tokens, predicateToken{kind: predicateOperator, text: text, position: start})
+		case r == '(':
+			tokens = append(tokens, predicateToken{kind: predicateLeftParen, text: "(", position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, predicateToken{kind: predicateRightParen, text: ")", position: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, predicateToken{kind: predicateComma, text: ",", position: i})
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidPredicate, r, i)
+		}
+	}
+
+	return append(tokens, predicateToken{kind: predicateEOF, position: len(runes)}), nil
+}
+
+type predicateParser struct {
+	tokens []predicateToken
+	pos    int
+}
+
+func (p *predicateParser) peek() predicateToken {
+	return p.tokens[p.pos]
+}
+
+func (p *predicateParser) next() predicateToken {
+	t := p.tokens[p.pos]
+	if t.kind != predicateEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+// keyword consumes the next token if it is the given word.
+func (p *predicateParser) keyword(word string) bool {
+	if t := p.peek(); t.kind == predicateWord && t.text == word {
+		p.next()
+		return true
+	}
+
+	return false
+}
+
+func (p *predicateParser) expect(kind predicateTokenKind) (predicateToken, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, p.unexpected(t)
+	}
+
+	return t, nil
+}
+
+func (p *predicateParser) unexpected(t predicateToken) error {
+	if t.kind == predicateEOF {
+		return fmt.Errorf("%w: unexpected end of predicate", ErrInvalidPredicate)
+	}
+
+	return fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidPredicate, t.text, t.position)
+}
+
+func (p *predicateParser) parseCondition() (condition, error) {
+	t := p.next()
+	if t.kind != predicateWord {
+		return condition{}, p.unexpected(t)
+	}
+
+	switch t.text {
+	case string(FieldValue):
+		return p.parseValueCondition()
+	case string(FieldNotes):
+		if !p.keyword(string(operatorContains)) {
+			return condition{}, p.unexpected(p.peek())
+		}
+		text, err := p.expect(predicateString)
+		if err != nil {
+			return condition{}, err
+		}
+		return condition{field: FieldNotes, operator: operatorContains, operands: []interface{}{text.text}}, nil
+	default:
+		return condition{}, fmt.Errorf("%w: unknown field %q at position %d", ErrInvalidPredicate, t.text, t.position)
+	}
+}
+
+func (p *predicateParser) parseValueCondition() (condition, error) {
+	c := condition{field: FieldValue}
+
+	switch t := p.next(); {
+	case t.kind == predicateOperator:
+		operand, err := p.parseLiteral()
+		if err != nil {
+			return condition{}, err
+		}
+		c.operator = operator(t.text)
+		c.operands = []interface{}{operand}
+		if isBoolean(operand) && c.operator != operatorEqual && c.operator != operatorNotEqual {
+			return condition{}, fmt.Errorf("%w: booleans only support = and != at position %d",
+				ErrInvalidPredicate, t.position)
+		}
+	case t.kind == predicateWord && t.text == string(operatorBetween):
+		low, err := p.parseNumber()
+		if err != nil {
+			return condition{}, err
+		}
+		if !p.keyword("and") {
+			return condition{}, p.unexpected(p.peek())
+		}
+		high, err := p.parseNumber()
+		if err != nil {
+			return condition{}, err
+		}
+		if low > high {
+			return condition{}, fmt.Errorf("%w: between bounds out of order at position %d",
+				ErrInvalidPredicate, t.position)
+		}
+		c.operator = operatorBetween
+		c.operands = []interface{}{low, high}
+	case t.kind == predicateWord && t.text == string(operatorIn):
+		operands, err := p.parseList()
+		if err != nil {
+			return condition{}, err
+		}
+		c.operator = operatorIn
+		c.operands = operands
+	default:
+		return condition{}, p.unexpected(t)
+	}
+
+	return c, nil
+}
+
+func (p *predicateParser) parseList() ([]interface{}, error) {
+	if _, err := p.expect(predicateLeftParen); err != nil {
+		return nil, err
+	}
+
+	var operands []interface{}
+	for {
+		operand, err := p.parseLiteral()
+		if err != nil {
+			return nil, err
+		}
+		if len(operands) > 0 && isBoolean(operand) != isBoolean(operands[0]) {
+			return nil, fmt.Errorf("%w: list mixes numbers and booleans", ErrInvalidPredicate)
+		}
+		operands = append(operands, operand)
+
+		t := p.next()
+		if t.kind == predicateRightParen {
+			break
+		}
+		if t.kind != predicateComma {
+			return nil, p.unexpected(t)
+		}
+	}
+
+	if len(operands) > maxInValues {
+		return nil, fmt.Errorf("%w: more than %d values in list", ErrInvalidPredicate, maxInValues)
+	}
+
+	return operands, nil
+}
+
+func (p *predicateParser) parseLiteral() (interface{}, error) {
+	switch t := p.peek(); {
+	case t.kind == predicateWord && (t.text == "true" || t.text == "false"):
+		p.next()
+		return t.text == "true", nil
+	default:
+		return p.parseNumber()
+	}
+}
+
+func isBoolean(operand interface{}) bool {
+	_, ok := operand.(bool)
+	return ok
+}
+
+func (p *predicateParser) parseNumber() (float64, error) {
+	t, err := p.expect(predicateNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(t.text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid number %q at position %d", ErrInvalidPredicate, t.text, t.position)
+	}
+
+	return value, nil
+}
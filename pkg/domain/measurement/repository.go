@@ -3,12 +3,31 @@ package measurement
 import (
 	"context"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
 type Repository interface {
 	CreateMeasurement(ctx context.Context, measurement Measurement) (Measurement, error)
-	ListMeasurementsByUser(ctx context.Context, userID uuid.UUID) ([]Measurement, error)
-	ListMeasurementsByParameter(ctx context.Context, parameterID uuid.UUID) ([]Measurement, error)
+	// CreateMeasurements stores the measurements and returns one error per
+	// measurement, in the same order, which is nil for those stored.
+	CreateMeasurements(ctx context.Context, measurements []Measurement) []error
+	ListMeasurementsByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[Measurement], error)
+	ListMeasurementsByParameter(
+		ctx context.Context,
+		parameterID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[Measurement], error)
 	DeleteMeasurement(ctx context.Context, id uuid.UUID) error
+	CountMeasurementsByParameter(ctx context.Context, parameterID uuid.UUID, filter Filter) (int, error)
+	// DeleteMeasurementsByParameter deletes the parameter's measurements that
+	// match the filter and returns how many it deleted. Running it again after
+	// an interruption deletes whatever is left.
+	DeleteMeasurementsByParameter(ctx context.Context, parameterID uuid.UUID, filter Filter) (int, error)
 }
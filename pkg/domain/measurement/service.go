@@ -4,19 +4,59 @@ import (
 	"context"
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
+// MaxBatchSize is the largest number of measurements created in one batch.
+const MaxBatchSize = 500
+
 type Service interface {
 	CreateMeasurement(ctx context.Context, input CreateMeasurementInput) (Measurement, error)
-	ListMeasurementsByUser(ctx context.Context, userID uuid.UUID) ([]Measurement, error)
-	ListMeasurementsByParameter(ctx context.Context, parameterID uuid.UUID) ([]Measurement, error)
+	CreateMeasurements(ctx context.Context, inputs []CreateMeasurementInput) ([]BatchResult, error)
+	ListMeasurementsByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[Measurement], error)
+	ListMeasurementsByParameter(
+		ctx context.Context,
+		parameterID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[Measurement], error)
 	DeleteMeasurement(ctx context.Context, id uuid.UUID) error
+	// DeleteMeasurementsByParameter deletes a parameter's measurements in the
+	// input's time range and returns how many were deleted, or, on a dry run,
+	// how many would be.
+	DeleteMeasurementsByParameter(ctx context.Context, input DeleteMeasurementsInput) (int, error)
 }
 
 type CreateMeasurementInput struct {
 	ParameterID uuid.UUID
 	Notes       string
 	Value       interface{}
-	Timestamp   time.Time
+	// Unit is the unit the value was submitted in. Values are converted to
+	// the parameter's unit before they are stored. Empty means the
+	// parameter's unit.
+	Unit      string
+	Timestamp time.Time
+}
+
+type DeleteMeasurementsInput struct {
+	ParameterID uuid.UUID
+	// From and To limit the deletion to measurements taken in the range,
+	// inclusive. Nil leaves that end open.
+	From *time.Time
+	To   *time.Time
+	// DryRun counts the matching measurements without deleting them.
+	DryRun bool
+}
+
+// BatchResult is the outcome of one item of a batch: the created measurement,
+// or the error that kept it from being created.
+type BatchResult struct {
+	Measurement Measurement
+	Err         error
 }
@@ -4,12 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrDerivedParameter = errors.New("measurements cannot be created for a derived parameter")
+	ErrIncompatibleUnit = errors.New("incompatible unit")
+	ErrInvalidTimeRange = errors.New("time range start must not be after its end")
+	ErrInvalidSort      = errors.New("invalid sort field")
+	ErrInvalidField     = errors.New("invalid field")
+	ErrInvalidValueType = errors.New("invalid value type")
+	ErrInvalidBatchSize = fmt.Errorf("a batch must hold between 1 and %d measurements", MaxBatchSize)
+)
+
 type ServiceImpl struct {
 	repo             Repository
 	parameterService parameter.Service
@@ -28,6 +42,106 @@ func (s *ServiceImpl) CreateMeasurement(ctx context.Context, input CreateMeasure
 		return nil, err
 	}
 
+	measurement, err := newMeasurement(measurementParameter, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateMeasurement(ctx, measurement)
+}
+
+func (s *ServiceImpl) ListMeasurementsByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	if err := validateOptions(options); err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+
+	return s.repo.ListMeasurementsByUser(ctx, userID, options, page)
+}
+
+func (s *ServiceImpl) ListMeasurementsByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[Measurement], error) {
+	if err := validateOptions(options); err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pagination.Result[Measurement]{}, err
+	}
+
+	return s.repo.ListMeasurementsByParameter(ctx, parameterID, options, page)
+}
+
+func (s *ServiceImpl) DeleteMeasurement(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteMeasurement(ctx, id)
+}
+
+// CreateMeasurements validates every input against its parameter on its own
+// and stores the valid ones together. One invalid item does not stop the
+// others; its error is reported in the result at the same index.
+func (s *ServiceImpl) CreateMeasurements(ctx context.Context, inputs []CreateMeasurementInput) ([]BatchResult, error) {
+	if len(inputs) == 0 || len(inputs) > MaxBatchSize {
+		return nil, ErrInvalidBatchSize
+	}
+
+	results := make([]BatchResult, len(inputs))
+	parameters := make(map[uuid.UUID]*parameter.Parameter)
+	measurements := make([]Measurement, 0, len(inputs))
+	indexes := make([]int, 0, len(inputs))
+	for i, input := range inputs {
+		measurementParameter, ok := parameters[input.ParameterID]
+		if !ok {
+			var err error
+			measurementParameter, err = s.parameterService.GetParameterByID(ctx, input.ParameterID)
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			parameters[input.ParameterID] = measurementParameter
+		}
+
+		measurement, err := newMeasurement(measurementParameter, input)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		measurements = append(measurements, measurement)
+		indexes = append(indexes, i)
+	}
+
+	if len(measurements) == 0 {
+		return results, nil
+	}
+
+	for j, err := range s.repo.CreateMeasurements(ctx, measurements) {
+		if err != nil {
+			results[indexes[j]].Err = err
+			continue
+		}
+		results[indexes[j]].Measurement = measurements[j]
+	}
+
+	return results, nil
+}
+
+// newMeasurement builds a measurement of the parameter from the input,
+// checking the value against the parameter's data type and converting it to
+// the parameter's unit.
+func newMeasurement(measurementParameter *parameter.Parameter, input CreateMeasurementInput) (Measurement, error) {
+	if measurementParameter.IsDerived() {
+		return nil, ErrDerivedParameter
+	}
+
 	ts := input.Timestamp
 	if ts.IsZero() {
 		ts = time.Now().UTC()
@@ -39,7 +153,11 @@ func (s *ServiceImpl) CreateMeasurement(ctx context.Context, input CreateMeasure
 	case parameter.DataType(DataTypeFloat):
 		v, ok := input.Value.(float64)
 		if !ok {
-			return nil, errors.New("invalid value type for float measurement")
+			return nil, fmt.Errorf("%w for float measurement", ErrInvalidValueType)
+		}
+		v, err := toParameterUnit(v, input.Unit, measurementParameter)
+		if err != nil {
+			return nil, err
 		}
 		measurement := &FloatMeasurement{
 			BaseMeasurement: BaseMeasurement{
@@ -54,40 +172,81 @@ func (s *ServiceImpl) CreateMeasurement(ctx context.Context, input CreateMeasure
 			},
 			Value: v,
 		}
-		return s.repo.CreateMeasurement(ctx, measurement)
-	// case MeasurementTypeBoolean:
-	//	// parse `input.Value` as bool
-	//	b, ok := input.Value.(bool)
-	//	if !ok {
-	//		return nil, errors.New("expected boolean value for measurement")
-	//	}
-	//	meas := BooleanMeasurement{
-	//		BaseMeasurement: BaseMeasurement{
-	//			Type:        MeasurementTypeBoolean,
-	//			ID:          uuid.New(),
-	//			UserID:      input.UserID,
-	//			ParameterID: input.ParameterID,
-	//			Timestamp:   input.Timestamp,
-	//			Notes:       input.Notes,
-	//			CreatedAt:   time.Now(),
-	//			UpdatedAt:   time.Now(),
-	//		},
-	//		Value: b,
-	//	}
-	//	return s.repo.CreateMeasurement(ctx, meas)
+		return measurement, nil
+	case parameter.DataType(DataTypeBoolean):
+		b, ok := input.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w for boolean measurement", ErrInvalidValueType)
+		}
+		if input.Unit != "" {
+			return nil, fmt.Errorf("%w: boolean measurements have no unit", ErrIncompatibleUnit)
+		}
+		measurement := &BooleanMeasurement{
+			BaseMeasurement: BaseMeasurement{
+				Type:        DataTypeBoolean,
+				ID:          uuid.New(),
+				UserID:      measurementParameter.UserID,
+				ParameterID: measurementParameter.ID,
+				Timestamp:   ts,
+				Notes:       input.Notes,
+				CreatedAt:   time.Now().UTC(),
+				UpdatedAt:   time.Now().UTC(),
+			},
+			Value: b,
+		}
+		return measurement, nil
 	default:
 		return nil, fmt.Errorf("unsupported measurement type: %s", measurementParameterType)
 	}
 }
 
-func (s *ServiceImpl) ListMeasurementsByUser(ctx context.Context, userID uuid.UUID) ([]Measurement, error) {
-	return s.repo.ListMeasurementsByUser(ctx, userID)
+func (s *ServiceImpl) DeleteMeasurementsByParameter(ctx context.Context, input DeleteMeasurementsInput) (int, error) {
+	if input.From != nil && input.To != nil && input.From.After(*input.To) {
+		return 0, ErrInvalidTimeRange
+	}
+
+	if _, err := s.parameterService.GetParameterByID(ctx, input.ParameterID); err != nil {
+		return 0, err
+	}
+
+	filter := Filter{From: input.From, To: input.To}
+	if input.DryRun {
+		return s.repo.CountMeasurementsByParameter(ctx, input.ParameterID, filter)
+	}
+
+	return s.repo.DeleteMeasurementsByParameter(ctx, input.ParameterID, filter)
 }
 
-func (s *ServiceImpl) ListMeasurementsByParameter(ctx context.Context, parameterID uuid.UUID) ([]Measurement, error) {
-	return s.repo.ListMeasurementsByParameter(ctx, parameterID)
+func validateOptions(options ListOptions) error {
+	if options.From != nil && options.To != nil && options.From.After(*options.To) {
+		return ErrInvalidTimeRange
+	}
+
+	switch options.Sort.Field {
+	case SortByCreatedAt, SortByTimestamp, SortByValue, "":
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSort, options.Sort.Field)
+	}
+
+	for _, field := range options.Fields {
+		if !slices.Contains(allFields, field) {
+			return fmt.Errorf("%w: %s", ErrInvalidField, field)
+		}
+	}
+
+	return nil
 }
 
-func (s *ServiceImpl) DeleteMeasurement(ctx context.Context, id uuid.UUID) error {
-	return s.repo.DeleteMeasurement(ctx, id)
+func toParameterUnit(value float64, unit string, measurementParameter *parameter.Parameter) (float64, error) {
+	unit = strings.TrimSpace(unit)
+	if unit == "" || strings.EqualFold(unit, measurementParameter.Unit) {
+		return value, nil
+	}
+
+	converted, err := units.Convert(value, unit, measurementParameter.Unit)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrIncompatibleUnit, err)
+	}
+
+	return converted, nil
 }
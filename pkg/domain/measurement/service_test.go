@@ -3,9 +3,12 @@ package measurement_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,7 +26,7 @@ func TestCreateMeasurement_Success(t *testing.T) {
 		Name:        "Test Parameter",
 		Description: "Test Description",
 		DataType:    parameter.DataTypeFloat,
-		Unit:        "Test Unit",
+		Unit:        "kg",
 	}
 
 	createdParam, err := parameterService.CreateParameter(context.Background(), parameterInput)
@@ -219,3 +222,667 @@ func TestCreateMeasurement_ParameterNotFound_ForFloatMeasurement(t *testing.T) {
 //}
 
 // https://chatgpt.com/c/678a9722-bd3c-800d-b4cb-bb5d2566a59d?model=o1-mini
+
+func TestCreateMeasurement_DerivedParameter(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	userID := uuid.New()
+	intake, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Intake",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	doubled, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Doubled intake",
+		DataType: parameter.DataTypeFloat,
+		Formula:  "{" + intake.ID.String() + "} * 2",
+	})
+	require.NoError(t, err)
+
+	createdMeasurement, err := measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: doubled.ID,
+		Value:       10.0,
+	})
+	require.ErrorIs(t, err, measurement.ErrDerivedParameter)
+	assert.Nil(t, createdMeasurement)
+}
+
+func TestCreateMeasurement_Boolean(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	createdParam, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Meditated",
+		DataType: parameter.DataTypeBoolean,
+	})
+	require.NoError(t, err)
+
+	createdMeasurement, err := measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: createdParam.ID,
+		Value:       false,
+	})
+	require.NoError(t, err)
+
+	booleanMeas, ok := createdMeasurement.(*measurement.BooleanMeasurement)
+	require.True(t, ok)
+	assert.False(t, booleanMeas.Value)
+	assert.Equal(t, measurement.DataTypeBoolean, booleanMeas.Type)
+
+	_, err = measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: createdParam.ID,
+		Value:       1.0,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid value type for boolean measurement")
+}
+
+func TestCreateMeasurement_ConvertsToParameterUnit(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	weight, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+
+	createdMeasurement, err := measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: weight.ID,
+		Value:       160.0,
+		Unit:        "lb",
+	})
+	require.NoError(t, err)
+
+	floatMeas, ok := createdMeasurement.(*measurement.FloatMeasurement)
+	require.True(t, ok)
+	assert.InDelta(t, 72.5747792, floatMeas.Value, 1e-6)
+
+	_, err = measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: weight.ID,
+		Value:       37.0,
+		Unit:        "°C",
+	})
+	require.ErrorIs(t, err, measurement.ErrIncompatibleUnit)
+}
+
+func TestCreateMeasurement_UnitlessParameter(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	mood, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	_, err = measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: mood.ID,
+		Value:       7.0,
+	})
+	require.NoError(t, err)
+
+	_, err = measurementService.CreateMeasurement(context.Background(), measurement.CreateMeasurementInput{
+		ParameterID: mood.ID,
+		Value:       7.0,
+		Unit:        "kg",
+	})
+	require.ErrorIs(t, err, measurement.ErrIncompatibleUnit)
+}
+
+func TestCreateMeasurements_Batch(t *testing.T) {
+	ctx := context.Background()
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+
+	userID := uuid.New()
+	weight, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+	workout, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Workout",
+		DataType: parameter.DataTypeBoolean,
+	})
+	require.NoError(t, err)
+
+	results, err := measurementService.CreateMeasurements(ctx, []measurement.CreateMeasurementInput{
+		{ParameterID: weight.ID, Value: 70.5},
+		{ParameterID: workout.ID, Value: true},
+		{ParameterID: weight.ID, Value: true},
+		{ParameterID: uuid.New(), Value: 1.0},
+		{ParameterID: weight.ID, Value: 71.0},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	for _, i := range []int{0, 1, 4} {
+		require.NoError(t, results[i].Err)
+		assert.NotNil(t, results[i].Measurement)
+	}
+	require.ErrorIs(t, results[2].Err, measurement.ErrInvalidValueType)
+	require.ErrorIs(t, results[3].Err, parameter.ErrParameterNotFound)
+	assert.Nil(t, results[3].Measurement)
+
+	listed, err := measurementService.ListMeasurementsByUser(
+		ctx, userID, measurement.ListOptions{}, pagination.Page{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, listed.Items, 3)
+
+	_, err = measurementService.CreateMeasurements(ctx, nil)
+	require.ErrorIs(t, err, measurement.ErrInvalidBatchSize)
+
+	tooMany := make([]measurement.CreateMeasurementInput, measurement.MaxBatchSize+1)
+	_, err = measurementService.CreateMeasurements(ctx, tooMany)
+	require.ErrorIs(t, err, measurement.ErrInvalidBatchSize)
+}
+
+func TestListMeasurements_Filter(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	sleep, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	for day := range 5 {
+		_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+			ParameterID: sleep.ID,
+			Value:       float64(day),
+			Timestamp:   start.AddDate(0, 0, day),
+		})
+		require.NoError(t, err)
+	}
+
+	// The bounds are inclusive and compared as instants, whatever their offset.
+	from := time.Date(2024, 3, 2, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	to := start.AddDate(0, 0, 3)
+	options := measurement.ListOptions{Filter: measurement.Filter{From: &from, To: &to}}
+
+	byParameter, err := measurementService.ListMeasurementsByParameter(ctx, sleep.ID, options, pagination.Page{})
+	require.NoError(t, err)
+	assert.Len(t, byParameter.Items, 3)
+	for _, m := range byParameter.Items {
+		assert.False(t, m.GetTimestamp().Before(from))
+		assert.False(t, m.GetTimestamp().After(to))
+	}
+
+	byUser, err := measurementService.ListMeasurementsByUser(
+		ctx, sleep.UserID, measurement.ListOptions{Filter: measurement.Filter{From: &to}}, pagination.Page{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, byUser.Items, 2)
+
+	all, err := measurementService.ListMeasurementsByUser(ctx, sleep.UserID, measurement.ListOptions{}, pagination.Page{})
+	require.NoError(t, err)
+	assert.Len(t, all.Items, 5)
+
+	_, err = measurementService.ListMeasurementsByParameter(
+		ctx, sleep.ID, measurement.ListOptions{Filter: measurement.Filter{From: &to, To: &from}}, pagination.Page{},
+	)
+	require.ErrorIs(t, err, measurement.ErrInvalidTimeRange)
+}
+
+func TestListMeasurements_Pagination(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	steps, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	for i := range 5 {
+		_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+			ParameterID: steps.ID,
+			Value:       float64(i),
+		})
+		require.NoError(t, err)
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	page := pagination.Page{Limit: 2}
+	pages := 0
+	for {
+		result, listErr := measurementService.ListMeasurementsByParameter(ctx, steps.ID, measurement.ListOptions{}, page)
+		require.NoError(t, listErr)
+		assert.LessOrEqual(t, len(result.Items), 2)
+		for _, m := range result.Items {
+			assert.False(t, seen[m.GetID()], "measurement listed twice")
+			seen[m.GetID()] = true
+		}
+
+		pages++
+		if result.Next == "" {
+			break
+		}
+		page.Cursor = result.Next
+	}
+	assert.Len(t, seen, 5)
+	assert.Equal(t, 3, pages)
+
+	_, err = measurementService.ListMeasurementsByParameter(
+		ctx, steps.ID, measurement.ListOptions{}, pagination.Page{Limit: 2, Cursor: "not a cursor"},
+	)
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
+
+	_, err = measurementService.ListMeasurementsByUser(
+		ctx, steps.UserID, measurement.ListOptions{}, pagination.Page{Limit: pagination.MaxLimit + 1},
+	)
+	require.ErrorIs(t, err, pagination.ErrInvalidLimit)
+}
+
+func TestListMeasurements_SortAndProjection(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	weight, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	for day, value := range []float64{72.4, 71.8, 72.9, 71.5} {
+		_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+			ParameterID: weight.ID,
+			Value:       value,
+			Notes:       "morning",
+			Timestamp:   start.AddDate(0, 0, 3-day),
+		})
+		require.NoError(t, err)
+	}
+
+	values := func(options measurement.ListOptions) []float64 {
+		result, listErr := measurementService.ListMeasurementsByParameter(ctx, weight.ID, options, pagination.Page{})
+		require.NoError(t, listErr)
+
+		var listed []float64
+		for _, m := range result.Items {
+			floatMeasurement, ok := m.(*measurement.FloatMeasurement)
+			require.True(t, ok)
+			listed = append(listed, floatMeasurement.Value)
+		}
+		return listed
+	}
+
+	assert.Equal(t, []float64{71.5, 72.9, 71.8, 72.4}, values(measurement.ListOptions{
+		Sort: measurement.Sort{Field: measurement.SortByTimestamp},
+	}))
+	assert.Equal(t, []float64{72.4, 71.8, 72.9, 71.5}, values(measurement.ListOptions{
+		Sort: measurement.Sort{Field: measurement.SortByTimestamp, Descending: true},
+	}))
+	assert.Equal(t, []float64{71.5, 71.8, 72.4, 72.9}, values(measurement.ListOptions{
+		Sort: measurement.Sort{Field: measurement.SortByValue},
+	}))
+
+	projected, err := measurementService.ListMeasurementsByParameter(ctx, weight.ID, measurement.ListOptions{
+		Fields: []measurement.Field{measurement.FieldValue},
+	}, pagination.Page{})
+	require.NoError(t, err)
+	require.Len(t, projected.Items, 4)
+	for _, m := range projected.Items {
+		assert.Empty(t, m.GetNotes())
+		assert.True(t, m.GetCreatedAt().IsZero())
+		assert.False(t, m.GetTimestamp().IsZero())
+	}
+
+	stored, err := measurementService.ListMeasurementsByParameter(
+		ctx, weight.ID, measurement.ListOptions{}, pagination.Page{Limit: 1},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "morning", stored.Items[0].GetNotes(), "projection must not modify stored measurements")
+
+	_, err = measurementService.ListMeasurementsByParameter(ctx, weight.ID, measurement.ListOptions{
+		Sort: measurement.Sort{Field: "notes"},
+	}, pagination.Page{})
+	require.ErrorIs(t, err, measurement.ErrInvalidSort)
+
+	_, err = measurementService.ListMeasurementsByParameter(ctx, weight.ID, measurement.ListOptions{
+		Fields: []measurement.Field{"colour"},
+	}, pagination.Page{})
+	require.ErrorIs(t, err, measurement.ErrInvalidField)
+
+	byUser, err := measurementService.ListMeasurementsByUser(ctx, weight.UserID, measurement.ListOptions{
+		Sort: measurement.Sort{Field: measurement.SortByValue, Descending: true},
+	}, pagination.Page{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, byUser.Items, 3)
+	assert.NotEmpty(t, byUser.Next)
+	floatMeasurement, ok := byUser.Items[0].(*measurement.FloatMeasurement)
+	require.True(t, ok)
+	assert.InDelta(t, 72.9, floatMeasurement.Value, 0.0001)
+}
+
+func TestListMeasurements_SortByCreatedAtDescending(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	weight, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+
+	created := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	var ids []uuid.UUID
+	for i := range 3 {
+		m := &measurement.FloatMeasurement{
+			BaseMeasurement: measurement.BaseMeasurement{
+				Type:        measurement.DataTypeFloat,
+				ID:          uuid.New(),
+				UserID:      weight.UserID,
+				ParameterID: weight.ID,
+				Timestamp:   created,
+				CreatedAt:   created.Add(time.Duration(i) * time.Minute),
+			},
+			Value: 70,
+		}
+		_, err = measurementRepository.CreateMeasurement(ctx, m)
+		require.NoError(t, err)
+		ids = append(ids, m.ID)
+	}
+
+	options := measurement.ListOptions{
+		Sort: measurement.Sort{Field: measurement.SortByCreatedAt, Descending: true},
+	}
+	byParameter, err := measurementService.ListMeasurementsByParameter(ctx, weight.ID, options, pagination.Page{})
+	require.NoError(t, err)
+	byUser, err := measurementService.ListMeasurementsByUser(ctx, weight.UserID, options, pagination.Page{})
+	require.NoError(t, err)
+
+	for _, result := range []pagination.Result[measurement.Measurement]{byParameter, byUser} {
+		require.Len(t, result.Items, 3)
+		for i, m := range result.Items {
+			assert.Equal(t, ids[2-i], m.GetID())
+		}
+	}
+}
+
+func TestParsePredicate(t *testing.T) {
+	valid := []string{
+		"value>80",
+		"value <= -1.5",
+		"VALUE between 5 and 7",
+		"value in (1, 2, 3)",
+		"value = true",
+		`notes contains "late \"night\""`,
+		`value < 6 and notes contains "nap"`,
+	}
+	for _, source := range valid {
+		predicate, err := measurement.ParsePredicate(source)
+		require.NoError(t, err, source)
+		assert.Equal(t, source, predicate.String())
+	}
+
+	invalid := []string{
+		"",
+		"value",
+		"value >",
+		"value => 5",
+		"value > true",
+		"value between 7 and 5",
+		"value in (1, true)",
+		"value in ()",
+		"notes = 5",
+		`notes contains "open`,
+		"timestamp > 5",
+		"value > 5 or value < 2",
+		"value > 5; DROP",
+	}
+	for _, source := range invalid {
+		_, err := measurement.ParsePredicate(source)
+		require.ErrorIs(t, err, measurement.ErrInvalidPredicate, source)
+	}
+}
+
+func TestListMeasurements_Predicate(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	sleep, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+	require.NoError(t, err)
+	coffee, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Late coffee",
+		DataType: parameter.DataTypeBoolean,
+	})
+	require.NoError(t, err)
+
+	for _, input := range []measurement.CreateMeasurementInput{
+		{ParameterID: sleep.ID, Value: 5.5, Notes: "Late night"},
+		{ParameterID: sleep.ID, Value: 6.0},
+		{ParameterID: sleep.ID, Value: 7.5, Notes: "nap included"},
+		{ParameterID: sleep.ID, Value: 8.0},
+		{ParameterID: coffee.ID, Value: true},
+		{ParameterID: coffee.ID, Value: false, Notes: "late start"},
+	} {
+		_, err = measurementService.CreateMeasurement(ctx, input)
+		require.NoError(t, err)
+	}
+
+	count := func(source string) int {
+		predicate, parseErr := measurement.ParsePredicate(source)
+		require.NoError(t, parseErr, source)
+
+		result, listErr := measurementService.ListMeasurementsByUser(ctx, userID, measurement.ListOptions{
+			Filter: measurement.Filter{Predicate: predicate},
+		}, pagination.Page{})
+		require.NoError(t, listErr, source)
+		return len(result.Items)
+	}
+
+	assert.Equal(t, 1, count("value < 6"))
+	assert.Equal(t, 2, count("value between 6 and 7.5"))
+	assert.Equal(t, 2, count("value in (6, 8)"))
+	assert.Equal(t, 3, count("value != 6"), "booleans never match a number")
+	assert.Equal(t, 1, count("value = true"))
+	assert.Equal(t, 2, count(`notes contains "LATE"`))
+	assert.Equal(t, 1, count(`value < 7 and notes contains "late"`))
+
+	// The predicate still applies when the value is left out of the projection.
+	predicate, err := measurement.ParsePredicate("value >= 7.5")
+	require.NoError(t, err)
+	projected, err := measurementService.ListMeasurementsByParameter(ctx, sleep.ID, measurement.ListOptions{
+		Filter: measurement.Filter{Predicate: predicate},
+		Fields: []measurement.Field{measurement.FieldNotes},
+	}, pagination.Page{})
+	require.NoError(t, err)
+	assert.Len(t, projected.Items, 2)
+}
+
+func TestListMeasurements_PredicateConvertedFor(t *testing.T) {
+	parameterRepository := parameter.NewInMemoryRepository()
+	parameterService := parameter.NewService(parameterRepository)
+
+	measurementRepository := measurement.NewInMemoryRepository()
+	measurementService := measurement.NewService(measurementRepository, parameterService)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	weight, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+	sleep, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "h",
+	})
+	require.NoError(t, err)
+
+	for _, input := range []measurement.CreateMeasurementInput{
+		{ParameterID: weight.ID, Value: 68.0},
+		{ParameterID: weight.ID, Value: 72.0},
+		{ParameterID: sleep.ID, Value: 6.0},
+		{ParameterID: sleep.ID, Value: 200.0},
+	} {
+		_, err = measurementService.CreateMeasurement(ctx, input)
+		require.NoError(t, err)
+	}
+
+	// The operand is written in pounds, which only applies to the weight.
+	predicate, err := measurement.ParsePredicate("value > 155")
+	require.NoError(t, err)
+	predicate, err = predicate.ConvertFor(weight.ID, func(value float64) (float64, error) {
+		return units.Convert(value, "lb", "kg")
+	})
+	require.NoError(t, err)
+
+	result, err := measurementService.ListMeasurementsByUser(ctx, userID, measurement.ListOptions{
+		Filter: measurement.Filter{Predicate: predicate},
+	}, pagination.Page{})
+	require.NoError(t, err)
+
+	var values []float64
+	for _, m := range result.Items {
+		floatMeasurement, ok := m.(*measurement.FloatMeasurement)
+		require.True(t, ok)
+		values = append(values, floatMeasurement.Value)
+	}
+	assert.ElementsMatch(t, []float64{72.0, 200.0}, values)
+	assert.Equal(t, "value > 155", predicate.String())
+}
+
+func TestDeleteMeasurementsByParameter(t *testing.T) {
+	ctx := context.Background()
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+
+	userID := uuid.New()
+	weight, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+	steps, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Steps",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	for day := range 5 {
+		for _, parameterID := range []uuid.UUID{weight.ID, steps.ID} {
+			_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+				ParameterID: parameterID,
+				Value:       float64(day),
+				Timestamp:   start.AddDate(0, 0, day),
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	from := start.AddDate(0, 0, 1)
+	to := start.AddDate(0, 0, 3)
+	input := measurement.DeleteMeasurementsInput{ParameterID: weight.ID, From: &from, To: &to, DryRun: true}
+
+	count, err := measurementService.DeleteMeasurementsByParameter(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	listed, err := measurementService.ListMeasurementsByParameter(
+		ctx, weight.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, listed.Items, 5, "a dry run deletes nothing")
+
+	input.DryRun = false
+	count, err = measurementService.DeleteMeasurementsByParameter(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	listed, err = measurementService.ListMeasurementsByParameter(
+		ctx, weight.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, listed.Items, 2)
+
+	count, err = measurementService.DeleteMeasurementsByParameter(ctx, input)
+	require.NoError(t, err)
+	assert.Zero(t, count, "running the delete again is harmless")
+
+	listed, err = measurementService.ListMeasurementsByParameter(
+		ctx, steps.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	require.NoError(t, err)
+	assert.Len(t, listed.Items, 5, "other parameters are untouched")
+
+	_, err = measurementService.DeleteMeasurementsByParameter(ctx, measurement.DeleteMeasurementsInput{
+		ParameterID: weight.ID,
+		From:        &to,
+		To:          &from,
+	})
+	require.ErrorIs(t, err, measurement.ErrInvalidTimeRange)
+
+	_, err = measurementService.DeleteMeasurementsByParameter(ctx, measurement.DeleteMeasurementsInput{
+		ParameterID: uuid.New(),
+	})
+	require.ErrorIs(t, err, parameter.ErrParameterNotFound)
+}
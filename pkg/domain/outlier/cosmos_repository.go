@@ -0,0 +1,206 @@
+package outlier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName  = "correlateapp"
+	containerName = "OutlierFlags"
+	partitionKey  = "/parameterId"
+)
+
+type CosmosFlagRepository struct {
+	client    *azcosmos.Client
+	container *azcosmos.ContainerClient
+}
+
+func NewCosmosFlagRepository(connectionString string) (*CosmosFlagRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for outlier flag repository: %w", err)
+	}
+
+	container, err := client.NewContainer(databaseName, containerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for outlier flag repository: %w", err)
+	}
+
+	return &CosmosFlagRepository{
+		client:    client,
+		container: container,
+	}, nil
+}
+
+func (r *CosmosFlagRepository) CreateFlag(ctx context.Context, flag *Flag) (*Flag, error) {
+	flagJSON, err := json.Marshal(NewCosmosFlag(flag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outlier flag: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(flag.ParameterID.String())
+
+	_, err = r.container.CreateItem(ctx, pk, flagJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outlier flag in Cosmos DB: %w", err)
+	}
+
+	return flag, nil
+}
+
+func (r *CosmosFlagRepository) GetFlagByID(ctx context.Context, id uuid.UUID) (*Flag, error) {
+	query := "SELECT * FROM flags f WHERE f.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	flags, err := r.queryFlags(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(flags) == 0 {
+		return nil, ErrFlagNotFound
+	}
+
+	return flags[0], nil
+}
+
+func (r *CosmosFlagRepository) ListFlagsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Flag, error) {
+	query := "SELECT * FROM flags f WHERE f.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+
+	return r.queryFlags(ctx, query, params)
+}
+
+func (r *CosmosFlagRepository) ListFlagsByUser(ctx context.Context, userID uuid.UUID) ([]*Flag, error) {
+	query := "SELECT * FROM flags f WHERE f.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryFlags(ctx, query, params)
+}
+
+func (r *CosmosFlagRepository) ListFlagsByMeasurement(
+	ctx context.Context,
+	measurementID uuid.UUID,
+) ([]*Flag, error) {
+	query := "SELECT * FROM flags f WHERE f.measurementId = @measurementID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@measurementID", Value: measurementID.String()},
+	}
+
+	return r.queryFlags(ctx, query, params)
+}
+
+func (r *CosmosFlagRepository) UpdateFlag(ctx context.Context, flag *Flag) (*Flag, error) {
+	flagJSON, err := json.Marshal(NewCosmosFlag(flag))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outlier flag: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(flag.ParameterID.String())
+
+	_, err = r.container.ReplaceItem(ctx, pk, flag.ID.String(), flagJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update outlier flag in Cosmos DB: %w", err)
+	}
+
+	return flag, nil
+}
+
+func (r *CosmosFlagRepository) DeleteFlag(ctx context.Context, flag *Flag) error {
+	pk := azcosmos.NewPartitionKeyString(flag.ParameterID.String())
+
+	_, err := r.container.DeleteItem(ctx, pk, flag.ID.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete outlier flag from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosFlagRepository) queryFlags(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Flag, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	flags := []*Flag{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosFlag CosmosFlag
+			if err := json.Unmarshal(item, &cosmosFlag); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal outlier flag: %w", err)
+			}
+			flags = append(flags, NewFlag(&cosmosFlag))
+		}
+	}
+
+	return flags, nil
+}
+
+type CosmosFlag struct {
+	ID            uuid.UUID `json:"id"`
+	UserID        uuid.UUID `json:"userId"`
+	ParameterID   uuid.UUID `json:"parameterId"`
+	MeasurementID uuid.UUID `json:"measurementId"`
+	Method        Method    `json:"method"`
+	Threshold     float64   `json:"threshold"`
+	Score         float64   `json:"score"`
+	Value         float64   `json:"value"`
+	Timestamp     time.Time `json:"timestamp"`
+	Status        Status    `json:"status"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+func NewCosmosFlag(flag *Flag) *CosmosFlag {
+	return &CosmosFlag{
+		ID:            flag.ID,
+		UserID:        flag.UserID,
+		ParameterID:   flag.ParameterID,
+		MeasurementID: flag.MeasurementID,
+		Method:        flag.Method,
+		Threshold:     flag.Threshold,
+		Score:         flag.Score,
+		Value:         flag.Value,
+		Timestamp:     flag.Timestamp,
+		Status:        flag.Status,
+		CreatedAt:     flag.CreatedAt,
+		UpdatedAt:     flag.UpdatedAt,
+	}
+}
+
+func NewFlag(cosmosFlag *CosmosFlag) *Flag {
+	return &Flag{
+		ID:            cosmosFlag.ID,
+		UserID:        cosmosFlag.UserID,
+		ParameterID:   cosmosFlag.ParameterID,
+		MeasurementID: cosmosFlag.MeasurementID,
+		Method:        cosmosFlag.Method,
+		Threshold:     cosmosFlag.Threshold,
+		Score:         cosmosFlag.Score,
+		Value:         cosmosFlag.Value,
+		Timestamp:     cosmosFlag.Timestamp,
+		Status:        cosmosFlag.Status,
+		CreatedAt:     cosmosFlag.CreatedAt,
+		UpdatedAt:     cosmosFlag.UpdatedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package outlier
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const (
+	minSampleSize = 3
+
+	// madScale makes the MAD consistent with the standard deviation of a normal distribution.
+	madScale = 0.6745
+	// meanAbsDevScale is used instead when more than half of the values are identical and the MAD is zero.
+	meanAbsDevScale = 0.7979
+)
+
+// Score returns an outlier score per value. A value is an outlier when its
+// score exceeds the threshold for the chosen method.
+func Score(values []float64, method Method) ([]float64, error) {
+	scores := make([]float64, len(values))
+	if len(values) < minSampleSize {
+		return scores, nil
+	}
+
+	switch method {
+	case MethodZScore:
+		mean := stats.Mean(values)
+		sd := stats.StdDev(values)
+		if sd == 0 {
+			return scores, nil
+		}
+		for i, v := range values {
+			scores[i] = math.Abs(v-mean) / sd
+		}
+	case MethodMAD:
+		median := stats.Median(values)
+		scale := stats.MedianAbsoluteDeviation(values) / madScale
+		if scale == 0 {
+			scale = meanAbsDeviation(values, median) / meanAbsDevScale
+		}
+		if scale == 0 {
+			return scores, nil
+		}
+		for i, v := range values {
+			scores[i] = math.Abs(v-median) / scale
+		}
+	case MethodIQR:
+		// The score is the distance from the quartile box in IQR units, so that
+		// a threshold of k corresponds to Tukey's k × IQR fences.
+		q1 := stats.Quantile(values, 0.25)
+		q3 := stats.Quantile(values, 0.75)
+		iqr := q3 - q1
+		if iqr == 0 {
+			return scores, nil
+		}
+		for i, v := range values {
+			switch {
+			case v < q1:
+				scores[i] = (q1 - v) / iqr
+			case v > q3:
+				scores[i] = (v - q3) / iqr
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported outlier method: %s", method)
+	}
+
+	return scores, nil
+}
+
+func meanAbsDeviation(values []float64, center float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += math.Abs(v - center)
+	}
+
+	return sum / float64(len(values))
+}
@@ -0,0 +1,104 @@
+package outlier
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu    sync.RWMutex
+	flags map[uuid.UUID]*Flag
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		flags: make(map[uuid.UUID]*Flag),
+	}
+}
+
+func (r *InMemoryRepository) CreateFlag(_ context.Context, flag *Flag) (*Flag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.flags[flag.ID] = flag
+
+	return flag, nil
+}
+
+func (r *InMemoryRepository) GetFlagByID(_ context.Context, id uuid.UUID) (*Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	flag, ok := r.flags[id]
+	if !ok {
+		return nil, ErrFlagNotFound
+	}
+
+	return flag, nil
+}
+
+func (r *InMemoryRepository) ListFlagsByParameter(_ context.Context, parameterID uuid.UUID) ([]*Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var flags []*Flag
+	for _, flag := range r.flags {
+		if flag.ParameterID == parameterID {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags, nil
+}
+
+func (r *InMemoryRepository) ListFlagsByUser(_ context.Context, userID uuid.UUID) ([]*Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var flags []*Flag
+	for _, flag := range r.flags {
+		if flag.UserID == userID {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags, nil
+}
+
+func (r *InMemoryRepository) ListFlagsByMeasurement(_ context.Context, measurementID uuid.UUID) ([]*Flag, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var flags []*Flag
+	for _, flag := range r.flags {
+		if flag.MeasurementID == measurementID {
+			flags = append(flags, flag)
+		}
+	}
+
+	return flags, nil
+}
+
+func (r *InMemoryRepository) UpdateFlag(_ context.Context, flag *Flag) (*Flag, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.flags[flag.ID]; !ok {
+		return nil, ErrFlagNotFound
+	}
+
+	r.flags[flag.ID] = flag
+
+	return flag, nil
+}
+
+func (r *InMemoryRepository) DeleteFlag(_ context.Context, flag *Flag) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.flags, flag.ID)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package outlier
+
+import (
+	"context"
+	"log"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/google/uuid"
+)
+
+// MeasurementService deletes outlier flags along with their measurements.
+type MeasurementService struct {
+	measurement.Service
+	outlierService Service
+}
+
+func NewMeasurementService(measurementService measurement.Service, outlierService Service) measurement.Service {
+	return &MeasurementService{
+		Service:        measurementService,
+		outlierService: outlierService,
+	}
+}
+
+// DeleteMeasurement deletes the measurement and then its flags.
+func (s *MeasurementService) DeleteMeasurement(ctx context.Context, id uuid.UUID) error {
+	if err := s.Service.DeleteMeasurement(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.outlierService.DeleteFlagsByMeasurement(ctx, id); err != nil {
+		log.Printf("failed to delete outlier flags for measurement %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// DeleteMeasurementsByParameter deletes the measurements and then the flags in
+// their range. A delete that stops part way reports how many measurements it
+// deleted and keeps the flags, some of which belong to measurements it kept.
+func (s *MeasurementService) DeleteMeasurementsByParameter(
+	ctx context.Context,
+	input measurement.DeleteMeasurementsInput,
+) (int, error) {
+	count, err := s.Service.DeleteMeasurementsByParameter(ctx, input)
+	if err != nil {
+		return count, err
+	}
+
+	if input.DryRun {
+		return count, nil
+	}
+
+	if err := s.outlierService.DeleteFlagsByParameter(ctx, input.ParameterID, input.From, input.To); err != nil {
+		log.Printf("failed to delete outlier flags for parameter %s: %v", input.ParameterID, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,54 @@
+package outlier
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Method string
+
+const (
+	MethodZScore Method = "zscore"
+	MethodMAD    Method = "mad"
+	MethodIQR    Method = "iqr"
+)
+
+// DefaultThreshold returns the conventional sensitivity for a method: three
+// standard deviations for z-scores, a modified z-score of 3.5 for MAD and
+// Tukey's 1.5 × IQR fences.
+func DefaultThreshold(method Method) float64 {
+	switch method {
+	case MethodZScore:
+		return 3
+	case MethodMAD:
+		return 3.5
+	case MethodIQR:
+		return 1.5
+	default:
+		return 0
+	}
+}
+
+type Status string
+
+const (
+	StatusFlagged   Status = "flagged"
+	StatusConfirmed Status = "confirmed"
+	StatusDismissed Status = "dismissed"
+)
+
+type Flag struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ParameterID   uuid.UUID
+	MeasurementID uuid.UUID
+	Method        Method
+	Threshold     float64
+	Score         float64
+	Value         float64
+	Timestamp     time.Time
+	Status        Status
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
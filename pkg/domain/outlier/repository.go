@@ -0,0 +1,22 @@
+package outlier
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFlagNotFound = errors.New("outlier flag not found")
+)
+
+type Repository interface {
+	CreateFlag(ctx context.Context, flag *Flag) (*Flag, error)
+	GetFlagByID(ctx context.Context, id uuid.UUID) (*Flag, error)
+	ListFlagsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Flag, error)
+	ListFlagsByUser(ctx context.Context, userID uuid.UUID) ([]*Flag, error)
+	ListFlagsByMeasurement(ctx context.Context, measurementID uuid.UUID) ([]*Flag, error)
+	UpdateFlag(ctx context.Context, flag *Flag) (*Flag, error)
+	DeleteFlag(ctx context.Context, flag *Flag) error
+}
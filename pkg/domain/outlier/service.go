@@ -0,0 +1,33 @@
+package outlier
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	DetectOutliers(ctx context.Context, input DetectOutliersInput) ([]*Flag, error)
+	ListFlagsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Flag, error)
+	ListFlagsByUser(ctx context.Context, userID uuid.UUID) ([]*Flag, error)
+	ReviewFlag(ctx context.Context, input ReviewFlagInput) (*Flag, error)
+	// DeleteFlagsByMeasurement deletes the flags raised on a measurement.
+	DeleteFlagsByMeasurement(ctx context.Context, measurementID uuid.UUID) error
+	// DeleteFlagsByParameter deletes a parameter's flags on measurements taken
+	// in the range, inclusive. Nil leaves that end open.
+	DeleteFlagsByParameter(ctx context.Context, parameterID uuid.UUID, from, to *time.Time) error
+}
+
+type DetectOutliersInput struct {
+	ParameterID uuid.UUID
+	Method      Method
+	// Threshold is the score above which a measurement is flagged.
+	// Zero means the default threshold for the method.
+	Threshold float64
+}
+
+type ReviewFlagInput struct {
+	ID     uuid.UUID
+	Status Status
+}
@@ -0,0 +1,206 @@
+package outlier
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+type ServiceImpl struct {
+	repo               Repository
+	parameterService   parameter.Service
+	measurementService measurement.Service
+}
+
+var (
+	ErrInvalidReviewStatus = errors.New("outlier flag can only be confirmed or dismissed")
+	ErrInvalidThreshold    = errors.New("outlier threshold must be positive")
+)
+
+func NewService(
+	repo Repository,
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+) Service {
+	return &ServiceImpl{
+		repo:               repo,
+		parameterService:   parameterService,
+		measurementService: measurementService,
+	}
+}
+
+func (s *ServiceImpl) DetectOutliers(ctx context.Context, input DetectOutliersInput) ([]*Flag, error) {
+	if input.Threshold < 0 {
+		return nil, ErrInvalidThreshold
+	}
+
+	threshold := input.Threshold
+	if threshold == 0 {
+		threshold = DefaultThreshold(input.Method)
+	}
+
+	flagParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	listed, err := s.measurementService.ListMeasurementsByParameter(
+		ctx, flagParameter.ID, measurement.ListOptions{}, pagination.Page{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	measurements := listed.Items
+
+	var floatMeasurements []*measurement.FloatMeasurement
+	var values []float64
+	for _, m := range measurements {
+		if floatMeasurement, ok := m.(*measurement.FloatMeasurement); ok {
+			floatMeasurements = append(floatMeasurements, floatMeasurement)
+			values = append(values, floatMeasurement.GetValue())
+		}
+	}
+
+	scores, err := Score(values, input.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	existingFlags, err := s.repo.ListFlagsByParameter(ctx, flagParameter.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	flagged := make(map[uuid.UUID]bool, len(existingFlags))
+	for _, flag := range existingFlags {
+		flagged[flag.MeasurementID] = true
+	}
+
+	createdFlags := []*Flag{}
+	for i, m := range floatMeasurements {
+		if scores[i] <= threshold || flagged[m.GetID()] {
+			continue
+		}
+
+		flag := &Flag{
+			ID:            uuid.New(),
+			UserID:        m.GetUserID(),
+			ParameterID:   m.GetParameterID(),
+			MeasurementID: m.GetID(),
+			Method:        input.Method,
+			Threshold:     threshold,
+			Score:         scores[i],
+			Value:         m.GetValue(),
+			Timestamp:     m.GetTimestamp(),
+			Status:        StatusFlagged,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+
+		createdFlag, createFlagErr := s.repo.CreateFlag(ctx, flag)
+		if createFlagErr != nil {
+			return nil, createFlagErr
+		}
+
+		createdFlags = append(createdFlags, createdFlag)
+	}
+
+	sortFlags(createdFlags)
+
+	return createdFlags, nil
+}
+
+func (s *ServiceImpl) ListFlagsByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Flag, error) {
+	flags, err := s.repo.ListFlagsByParameter(ctx, parameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	sortFlags(flags)
+
+	return flags, nil
+}
+
+func (s *ServiceImpl) ListFlagsByUser(ctx context.Context, userID uuid.UUID) ([]*Flag, error) {
+	flags, err := s.repo.ListFlagsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sortFlags(flags)
+
+	return flags, nil
+}
+
+func (s *ServiceImpl) ReviewFlag(ctx context.Context, input ReviewFlagInput) (*Flag, error) {
+	if input.Status != StatusConfirmed && input.Status != StatusDismissed {
+		return nil, ErrInvalidReviewStatus
+	}
+
+	flag, err := s.repo.GetFlagByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	flag.Status = input.Status
+	flag.UpdatedAt = time.Now()
+
+	updatedFlag, err := s.repo.UpdateFlag(ctx, flag)
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedFlag, nil
+}
+
+func (s *ServiceImpl) DeleteFlagsByMeasurement(ctx context.Context, measurementID uuid.UUID) error {
+	flags, err := s.repo.ListFlagsByMeasurement(ctx, measurementID)
+	if err != nil {
+		return err
+	}
+
+	for _, flag := range flags {
+		if err := s.repo.DeleteFlag(ctx, flag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) DeleteFlagsByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	from, to *time.Time,
+) error {
+	flags, err := s.repo.ListFlagsByParameter(ctx, parameterID)
+	if err != nil {
+		return err
+	}
+
+	for _, flag := range flags {
+		if from != nil && flag.Timestamp.Before(*from) {
+			continue
+		}
+		if to != nil && flag.Timestamp.After(*to) {
+			continue
+		}
+		if err := s.repo.DeleteFlag(ctx, flag); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sortFlags(flags []*Flag) {
+	sort.Slice(flags, func(i, j int) bool {
+		return flags[i].Timestamp.Before(flags[j].Timestamp)
+	})
+}
@@ -0,0 +1,248 @@
+package outlier_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectOutliers_Methods(t *testing.T) {
+	// With n values no z-score can exceed (n-1)/sqrt(n), so the sample must be
+	// large enough for a single typo to stand out.
+	values := []float64{
+		72.1, 72.4, 71.9, 72.0, 720, 71.8, 72.2, 72.3, 72.0, 71.7,
+		72.5, 72.1, 71.6, 72.0, 72.2, 71.9, 72.4, 72.1, 71.8, 72.0,
+	}
+
+	for _, method := range []outlier.Method{outlier.MethodZScore, outlier.MethodMAD, outlier.MethodIQR} {
+		t.Run(string(method), func(t *testing.T) {
+			parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+			measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+			svc := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+			param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+				UserID:   uuid.New(),
+				Name:     "Weight",
+				DataType: parameter.DataTypeFloat,
+				Unit:     "kg",
+			})
+
+			start := time.Date(2025, time.January, 1, 7, 30, 0, 0, time.UTC)
+			for i, v := range values {
+				domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), v)
+			}
+
+			flags, err := svc.DetectOutliers(context.Background(), outlier.DetectOutliersInput{
+				ParameterID: param.ID,
+				Method:      method,
+			})
+			require.NoError(t, err)
+			require.Len(t, flags, 1)
+			assert.InDelta(t, 720.0, flags[0].Value, 0.0001)
+			assert.Equal(t, outlier.StatusFlagged, flags[0].Status)
+			assert.InDelta(t, outlier.DefaultThreshold(method), flags[0].Threshold, 0.0001)
+		})
+	}
+}
+
+func TestDetectOutliers_DoesNotReflagReviewedMeasurements(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	svc := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+
+	start := time.Date(2025, time.January, 1, 7, 30, 0, 0, time.UTC)
+	for i, v := range []float64{72.1, 72.4, 71.9, 720, 72.0, 71.8} {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), v)
+	}
+	input := outlier.DetectOutliersInput{ParameterID: param.ID, Method: outlier.MethodMAD}
+
+	flags, err := svc.DetectOutliers(context.Background(), input)
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+
+	_, err = svc.ReviewFlag(context.Background(), outlier.ReviewFlagInput{
+		ID:     flags[0].ID,
+		Status: outlier.StatusDismissed,
+	})
+	require.NoError(t, err)
+
+	flags, err = svc.DetectOutliers(context.Background(), input)
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+
+	allFlags, err := svc.ListFlagsByParameter(context.Background(), param.ID)
+	require.NoError(t, err)
+	require.Len(t, allFlags, 1)
+	assert.Equal(t, outlier.StatusDismissed, allFlags[0].Status)
+}
+
+func TestDetectOutliers_Sensitivity(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	svc := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+
+	start := time.Date(2025, time.January, 1, 7, 30, 0, 0, time.UTC)
+	for i, v := range []float64{10, 11, 12, 10, 11, 13, 10, 11} {
+		domaintest.LogValue(t, measurementService, param.ID, start.AddDate(0, 0, i), v)
+	}
+
+	flags, err := svc.DetectOutliers(context.Background(), outlier.DetectOutliersInput{
+		ParameterID: param.ID,
+		Method:      outlier.MethodZScore,
+		Threshold:   1.5,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+	assert.InDelta(t, 13.0, flags[0].Value, 0.0001)
+}
+
+func TestReviewFlag_InvalidStatus(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	svc := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+
+	_, err := svc.ReviewFlag(context.Background(), outlier.ReviewFlagInput{
+		ID:     uuid.New(),
+		Status: outlier.StatusFlagged,
+	})
+	require.ErrorIs(t, err, outlier.ErrInvalidReviewStatus)
+}
+
+func TestMeasurementService_DeletesFlagsWithMeasurements(t *testing.T) {
+	ctx := context.Background()
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	deletingService := outlier.NewMeasurementService(measurementService, outlierService)
+
+	createdParam, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2025, time.January, 1, 7, 30, 0, 0, time.UTC)
+	values := []float64{72.1, 72.4, 720, 71.9, 72.0, 71.8, 72.2, 7.2, 72.3, 72.0}
+	for i, v := range values {
+		_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+			ParameterID: createdParam.ID,
+			Value:       v,
+			Timestamp:   start.AddDate(0, 0, i),
+		})
+		require.NoError(t, err)
+	}
+
+	flags, err := outlierService.DetectOutliers(ctx, outlier.DetectOutliersInput{
+		ParameterID: createdParam.ID,
+		Method:      outlier.MethodMAD,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 2)
+
+	require.NoError(t, deletingService.DeleteMeasurement(ctx, flags[0].MeasurementID))
+
+	remaining, err := outlierService.ListFlagsByParameter(ctx, createdParam.ID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, flags[1].ID, remaining[0].ID)
+
+	from := start.AddDate(0, 0, 5)
+	deleted, err := deletingService.DeleteMeasurementsByParameter(ctx, measurement.DeleteMeasurementsInput{
+		ParameterID: createdParam.ID,
+		From:        &from,
+		DryRun:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, deleted)
+
+	remaining, err = outlierService.ListFlagsByParameter(ctx, createdParam.ID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1, "a dry run keeps the flags")
+
+	_, err = deletingService.DeleteMeasurementsByParameter(ctx, measurement.DeleteMeasurementsInput{
+		ParameterID: createdParam.ID,
+		From:        &from,
+	})
+	require.NoError(t, err)
+
+	remaining, err = outlierService.ListFlagsByParameter(ctx, createdParam.ID)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+// partialDeleteService stops a bulk delete after some of the measurements.
+type partialDeleteService struct {
+	measurement.Service
+}
+
+func (partialDeleteService) DeleteMeasurementsByParameter(
+	context.Context,
+	measurement.DeleteMeasurementsInput,
+) (int, error) {
+	return 3, errors.New("connection reset")
+}
+
+func TestMeasurementService_PartialDeleteKeepsFlags(t *testing.T) {
+	ctx := context.Background()
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	deletingService := outlier.NewMeasurementService(partialDeleteService{measurementService}, outlierService)
+
+	createdParam, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2025, time.January, 1, 7, 30, 0, 0, time.UTC)
+	for i, v := range []float64{72.1, 72.4, 720, 71.9, 72.0, 71.8} {
+		_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+			ParameterID: createdParam.ID,
+			Value:       v,
+			Timestamp:   start.AddDate(0, 0, i),
+		})
+		require.NoError(t, err)
+	}
+
+	flags, err := outlierService.DetectOutliers(ctx, outlier.DetectOutliersInput{
+		ParameterID: createdParam.ID,
+		Method:      outlier.MethodMAD,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+
+	deleted, err := deletingService.DeleteMeasurementsByParameter(ctx, measurement.DeleteMeasurementsInput{
+		ParameterID: createdParam.ID,
+	})
+	require.Error(t, err)
+	assert.Equal(t, 3, deleted)
+
+	remaining, err := outlierService.ListFlagsByParameter(ctx, createdParam.ID)
+	require.NoError(t, err)
+	assert.Len(t, remaining, 1)
+}
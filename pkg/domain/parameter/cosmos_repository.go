@@ -3,11 +3,14 @@ package parameter
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/internal/cosmosutil"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
@@ -47,10 +50,11 @@ func (r *CosmosParameterRepository) CreateParameter(ctx context.Context, param *
 
 	pk := azcosmos.NewPartitionKeyString(param.UserID.String())
 
-	_, err = r.container.CreateItem(ctx, pk, paramJSON, nil)
+	resp, err := r.container.CreateItem(ctx, pk, paramJSON, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create parameter in Cosmos DB: %w", err)
 	}
+	param.ETag = string(resp.ETag)
 
 	return param, nil
 }
@@ -80,35 +84,62 @@ func (r *CosmosParameterRepository) GetParameterByID(ctx context.Context, id uui
 		}
 	}
 
-	return nil, errors.New("parameter not found")
+	return nil, ErrParameterNotFound
 }
 
-func (r *CosmosParameterRepository) ListParametersByUser(ctx context.Context, userID uuid.UUID) ([]*Parameter, error) {
-	query := "SELECT * FROM parameters p WHERE p.userId = @userID"
+func (r *CosmosParameterRepository) ListParametersByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[*Parameter], error) {
+	query := selectParameters(options) + " WHERE p.userId = @userID" + orderParameters(options.Sort)
 	params := []azcosmos.QueryParameter{
 		{Name: "@userID", Value: userID.String()},
 	}
 
-	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
-	pager := r.container.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+	queryOptions := &azcosmos.QueryOptions{
+		QueryParameters: params,
+		PageSizeHint:    page.SizeHint(),
+	}
+	if page.Cursor != "" {
+		token, err := pagination.DecodeCursor(page.Cursor)
+		if err != nil {
+			return pagination.Result[*Parameter]{}, err
+		}
+		queryOptions.ContinuationToken = &token
+	}
+	// Parameters are partitioned by user, so the listing stays in one
+	// partition, where Cosmos DB can order it.
+	pk := azcosmos.NewPartitionKeyString(userID.String())
+	pager := r.container.NewQueryItemsPager(query, pk, queryOptions)
 
-	parameters := []*Parameter{}
+	result := pagination.Result[*Parameter]{Items: []*Parameter{}}
 	for pager.More() {
 		resp, nextPageErr := pager.NextPage(ctx)
 		if nextPageErr != nil {
-			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+			return pagination.Result[*Parameter]{}, fmt.Errorf("query failed: %w", nextPageErr)
 		}
 
 		for _, item := range resp.Items {
 			var cosmosParameter CosmosParameter
 			if err := json.Unmarshal(item, &cosmosParameter); err != nil {
-				return nil, fmt.Errorf("failed to unmarshal parameter: %w", err)
+				return pagination.Result[*Parameter]{}, fmt.Errorf("failed to unmarshal parameter: %w", err)
 			}
-			parameters = append(parameters, NewParameter(&cosmosParameter))
+			result.Items = append(result.Items, NewParameter(&cosmosParameter))
+		}
+
+		// A limited listing stops after one Cosmos DB page and hands its
+		// continuation token back to the client.
+		if page.Limit > 0 {
+			if resp.ContinuationToken != nil {
+				result.Next = pagination.EncodeCursor(*resp.ContinuationToken)
+			}
+			break
 		}
 	}
 
-	return parameters, nil
+	return result, nil
 }
 
 func (r *CosmosParameterRepository) UpdateParameter(ctx context.Context, param *Parameter) (*Parameter, error) {
@@ -119,15 +150,22 @@ func (r *CosmosParameterRepository) UpdateParameter(ctx context.Context, param *
 
 	pk := azcosmos.NewPartitionKeyString(param.UserID.String())
 
-	_, err = r.container.ReplaceItem(ctx, pk, param.ID.String(), paramJSON, nil)
+	resp, err := r.container.ReplaceItem(ctx, pk, param.ID.String(), paramJSON, cosmosutil.IfMatchOptions(param.ETag))
 	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusPreconditionFailed) {
+			return nil, ErrVersionConflict
+		}
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return nil, ErrParameterNotFound
+		}
 		return nil, fmt.Errorf("failed to update parameter in Cosmos DB: %w", err)
 	}
+	param.ETag = string(resp.ETag)
 
 	return param, nil
 }
 
-func (r *CosmosParameterRepository) DeleteParameter(ctx context.Context, id uuid.UUID) error {
+func (r *CosmosParameterRepository) DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error {
 	// First, retrieve the parameter to get its UserID (required for partition key)
 	param, err := r.GetParameterByID(ctx, id)
 	if err != nil {
@@ -136,23 +174,69 @@ func (r *CosmosParameterRepository) DeleteParameter(ctx context.Context, id uuid
 
 	pk := azcosmos.NewPartitionKeyString(param.UserID.String())
 
-	_, err = r.container.DeleteItem(ctx, pk, id.String(), nil)
+	_, err = r.container.DeleteItem(ctx, pk, id.String(), cosmosutil.IfMatchOptions(ifMatch))
 	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusPreconditionFailed) {
+			return ErrVersionConflict
+		}
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return ErrParameterNotFound
+		}
 		return fmt.Errorf("failed to delete parameter from Cosmos DB: %w", err)
 	}
 
 	return nil
 }
 
+// selectParameters returns the SELECT clause of a listing. The field names
+// match the document properties, so projections are pushed down to Cosmos DB.
+func selectParameters(options ListOptions) string {
+	if len(options.Fields) == 0 {
+		return "SELECT * FROM parameters p"
+	}
+
+	columns := make([]string, 0, len(allFields))
+	for _, field := range allFields {
+		if options.loads(field) {
+			columns = append(columns, "p."+string(field))
+		}
+	}
+
+	return "SELECT " + strings.Join(columns, ", ") + " FROM parameters p"
+}
+
+// orderParameters returns the ORDER BY clause of a listing.
+func orderParameters(sort Sort) string {
+	if sort == (Sort{}) {
+		return ""
+	}
+
+	field := sort.Field
+	if field == "" {
+		field = SortByCreatedAt
+	}
+	direction := "ASC"
+	if sort.Descending {
+		direction = "DESC"
+	}
+
+	return " ORDER BY p." + string(field) + " " + direction
+}
+
 type CosmosParameter struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"userId"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	DataType    DataType  `json:"dataType"`
-	Unit        string    `json:"unit"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"userId"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	DataType    DataType       `json:"dataType"`
+	Unit        string         `json:"unit"`
+	Formula     string         `json:"formula,omitempty"`
+	Schedule    []time.Weekday `json:"schedule,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+	// ETag is the system _etag property. It is only read; Cosmos DB sets it
+	// on every write.
+	ETag string `json:"_etag,omitempty"`
 }
 
 func NewCosmosParameter(parameter *Parameter) *CosmosParameter {
@@ -163,6 +247,8 @@ func NewCosmosParameter(parameter *Parameter) *CosmosParameter {
 		Description: parameter.Description,
 		DataType:    parameter.DataType,
 		Unit:        parameter.Unit,
+		Formula:     parameter.Formula,
+		Schedule:    parameter.Schedule,
 		CreatedAt:   parameter.CreatedAt,
 		UpdatedAt:   parameter.UpdatedAt,
 	}
@@ -176,7 +262,10 @@ func NewParameter(cosmosParameter *CosmosParameter) *Parameter {
 		Description: cosmosParameter.Description,
 		DataType:    cosmosParameter.DataType,
 		Unit:        cosmosParameter.Unit,
+		Formula:     cosmosParameter.Formula,
+		Schedule:    cosmosParameter.Schedule,
 		CreatedAt:   cosmosParameter.CreatedAt,
 		UpdatedAt:   cosmosParameter.UpdatedAt,
+		ETag:        cosmosParameter.ETag,
 	}
 }
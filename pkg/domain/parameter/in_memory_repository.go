@@ -3,31 +3,39 @@ package parameter
 import (
 	"context"
 	"errors"
+	"slices"
+	"strconv"
 	"sync"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
+// InMemoryRepository counts the writes of each parameter to stand in for
+// Cosmos DB ETags.
 type InMemoryRepository struct {
 	mu         sync.RWMutex
 	parameters map[uuid.UUID]*Parameter
+	versions   map[uuid.UUID]int
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
 		parameters: make(map[uuid.UUID]*Parameter),
+		versions:   make(map[uuid.UUID]int),
 	}
 }
 
 var (
 	ErrParameterNotFound = errors.New("parameter not found")
+	ErrVersionConflict   = errors.New("parameter was changed since it was read")
 )
 
 func (r *InMemoryRepository) CreateParameter(_ context.Context, parameter *Parameter) (*Parameter, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.parameters[parameter.ID] = parameter
+	r.store(parameter)
 
 	return parameter, nil
 }
@@ -44,18 +52,30 @@ func (r *InMemoryRepository) GetParameterByID(_ context.Context, id uuid.UUID) (
 	return parameter, nil
 }
 
-func (r *InMemoryRepository) ListParametersByUser(_ context.Context, userID uuid.UUID) ([]*Parameter, error) {
+func (r *InMemoryRepository) ListParametersByUser(
+	_ context.Context,
+	userID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[*Parameter], error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	var parameters []*Parameter
+	parameters := []*Parameter{}
 	for _, parameter := range r.parameters {
 		if parameter.UserID == userID {
 			parameters = append(parameters, parameter)
 		}
 	}
 
-	return parameters, nil
+	slices.SortFunc(parameters, func(a, b *Parameter) int {
+		return compareParameters(a, b, options.Sort)
+	})
+	for i, parameter := range parameters {
+		parameters[i] = options.project(parameter)
+	}
+
+	return pagination.Slice(parameters, page)
 }
 
 func (r *InMemoryRepository) UpdateParameter(_ context.Context, param *Parameter) (*Parameter, error) {
@@ -65,21 +85,41 @@ func (r *InMemoryRepository) UpdateParameter(_ context.Context, param *Parameter
 	if _, ok := r.parameters[param.ID]; !ok {
 		return nil, ErrParameterNotFound
 	}
+	if param.ETag != "" && param.ETag != r.etag(param.ID) {
+		return nil, ErrVersionConflict
+	}
 
-	r.parameters[param.ID] = param
+	r.store(param)
 
 	return param, nil
 }
 
-func (r *InMemoryRepository) DeleteParameter(_ context.Context, id uuid.UUID) error {
+func (r *InMemoryRepository) DeleteParameter(_ context.Context, id uuid.UUID, ifMatch string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	if _, ok := r.parameters[id]; !ok {
 		return ErrParameterNotFound
 	}
+	if ifMatch != "" && ifMatch != r.etag(id) {
+		return ErrVersionConflict
+	}
 
 	delete(r.parameters, id)
+	delete(r.versions, id)
 
 	return nil
 }
+
+// store saves the parameter under a new version and sets its ETag to it. The
+// caller holds the lock.
+func (r *InMemoryRepository) store(parameter *Parameter) {
+	r.versions[parameter.ID]++
+	parameter.ETag = r.etag(parameter.ID)
+
+	r.parameters[parameter.ID] = parameter
+}
+
+func (r *InMemoryRepository) etag(id uuid.UUID) string {
+	return strconv.Quote(strconv.Itoa(r.versions[id]))
+}
@@ -1,16 +1,19 @@
 package parameter
 
 import (
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
 type DataType string
 
 const (
-	DataTypeFloat DataType = "float"
-	// DataTypeBoolean DataType = "boolean"
+	DataTypeFloat   DataType = "float"
+	DataTypeBoolean DataType = "boolean"
 	// DataTypeCategory DataType = "category"
 	// Future data types can be added here.
 )
@@ -22,6 +25,156 @@ type Parameter struct {
 	Description string
 	DataType    DataType
 	Unit        string
+	Formula     string
+	Schedule    []time.Weekday
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	// ETag identifies the stored version of the parameter and changes on
+	// every write. Updates are only written over the version they were read
+	// from.
+	ETag string
+}
+
+// SortField names the attribute a parameter listing is ordered by.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "createdAt"
+	SortByName      SortField = "name"
+)
+
+// Sort orders a parameter listing. The zero Sort is creation order.
+type Sort struct {
+	Field      SortField
+	Descending bool
+}
+
+// Field names a stored parameter attribute that a listing can load.
+type Field string
+
+const (
+	FieldID          Field = "id"
+	FieldUserID      Field = "userId"
+	FieldName        Field = "name"
+	FieldDescription Field = "description"
+	FieldDataType    Field = "dataType"
+	FieldUnit        Field = "unit"
+	FieldFormula     Field = "formula"
+	FieldSchedule    Field = "schedule"
+	FieldCreatedAt   Field = "createdAt"
+	FieldUpdatedAt   Field = "updatedAt"
+)
+
+var allFields = []Field{
+	FieldID, FieldUserID, FieldName, FieldDescription, FieldDataType,
+	FieldUnit, FieldFormula, FieldSchedule, FieldCreatedAt, FieldUpdatedAt,
+}
+
+// requiredFields are loaded whatever the projection.
+var requiredFields = []Field{FieldID, FieldUserID}
+
+// ListOptions orders and projects a parameter listing.
+type ListOptions struct {
+	Sort Sort
+	// Fields lists the attributes to load. Empty loads all of them; the
+	// others are left at their zero value.
+	Fields []Field
+}
+
+// loads reports whether the projection loads the field.
+func (o ListOptions) loads(field Field) bool {
+	return len(o.Fields) == 0 || slices.Contains(requiredFields, field) || slices.Contains(o.Fields, field)
+}
+
+// project returns a copy of the parameter holding only the loaded fields.
+func (o ListOptions) project(p *Parameter) *Parameter {
+	if len(o.Fields) == 0 {
+		return p
+	}
+
+	projected := &Parameter{ID: p.ID, UserID: p.UserID}
+	if o.loads(FieldName) {
+		projected.Name = p.Name
+	}
+	if o.loads(FieldDescription) {
+		projected.Description = p.Description
+	}
+	if o.loads(FieldDataType) {
+		projected.DataType = p.DataType
+	}
+	if o.loads(FieldUnit) {
+		projected.Unit = p.Unit
+	}
+	if o.loads(FieldFormula) {
+		projected.Formula = p.Formula
+	}
+	if o.loads(FieldSchedule) {
+		projected.Schedule = p.Schedule
+	}
+	if o.loads(FieldCreatedAt) {
+		projected.CreatedAt = p.CreatedAt
+	}
+	if o.loads(FieldUpdatedAt) {
+		projected.UpdatedAt = p.UpdatedAt
+	}
+
+	return projected
+}
+
+// compareParameters orders two parameters by the sort field, falling back to
+// creation order and then the ID so that the order is total.
+func compareParameters(a, b *Parameter, sort Sort) int {
+	var byField int
+	switch sort.Field {
+	case SortByName:
+		byField = strings.Compare(a.Name, b.Name)
+	case SortByCreatedAt, "":
+		byField = a.CreatedAt.Compare(b.CreatedAt)
+	}
+	if sort.Descending {
+		byField = -byField
+	}
+	if byField != 0 {
+		return byField
+	}
+
+	if byTime := a.CreatedAt.Compare(b.CreatedAt); byTime != 0 {
+		return byTime
+	}
+	return strings.Compare(a.ID.String(), b.ID.String())
+}
+
+// IsDerived reports whether the parameter is defined by a formula over other
+// parameters of the same user. Derived parameters have no measurements of
+// their own; their values are computed on read.
+func (p *Parameter) IsDerived() bool {
+	return p.Formula != ""
+}
+
+// IsScheduled reports whether the parameter is expected to be logged on the
+// given weekday. An empty schedule means every day.
+func (p *Parameter) IsScheduled(weekday time.Weekday) bool {
+	if len(p.Schedule) == 0 {
+		return true
+	}
+
+	for _, scheduled := range p.Schedule {
+		if scheduled == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegisteredUnit returns the parameter's unit from the units registry.
+// Unitless parameters, and ones saved with a free-form label before units were
+// validated, have none.
+func (p *Parameter) RegisteredUnit() (units.Unit, bool) {
+	u, err := units.Lookup(p.Unit)
+	if err != nil {
+		return units.Unit{}, false
+	}
+
+	return u, true
 }
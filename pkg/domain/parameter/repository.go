@@ -3,13 +3,24 @@ package parameter
 import (
 	"context"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
 type Repository interface {
 	CreateParameter(ctx context.Context, parameter *Parameter) (*Parameter, error)
 	GetParameterByID(ctx context.Context, id uuid.UUID) (*Parameter, error)
-	ListParametersByUser(ctx context.Context, userID uuid.UUID) ([]*Parameter, error)
+	ListParametersByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[*Parameter], error)
+	// UpdateParameter writes the parameter over the stored version with the
+	// parameter's ETag, failing with ErrVersionConflict when that is no longer
+	// current. The returned parameter carries the new ETag.
 	UpdateParameter(ctx context.Context, param *Parameter) (*Parameter, error)
-	DeleteParameter(ctx context.Context, id uuid.UUID) error
+	// DeleteParameter deletes the parameter. A non-empty ifMatch must be the
+	// current ETag, otherwise it fails with ErrVersionConflict.
+	DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error
 }
@@ -2,16 +2,25 @@ package parameter
 
 import (
 	"context"
+	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
 	"github.com/google/uuid"
 )
 
 type Service interface {
 	CreateParameter(ctx context.Context, input CreateParameterInput) (*Parameter, error)
 	GetParameterByID(ctx context.Context, id uuid.UUID) (*Parameter, error)
-	ListParametersByUser(ctx context.Context, userID uuid.UUID) ([]*Parameter, error)
+	ListParametersByUser(
+		ctx context.Context,
+		userID uuid.UUID,
+		options ListOptions,
+		page pagination.Page,
+	) (pagination.Result[*Parameter], error)
 	UpdateParameter(ctx context.Context, input UpdateParameterInput) (*Parameter, error)
-	DeleteParameter(ctx context.Context, id uuid.UUID) error
+	// DeleteParameter deletes the parameter. A non-empty ifMatch must be the
+	// parameter's current ETag.
+	DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error
 }
 
 type CreateParameterInput struct {
@@ -20,6 +29,8 @@ type CreateParameterInput struct {
 	Description string
 	DataType    DataType
 	Unit        string
+	Formula     string
+	Schedule    []time.Weekday
 }
 
 type UpdateParameterInput struct {
@@ -27,4 +38,9 @@ type UpdateParameterInput struct {
 	Name        *string
 	Description *string
 	Unit        *string
+	Formula     *string
+	Schedule    *[]time.Weekday
+	// IfMatch, when not empty, is the ETag the client last read. The update
+	// fails with ErrVersionConflict if the parameter has changed since.
+	IfMatch string
 }
@@ -2,11 +2,29 @@ package parameter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/formula"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidFormula      = errors.New("invalid formula")
+	ErrFormulaCycle        = errors.New("formula creates a dependency cycle")
+	ErrParameterReferenced = errors.New("parameter is referenced by a derived parameter")
+	ErrInvalidSchedule     = errors.New("schedule must list distinct weekdays")
+	ErrUnitChange          = errors.New("the unit of a parameter cannot be changed once it is a registered unit")
+	ErrInvalidSort         = errors.New("invalid sort field")
+	ErrInvalidField        = errors.New("invalid field")
+	ErrUnknownUnit         = errors.New("unknown unit")
+)
+
 type ServiceImpl struct {
 	repo Repository
 }
@@ -18,17 +36,32 @@ func NewService(repo Repository) Service {
 }
 
 func (s *ServiceImpl) CreateParameter(ctx context.Context, input CreateParameterInput) (*Parameter, error) {
+	unit, err := normalizeUnit(input.Unit)
+	if err != nil {
+		return nil, err
+	}
+
 	parameter := &Parameter{
 		ID:          uuid.New(),
 		UserID:      input.UserID,
 		Name:        input.Name,
 		Description: input.Description,
 		DataType:    input.DataType,
-		Unit:        input.Unit,
+		Unit:        unit,
+		Formula:     input.Formula,
+		Schedule:    input.Schedule,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
+	if err = validateSchedule(parameter.Schedule); err != nil {
+		return nil, err
+	}
+
+	if err = s.validateFormula(ctx, parameter); err != nil {
+		return nil, err
+	}
+
 	createdParameter, err := s.repo.CreateParameter(ctx, parameter)
 	if err != nil {
 		return nil, err
@@ -46,21 +79,37 @@ func (s *ServiceImpl) GetParameterByID(ctx context.Context, id uuid.UUID) (*Para
 	return parameter, nil
 }
 
-func (s *ServiceImpl) ListParametersByUser(ctx context.Context, userID uuid.UUID) ([]*Parameter, error) {
-	parameters, err := s.repo.ListParametersByUser(ctx, userID)
-	if err != nil {
-		return nil, err
+func (s *ServiceImpl) ListParametersByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	options ListOptions,
+	page pagination.Page,
+) (pagination.Result[*Parameter], error) {
+	if err := validateOptions(options); err != nil {
+		return pagination.Result[*Parameter]{}, err
+	}
+	if err := page.Validate(); err != nil {
+		return pagination.Result[*Parameter]{}, err
 	}
 
-	return parameters, nil
+	return s.repo.ListParametersByUser(ctx, userID, options, page)
 }
 
 func (s *ServiceImpl) UpdateParameter(ctx context.Context, input UpdateParameterInput) (*Parameter, error) {
-	parameter, err := s.repo.GetParameterByID(ctx, input.ID)
+	stored, err := s.repo.GetParameterByID(ctx, input.ID)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.IfMatch != "" && input.IfMatch != stored.ETag {
+		return nil, ErrVersionConflict
+	}
+
+	// Work on a copy so that a rejected update leaves the stored parameter
+	// untouched, even when the repository hands out shared pointers.
+	updated := *stored
+	parameter := &updated
+
 	if input.Name != nil {
 		parameter.Name = *input.Name
 	}
@@ -68,7 +117,28 @@ func (s *ServiceImpl) UpdateParameter(ctx context.Context, input UpdateParameter
 		parameter.Description = *input.Description
 	}
 	if input.Unit != nil {
-		parameter.Unit = *input.Unit
+		// Stored values are in the parameter's unit, so switching to another
+		// registered unit would silently reinterpret them.
+		unit, unitErr := normalizeUnit(*input.Unit)
+		if unitErr != nil {
+			return nil, unitErr
+		}
+		if _, registered := stored.RegisteredUnit(); registered && unit != stored.Unit {
+			return nil, ErrUnitChange
+		}
+		parameter.Unit = unit
+	}
+	if input.Schedule != nil {
+		if err = validateSchedule(*input.Schedule); err != nil {
+			return nil, err
+		}
+		parameter.Schedule = *input.Schedule
+	}
+	if input.Formula != nil {
+		parameter.Formula = *input.Formula
+		if err = s.validateFormula(ctx, parameter); err != nil {
+			return nil, err
+		}
 	}
 
 	parameter.UpdatedAt = time.Now()
@@ -81,11 +151,164 @@ func (s *ServiceImpl) UpdateParameter(ctx context.Context, input UpdateParameter
 	return updatedParameter, nil
 }
 
-func (s *ServiceImpl) DeleteParameter(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteParameter(ctx, id)
+func (s *ServiceImpl) DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error {
+	parameter, err := s.repo.GetParameterByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if ifMatch != "" && ifMatch != parameter.ETag {
+		return ErrVersionConflict
+	}
+
+	siblings, err := s.repo.ListParametersByUser(ctx, parameter.UserID, ListOptions{}, pagination.Page{})
+	if err != nil {
+		return err
+	}
+
+	for _, sibling := range siblings.Items {
+		if sibling.ID != id && references(sibling)[id] {
+			return fmt.Errorf("%w: %s", ErrParameterReferenced, sibling.Name)
+		}
+	}
+
+	err = s.repo.DeleteParameter(ctx, id, ifMatch)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// validateFormula checks that a derived parameter's formula parses, only
+// references other parameters of the same user and does not depend on itself,
+// directly or through other derived parameters.
+func (s *ServiceImpl) validateFormula(ctx context.Context, parameter *Parameter) error {
+	if !parameter.IsDerived() {
+		return nil
+	}
+
+	if parameter.DataType != DataTypeFloat {
+		return fmt.Errorf("%w: derived parameters must have data type %s", ErrInvalidFormula, DataTypeFloat)
+	}
+
+	expr, err := formula.Parse(parameter.Formula)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFormula, err)
+	}
+
+	if len(expr.References()) == 0 {
+		return fmt.Errorf("%w: formula must reference at least one parameter", ErrInvalidFormula)
+	}
+
+	siblings, err := s.repo.ListParametersByUser(ctx, parameter.UserID, ListOptions{}, pagination.Page{})
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uuid.UUID]*Parameter, len(siblings.Items)+1)
+	for _, sibling := range siblings.Items {
+		byID[sibling.ID] = sibling
+	}
+	byID[parameter.ID] = parameter
+
+	for _, id := range expr.References() {
+		referenced, ok := byID[id]
+		if !ok {
+			return fmt.Errorf("%w: unknown parameter %s", ErrInvalidFormula, id)
+		}
+		if referenced.DataType != DataTypeFloat {
+			return fmt.Errorf("%w: parameter %s is not numeric", ErrInvalidFormula, referenced.Name)
+		}
+	}
+
+	if dependsOn(parameter.ID, parameter.ID, byID, make(map[uuid.UUID]bool)) {
+		return ErrFormulaCycle
+	}
+
+	return nil
+}
+
+// dependsOn reports whether the parameter with the given ID transitively
+// references target.
+func dependsOn(id, target uuid.UUID, byID map[uuid.UUID]*Parameter, visited map[uuid.UUID]bool) bool {
+	if visited[id] {
+		return false
+	}
+	visited[id] = true
+
+	parameter, ok := byID[id]
+	if !ok {
+		return false
+	}
+
+	for reference := range references(parameter) {
+		if reference == target || dependsOn(reference, target, byID, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func references(parameter *Parameter) map[uuid.UUID]bool {
+	ids := make(map[uuid.UUID]bool)
+	if !parameter.IsDerived() {
+		return ids
+	}
+
+	expr, err := formula.Parse(parameter.Formula)
+	if err != nil {
+		return ids
+	}
+
+	for _, id := range expr.References() {
+		ids[id] = true
+	}
+
+	return ids
+}
+
+func validateSchedule(schedule []time.Weekday) error {
+	seen := make(map[time.Weekday]bool, len(schedule))
+	for _, weekday := range schedule {
+		if weekday < time.Sunday || weekday > time.Saturday || seen[weekday] {
+			return ErrInvalidSchedule
+		}
+		seen[weekday] = true
+	}
+
+	return nil
+}
+
+// normalizeUnit replaces registered units with their canonical symbol, e.g.
+// "kilograms" becomes "kg". An empty unit marks a unitless parameter; any
+// other unit must be registered.
+func normalizeUnit(unit string) (string, error) {
+	if strings.TrimSpace(unit) == "" {
+		return "", nil
+	}
+
+	u, err := units.Lookup(unit)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownUnit, unit)
+	}
+
+	return u.Symbol, nil
+}
+
+func validateOptions(options ListOptions) error {
+	switch options.Sort.Field {
+	case SortByCreatedAt, SortByName, "":
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidSort, options.Sort.Field)
+	}
+
+	for _, field := range options.Fields {
+		if !slices.Contains(allFields, field) {
+			return fmt.Errorf("%w: %s", ErrInvalidField, field)
+		}
+	}
+
+	return nil
+}
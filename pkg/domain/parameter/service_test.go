@@ -0,0 +1,261 @@
+package parameter_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createParameter(t *testing.T, service parameter.Service, userID uuid.UUID, name, formula string) *parameter.Parameter {
+	t.Helper()
+
+	created, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     name,
+		DataType: parameter.DataTypeFloat,
+		Formula:  formula,
+	})
+	require.NoError(t, err)
+
+	return created
+}
+
+func reference(p *parameter.Parameter) string {
+	return "{" + p.ID.String() + "}"
+}
+
+func TestCreateParameter_Derived(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+	userID := uuid.New()
+
+	weight := createParameter(t, service, userID, "Weight", "")
+	height := createParameter(t, service, userID, "Height", "")
+	bmi := createParameter(t, service, userID, "BMI", reference(weight)+" / (locf("+reference(height)+") / 100) ^ 2")
+
+	assert.True(t, bmi.IsDerived())
+	assert.False(t, weight.IsDerived())
+}
+
+func TestCreateParameter_InvalidFormula(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+	userID := uuid.New()
+
+	weight := createParameter(t, service, userID, "Weight", "")
+	foreign := createParameter(t, service, uuid.New(), "Someone else's", "")
+
+	for _, formula := range []string{
+		reference(weight) + " +",
+		"42",
+		reference(foreign) + " * 2",
+		"{" + uuid.NewString() + "}",
+	} {
+		_, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+			UserID:   userID,
+			Name:     "Derived",
+			DataType: parameter.DataTypeFloat,
+			Formula:  formula,
+		})
+		require.ErrorIs(t, err, parameter.ErrInvalidFormula, formula)
+	}
+}
+
+func TestUpdateParameter_FormulaCycle(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+	userID := uuid.New()
+
+	intake := createParameter(t, service, userID, "Intake", "")
+	a := createParameter(t, service, userID, "A", reference(intake)+" * 2")
+	b := createParameter(t, service, userID, "B", reference(a)+" + 1")
+
+	cyclic := reference(b) + " - 1"
+	_, err := service.UpdateParameter(context.Background(), parameter.UpdateParameterInput{ID: a.ID, Formula: &cyclic})
+	require.ErrorIs(t, err, parameter.ErrFormulaCycle)
+
+	self := reference(a) + " + 1"
+	_, err = service.UpdateParameter(context.Background(), parameter.UpdateParameterInput{ID: a.ID, Formula: &self})
+	require.ErrorIs(t, err, parameter.ErrFormulaCycle)
+
+	stored, err := service.GetParameterByID(context.Background(), a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, reference(intake)+" * 2", stored.Formula)
+}
+
+func TestDeleteParameter_ReferencedByDerived(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+	userID := uuid.New()
+
+	intake := createParameter(t, service, userID, "Intake", "")
+	derived := createParameter(t, service, userID, "Doubled", reference(intake)+" * 2")
+
+	err := service.DeleteParameter(context.Background(), intake.ID, "")
+	require.ErrorIs(t, err, parameter.ErrParameterReferenced)
+
+	require.NoError(t, service.DeleteParameter(context.Background(), derived.ID, ""))
+	require.NoError(t, service.DeleteParameter(context.Background(), intake.ID, ""))
+}
+
+func TestCreateParameter_Schedule(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+
+	created, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Gym",
+		DataType: parameter.DataTypeBoolean,
+		Schedule: []time.Weekday{time.Monday, time.Wednesday, time.Friday},
+	})
+	require.NoError(t, err)
+	assert.True(t, created.IsScheduled(time.Wednesday))
+	assert.False(t, created.IsScheduled(time.Sunday))
+
+	_, err = service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Gym",
+		DataType: parameter.DataTypeBoolean,
+		Schedule: []time.Weekday{time.Monday, time.Monday},
+	})
+	require.ErrorIs(t, err, parameter.ErrInvalidSchedule)
+}
+
+func TestCreateParameter_NormalizesRegisteredUnit(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+
+	weight, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "Kilograms",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "kg", weight.Unit)
+
+	pounds := "lb"
+	_, err = service.UpdateParameter(context.Background(), parameter.UpdateParameterInput{ID: weight.ID, Unit: &pounds})
+	require.ErrorIs(t, err, parameter.ErrUnitChange)
+
+	same := "kilogram"
+	updated, err := service.UpdateParameter(context.Background(), parameter.UpdateParameterInput{ID: weight.ID, Unit: &same})
+	require.NoError(t, err)
+	assert.Equal(t, "kg", updated.Unit)
+}
+
+func TestCreateParameter_UnknownUnit(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+
+	_, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "points",
+	})
+	require.ErrorIs(t, err, parameter.ErrUnknownUnit)
+
+	mood, err := service.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, mood.Unit)
+
+	points := "points"
+	_, err = service.UpdateParameter(context.Background(), parameter.UpdateParameterInput{ID: mood.ID, Unit: &points})
+	require.ErrorIs(t, err, parameter.ErrUnknownUnit)
+}
+
+func TestListParametersByUser_SortAndProjection(t *testing.T) {
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+	userID := uuid.New()
+
+	for _, name := range []string{"Weight", "Mood", "Sleep"} {
+		createParameter(t, service, userID, name, "")
+	}
+	createParameter(t, service, uuid.New(), "Steps", "")
+
+	names := func(options parameter.ListOptions) []string {
+		result, err := service.ListParametersByUser(context.Background(), userID, options, pagination.Page{})
+		require.NoError(t, err)
+
+		var listed []string
+		for _, p := range result.Items {
+			listed = append(listed, p.Name)
+		}
+		return listed
+	}
+
+	assert.Equal(t, []string{"Mood", "Sleep", "Weight"}, names(parameter.ListOptions{
+		Sort: parameter.Sort{Field: parameter.SortByName},
+	}))
+	assert.Equal(t, []string{"Weight", "Sleep", "Mood"}, names(parameter.ListOptions{
+		Sort: parameter.Sort{Field: parameter.SortByName, Descending: true},
+	}))
+
+	projected, err := service.ListParametersByUser(context.Background(), userID, parameter.ListOptions{
+		Fields: []parameter.Field{parameter.FieldName},
+	}, pagination.Page{})
+	require.NoError(t, err)
+	require.Len(t, projected.Items, 3)
+	for _, p := range projected.Items {
+		assert.NotEmpty(t, p.Name)
+		assert.Equal(t, userID, p.UserID)
+		assert.Empty(t, p.DataType)
+		assert.True(t, p.CreatedAt.IsZero())
+	}
+
+	_, err = service.ListParametersByUser(context.Background(), userID, parameter.ListOptions{
+		Sort: parameter.Sort{Field: "unit"},
+	}, pagination.Page{})
+	require.ErrorIs(t, err, parameter.ErrInvalidSort)
+
+	_, err = service.ListParametersByUser(context.Background(), userID, parameter.ListOptions{
+		Fields: []parameter.Field{"colour"},
+	}, pagination.Page{})
+	require.ErrorIs(t, err, parameter.ErrInvalidField)
+}
+
+func TestUpdateParameter_IfMatch(t *testing.T) {
+	ctx := context.Background()
+	service := parameter.NewService(parameter.NewInMemoryRepository())
+
+	weight := createParameter(t, service, uuid.New(), "Weight", "")
+	require.NotEmpty(t, weight.ETag)
+	staleETag := weight.ETag
+
+	name := "Body weight"
+	updated, err := service.UpdateParameter(ctx, parameter.UpdateParameterInput{
+		ID:      weight.ID,
+		Name:    &name,
+		IfMatch: staleETag,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, staleETag, updated.ETag)
+
+	// A second device still holding the first version must not overwrite.
+	other := "Mass"
+	_, err = service.UpdateParameter(ctx, parameter.UpdateParameterInput{
+		ID:      weight.ID,
+		Name:    &other,
+		IfMatch: staleETag,
+	})
+	require.ErrorIs(t, err, parameter.ErrVersionConflict)
+
+	fetched, err := service.GetParameterByID(ctx, weight.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Body weight", fetched.Name)
+	assert.Equal(t, updated.ETag, fetched.ETag)
+
+	// Without If-Match the update applies to whatever is stored.
+	_, err = service.UpdateParameter(ctx, parameter.UpdateParameterInput{ID: weight.ID, Name: &other})
+	require.NoError(t, err)
+
+	require.ErrorIs(t, service.DeleteParameter(ctx, weight.ID, updated.ETag), parameter.ErrVersionConflict)
+
+	fetched, err = service.GetParameterByID(ctx, weight.ID)
+	require.NoError(t, err)
+	require.NoError(t, service.DeleteParameter(ctx, weight.ID, fetched.ETag))
+}
@@ -0,0 +1,341 @@
+package reminder
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/google/uuid"
+)
+
+const (
+	databaseName          = "correlateapp"
+	scheduleContainerName = "ReminderSchedules"
+	reminderContainerName = "Reminders"
+	partitionKey          = "/userId"
+)
+
+type CosmosReminderRepository struct {
+	client            *azcosmos.Client
+	scheduleContainer *azcosmos.ContainerClient
+	reminderContainer *azcosmos.ContainerClient
+}
+
+func NewCosmosReminderRepository(connectionString string) (*CosmosReminderRepository, error) {
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Cosmos DB client for reminder repository: %w", err)
+	}
+
+	scheduleContainer, err := client.NewContainer(databaseName, scheduleContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for reminder schedules: %w", err)
+	}
+
+	reminderContainer, err := client.NewContainer(databaseName, reminderContainerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Cosmos DB container for reminders: %w", err)
+	}
+
+	return &CosmosReminderRepository{
+		client:            client,
+		scheduleContainer: scheduleContainer,
+		reminderContainer: reminderContainer,
+	}, nil
+}
+
+func (r *CosmosReminderRepository) CreateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error) {
+	scheduleJSON, err := json.Marshal(NewCosmosSchedule(schedule))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder schedule: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(schedule.UserID.String())
+
+	_, err = r.scheduleContainer.CreateItem(ctx, pk, scheduleJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reminder schedule in Cosmos DB: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (r *CosmosReminderRepository) GetScheduleByID(ctx context.Context, id uuid.UUID) (*Schedule, error) {
+	query := "SELECT * FROM schedules s WHERE s.id = @id"
+	params := []azcosmos.QueryParameter{
+		{Name: "@id", Value: id.String()},
+	}
+
+	schedules, err := r.querySchedules(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) == 0 {
+		return nil, ErrScheduleNotFound
+	}
+
+	return schedules[0], nil
+}
+
+func (r *CosmosReminderRepository) ListSchedules(ctx context.Context) ([]*Schedule, error) {
+	return r.querySchedules(ctx, "SELECT * FROM schedules s", nil)
+}
+
+func (r *CosmosReminderRepository) ListSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]*Schedule, error) {
+	query := "SELECT * FROM schedules s WHERE s.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.querySchedules(ctx, query, params)
+}
+
+func (r *CosmosReminderRepository) ListSchedulesByParameter(
+	ctx context.Context,
+	parameterID uuid.UUID,
+) ([]*Schedule, error) {
+	query := "SELECT * FROM schedules s WHERE s.parameterId = @parameterID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@parameterID", Value: parameterID.String()},
+	}
+
+	return r.querySchedules(ctx, query, params)
+}
+
+func (r *CosmosReminderRepository) UpdateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error) {
+	scheduleJSON, err := json.Marshal(NewCosmosSchedule(schedule))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder schedule: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(schedule.UserID.String())
+
+	_, err = r.scheduleContainer.ReplaceItem(ctx, pk, schedule.ID.String(), scheduleJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reminder schedule in Cosmos DB: %w", err)
+	}
+
+	return schedule, nil
+}
+
+func (r *CosmosReminderRepository) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	// First, retrieve the schedule to get its UserID (required for partition key)
+	schedule, err := r.GetScheduleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	pk := azcosmos.NewPartitionKeyString(schedule.UserID.String())
+
+	_, err = r.scheduleContainer.DeleteItem(ctx, pk, id.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete reminder schedule from Cosmos DB: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CosmosReminderRepository) CreateReminder(ctx context.Context, reminder *Reminder) (*Reminder, error) {
+	reminderJSON, err := json.Marshal(NewCosmosReminder(reminder))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(reminder.UserID.String())
+
+	_, err = r.reminderContainer.CreateItem(ctx, pk, reminderJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reminder in Cosmos DB: %w", err)
+	}
+
+	return reminder, nil
+}
+
+func (r *CosmosReminderRepository) ListRemindersByUser(ctx context.Context, userID uuid.UUID) ([]*Reminder, error) {
+	query := "SELECT * FROM reminders r WHERE r.userId = @userID"
+	params := []azcosmos.QueryParameter{
+		{Name: "@userID", Value: userID.String()},
+	}
+
+	return r.queryReminders(ctx, query, params)
+}
+
+func (r *CosmosReminderRepository) ListRemindersByStatus(ctx context.Context, status Status) ([]*Reminder, error) {
+	query := "SELECT * FROM reminders r WHERE r.status = @status"
+	params := []azcosmos.QueryParameter{
+		{Name: "@status", Value: string(status)},
+	}
+
+	return r.queryReminders(ctx, query, params)
+}
+
+func (r *CosmosReminderRepository) UpdateReminder(ctx context.Context, reminder *Reminder) (*Reminder, error) {
+	reminderJSON, err := json.Marshal(NewCosmosReminder(reminder))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder: %w", err)
+	}
+
+	pk := azcosmos.NewPartitionKeyString(reminder.UserID.String())
+
+	_, err = r.reminderContainer.ReplaceItem(ctx, pk, reminder.ID.String(), reminderJSON, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update reminder in Cosmos DB: %w", err)
+	}
+
+	return reminder, nil
+}
+
+func (r *CosmosReminderRepository) querySchedules(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Schedule, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.scheduleContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	schedules := []*Schedule{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosSchedule CosmosSchedule
+			if err := json.Unmarshal(item, &cosmosSchedule); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal reminder schedule: %w", err)
+			}
+			schedules = append(schedules, NewSchedule(&cosmosSchedule))
+		}
+	}
+
+	return schedules, nil
+}
+
+func (r *CosmosReminderRepository) queryReminders(
+	ctx context.Context,
+	query string,
+	params []azcosmos.QueryParameter,
+) ([]*Reminder, error) {
+	queryOptions := &azcosmos.QueryOptions{QueryParameters: params}
+	pager := r.reminderContainer.NewQueryItemsPager(query, azcosmos.NewPartitionKey(), queryOptions)
+
+	reminders := []*Reminder{}
+	for pager.More() {
+		resp, nextPageErr := pager.NextPage(ctx)
+		if nextPageErr != nil {
+			return nil, fmt.Errorf("query failed: %w", nextPageErr)
+		}
+
+		for _, item := range resp.Items {
+			var cosmosReminder CosmosReminder
+			if err := json.Unmarshal(item, &cosmosReminder); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal reminder: %w", err)
+			}
+			reminders = append(reminders, NewReminder(&cosmosReminder))
+		}
+	}
+
+	return reminders, nil
+}
+
+type CosmosSchedule struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"userId"`
+	ParameterID     uuid.UUID      `json:"parameterId"`
+	Kind            Kind           `json:"kind"`
+	Hour            int            `json:"hour"`
+	Minute          int            `json:"minute"`
+	Weekdays        []time.Weekday `json:"weekdays,omitempty"`
+	IntervalMinutes int            `json:"intervalMinutes,omitempty"`
+	StartAt         time.Time      `json:"startAt"`
+	GraceMinutes    int            `json:"graceMinutes"`
+	LastDueAt       *time.Time     `json:"lastDueAt,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
+}
+
+func NewCosmosSchedule(schedule *Schedule) *CosmosSchedule {
+	return &CosmosSchedule{
+		ID:              schedule.ID,
+		UserID:          schedule.UserID,
+		ParameterID:     schedule.ParameterID,
+		Kind:            schedule.Kind,
+		Hour:            schedule.Hour,
+		Minute:          schedule.Minute,
+		Weekdays:        schedule.Weekdays,
+		IntervalMinutes: int(schedule.Interval / time.Minute),
+		StartAt:         schedule.StartAt,
+		GraceMinutes:    int(schedule.Grace / time.Minute),
+		LastDueAt:       schedule.LastDueAt,
+		CreatedAt:       schedule.CreatedAt,
+		UpdatedAt:       schedule.UpdatedAt,
+	}
+}
+
+func NewSchedule(cosmosSchedule *CosmosSchedule) *Schedule {
+	return &Schedule{
+		ID:          cosmosSchedule.ID,
+		UserID:      cosmosSchedule.UserID,
+		ParameterID: cosmosSchedule.ParameterID,
+		Kind:        cosmosSchedule.Kind,
+		Hour:        cosmosSchedule.Hour,
+		Minute:      cosmosSchedule.Minute,
+		Weekdays:    cosmosSchedule.Weekdays,
+		Interval:    time.Duration(cosmosSchedule.IntervalMinutes) * time.Minute,
+		StartAt:     cosmosSchedule.StartAt,
+		Grace:       time.Duration(cosmosSchedule.GraceMinutes) * time.Minute,
+		LastDueAt:   cosmosSchedule.LastDueAt,
+		CreatedAt:   cosmosSchedule.CreatedAt,
+		UpdatedAt:   cosmosSchedule.UpdatedAt,
+	}
+}
+
+type CosmosReminder struct {
+	ID            uuid.UUID  `json:"id"`
+	ScheduleID    uuid.UUID  `json:"scheduleId"`
+	UserID        uuid.UUID  `json:"userId"`
+	ParameterID   uuid.UUID  `json:"parameterId"`
+	DueAt         time.Time  `json:"dueAt"`
+	GraceMinutes  int        `json:"graceMinutes"`
+	Status        Status     `json:"status"`
+	MeasurementID *uuid.UUID `json:"measurementId,omitempty"`
+	ResolvedAt    *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+func NewCosmosReminder(reminder *Reminder) *CosmosReminder {
+	return &CosmosReminder{
+		ID:            reminder.ID,
+		ScheduleID:    reminder.ScheduleID,
+		UserID:        reminder.UserID,
+		ParameterID:   reminder.ParameterID,
+		DueAt:         reminder.DueAt,
+		GraceMinutes:  int(reminder.Grace / time.Minute),
+		Status:        reminder.Status,
+		MeasurementID: reminder.MeasurementID,
+		ResolvedAt:    reminder.ResolvedAt,
+		CreatedAt:     reminder.CreatedAt,
+		UpdatedAt:     reminder.UpdatedAt,
+	}
+}
+
+func NewReminder(cosmosReminder *CosmosReminder) *Reminder {
+	return &Reminder{
+		ID:            cosmosReminder.ID,
+		ScheduleID:    cosmosReminder.ScheduleID,
+		UserID:        cosmosReminder.UserID,
+		ParameterID:   cosmosReminder.ParameterID,
+		DueAt:         cosmosReminder.DueAt,
+		Grace:         time.Duration(cosmosReminder.GraceMinutes) * time.Minute,
+		Status:        cosmosReminder.Status,
+		MeasurementID: cosmosReminder.MeasurementID,
+		ResolvedAt:    cosmosReminder.ResolvedAt,
+		CreatedAt:     cosmosReminder.CreatedAt,
+		UpdatedAt:     cosmosReminder.UpdatedAt,
+	}
+}
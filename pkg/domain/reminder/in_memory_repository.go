@@ -0,0 +1,161 @@
+package reminder
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type InMemoryRepository struct {
+	mu        sync.RWMutex
+	schedules map[uuid.UUID]*Schedule
+	reminders map[uuid.UUID]*Reminder
+}
+
+func NewInMemoryRepository() *InMemoryRepository {
+	return &InMemoryRepository{
+		schedules: make(map[uuid.UUID]*Schedule),
+		reminders: make(map[uuid.UUID]*Reminder),
+	}
+}
+
+func (r *InMemoryRepository) CreateSchedule(_ context.Context, schedule *Schedule) (*Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.schedules[schedule.ID] = schedule
+
+	return schedule, nil
+}
+
+func (r *InMemoryRepository) GetScheduleByID(_ context.Context, id uuid.UUID) (*Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, ok := r.schedules[id]
+	if !ok {
+		return nil, ErrScheduleNotFound
+	}
+
+	return schedule, nil
+}
+
+func (r *InMemoryRepository) ListSchedules(_ context.Context) ([]*Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedules := make([]*Schedule, 0, len(r.schedules))
+	for _, schedule := range r.schedules {
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+func (r *InMemoryRepository) ListSchedulesByUser(_ context.Context, userID uuid.UUID) ([]*Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schedules []*Schedule
+	for _, schedule := range r.schedules {
+		if schedule.UserID == userID {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	return schedules, nil
+}
+
+func (r *InMemoryRepository) ListSchedulesByParameter(
+	_ context.Context,
+	parameterID uuid.UUID,
+) ([]*Schedule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var schedules []*Schedule
+	for _, schedule := range r.schedules {
+		if schedule.ParameterID == parameterID {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	return schedules, nil
+}
+
+func (r *InMemoryRepository) UpdateSchedule(_ context.Context, schedule *Schedule) (*Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schedules[schedule.ID]; !ok {
+		return nil, ErrScheduleNotFound
+	}
+
+	r.schedules[schedule.ID] = schedule
+
+	return schedule, nil
+}
+
+func (r *InMemoryRepository) DeleteSchedule(_ context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.schedules[id]; !ok {
+		return ErrScheduleNotFound
+	}
+
+	delete(r.schedules, id)
+
+	return nil
+}
+
+func (r *InMemoryRepository) CreateReminder(_ context.Context, reminder *Reminder) (*Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reminders[reminder.ID] = reminder
+
+	return reminder, nil
+}
+
+func (r *InMemoryRepository) ListRemindersByUser(_ context.Context, userID uuid.UUID) ([]*Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var reminders []*Reminder
+	for _, reminder := range r.reminders {
+		if reminder.UserID == userID {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	return reminders, nil
+}
+
+func (r *InMemoryRepository) ListRemindersByStatus(_ context.Context, status Status) ([]*Reminder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var reminders []*Reminder
+	for _, reminder := range r.reminders {
+		if reminder.Status == status {
+			reminders = append(reminders, reminder)
+		}
+	}
+
+	return reminders, nil
+}
+
+func (r *InMemoryRepository) UpdateReminder(_ context.Context, reminder *Reminder) (*Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reminders[reminder.ID]; !ok {
+		return nil, ErrReminderNotFound
+	}
+
+	r.reminders[reminder.ID] = reminder
+
+	return reminder, nil
+}
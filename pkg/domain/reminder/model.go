@@ -0,0 +1,81 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	// KindDaily reminds at a local time of day, optionally on some weekdays only.
+	KindDaily Kind = "daily"
+	// KindInterval reminds at a fixed spacing, such as every 8 hours.
+	KindInterval Kind = "interval"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusCompleted Status = "completed"
+	StatusMissed    Status = "missed"
+)
+
+type Schedule struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	ParameterID uuid.UUID
+	Kind        Kind
+	// Hour and Minute are the local time of daily reminders.
+	Hour   int
+	Minute int
+	// Weekdays limits daily reminders to some days of the week. Empty means
+	// every day.
+	Weekdays []time.Weekday
+	// Interval is the spacing of interval reminders, counted from StartAt.
+	Interval time.Duration
+	StartAt  time.Time
+	// Grace is how long before or after a reminder is due an entry still
+	// counts for it.
+	Grace time.Duration
+	// LastDueAt is the latest occurrence a reminder was produced for.
+	LastDueAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Reminder struct {
+	ID          uuid.UUID
+	ScheduleID  uuid.UUID
+	UserID      uuid.UUID
+	ParameterID uuid.UUID
+	DueAt       time.Time
+	Grace       time.Duration
+	Status      Status
+	// MeasurementID is the entry that completed the reminder.
+	MeasurementID *uuid.UUID
+	ResolvedAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ExpiresAt is the end of the reminder's grace window, after which it is
+// missed.
+func (r *Reminder) ExpiresAt() time.Time {
+	return r.DueAt.Add(r.Grace)
+}
+
+// Covers reports whether an entry at t counts for the reminder.
+func (r *Reminder) Covers(t time.Time) bool {
+	return !t.Before(r.DueAt.Add(-r.Grace)) && !t.After(r.ExpiresAt())
+}
+
+// RunResult lists what a scheduler run changed.
+type RunResult struct {
+	// Due are the reminders that became due and are still waiting for an entry.
+	Due       []*Reminder
+	Completed []*Reminder
+	Missed    []*Reminder
+}
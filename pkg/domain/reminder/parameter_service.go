@@ -0,0 +1,35 @@
+package reminder
+
+import (
+	"context"
+	"log"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/google/uuid"
+)
+
+// ParameterService deletes reminder schedules along with their parameter.
+type ParameterService struct {
+	parameter.Service
+	reminderService Service
+}
+
+func NewParameterService(parameterService parameter.Service, reminderService Service) parameter.Service {
+	return &ParameterService{
+		Service:         parameterService,
+		reminderService: reminderService,
+	}
+}
+
+// DeleteParameter deletes the parameter and then its schedules.
+func (s *ParameterService) DeleteParameter(ctx context.Context, id uuid.UUID, ifMatch string) error {
+	if err := s.Service.DeleteParameter(ctx, id, ifMatch); err != nil {
+		return err
+	}
+
+	if err := s.reminderService.DeleteSchedulesByParameter(ctx, id); err != nil {
+		log.Printf("failed to delete reminder schedules for parameter %s: %v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrScheduleNotFound = errors.New("reminder schedule not found")
+	ErrReminderNotFound = errors.New("reminder not found")
+)
+
+type Repository interface {
+	CreateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error)
+	GetScheduleByID(ctx context.Context, id uuid.UUID) (*Schedule, error)
+	ListSchedules(ctx context.Context) ([]*Schedule, error)
+	ListSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]*Schedule, error)
+	ListSchedulesByParameter(ctx context.Context, parameterID uuid.UUID) ([]*Schedule, error)
+	UpdateSchedule(ctx context.Context, schedule *Schedule) (*Schedule, error)
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+
+	CreateReminder(ctx context.Context, reminder *Reminder) (*Reminder, error)
+	ListRemindersByUser(ctx context.Context, userID uuid.UUID) ([]*Reminder, error)
+	ListRemindersByStatus(ctx context.Context, status Status) ([]*Reminder, error)
+	UpdateReminder(ctx context.Context, reminder *Reminder) (*Reminder, error)
+}
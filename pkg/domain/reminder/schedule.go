@@ -0,0 +1,35 @@
+package reminder
+
+import (
+	"slices"
+	"time"
+)
+
+// Next returns the first occurrence of the schedule strictly after t. Daily
+// times are interpreted in loc.
+func (s *Schedule) Next(t time.Time, loc *time.Location) time.Time {
+	switch s.Kind {
+	case KindInterval:
+		if t.Before(s.StartAt) {
+			return s.StartAt
+		}
+		elapsed := t.Sub(s.StartAt)
+		return s.StartAt.Add((elapsed/s.Interval + 1) * s.Interval)
+	case KindDaily:
+		local := t.In(loc)
+		// A week and a day covers every weekday filter.
+		for offset := range 8 {
+			day := local.AddDate(0, 0, offset)
+			candidate := time.Date(day.Year(), day.Month(), day.Day(), s.Hour, s.Minute, 0, 0, loc)
+			if candidate.After(t) && s.isScheduled(candidate.Weekday()) {
+				return candidate
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) isScheduled(weekday time.Weekday) bool {
+	return len(s.Weekdays) == 0 || slices.Contains(s.Weekdays, weekday)
+}
@@ -0,0 +1,42 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	CreateSchedule(ctx context.Context, input CreateScheduleInput) (*Schedule, error)
+	GetScheduleByID(ctx context.Context, id uuid.UUID) (*Schedule, error)
+	ListSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]*Schedule, error)
+	DeleteSchedule(ctx context.Context, id uuid.UUID) error
+	DeleteSchedulesByUser(ctx context.Context, userID uuid.UUID) error
+	DeleteSchedulesByParameter(ctx context.Context, parameterID uuid.UUID) error
+	ListRemindersByUser(ctx context.Context, input ListRemindersInput) ([]*Reminder, error)
+	// RunScheduler produces the reminders that fell due since the previous
+	// run and resolves pending ones as completed or missed. A schedule that
+	// fails is logged and skipped.
+	RunScheduler(ctx context.Context) (*RunResult, error)
+}
+
+type CreateScheduleInput struct {
+	ParameterID uuid.UUID
+	Kind        Kind
+	Hour        int
+	Minute      int
+	// Weekdays defaults to the parameter's own schedule.
+	Weekdays []time.Weekday
+	Interval time.Duration
+	// StartAt anchors interval reminders. Zero means now.
+	StartAt time.Time
+	// Grace defaults to DefaultGrace.
+	Grace time.Duration
+}
+
+type ListRemindersInput struct {
+	UserID uuid.UUID
+	// Status filters the reminders. Empty returns all of them.
+	Status Status
+}
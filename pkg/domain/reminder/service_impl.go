@@ -0,0 +1,409 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/clock"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultGrace = 2 * time.Hour
+	MinInterval  = time.Hour
+	// maxRemindersPerRun bounds the catch-up after downtime. Later runs
+	// continue where a capped run stopped.
+	maxRemindersPerRun = 100
+)
+
+var (
+	ErrInvalidKind      = errors.New("reminder kind must be daily or interval")
+	ErrInvalidTime      = errors.New("time of day must be between 00:00 and 23:59")
+	ErrInvalidWeekdays  = errors.New("weekdays must be distinct days of the week")
+	ErrInvalidInterval  = errors.New("interval must be at least one hour")
+	ErrInvalidGrace     = errors.New("grace must be positive and shorter than the time between reminders")
+	ErrDerivedParameter = errors.New("reminders cannot be set for a derived parameter")
+)
+
+type ServiceImpl struct {
+	repo               Repository
+	userService        user.Service
+	parameterService   parameter.Service
+	measurementService measurement.Service
+	clock              clock.Clock
+}
+
+func NewService(
+	repo Repository,
+	userService user.Service,
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+	clock clock.Clock,
+) Service {
+	return &ServiceImpl{
+		repo:               repo,
+		userService:        userService,
+		parameterService:   parameterService,
+		measurementService: measurementService,
+		clock:              clock,
+	}
+}
+
+func (s *ServiceImpl) CreateSchedule(ctx context.Context, input CreateScheduleInput) (*Schedule, error) {
+	reminderParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reminderParameter.IsDerived() {
+		return nil, ErrDerivedParameter
+	}
+
+	now := s.clock.Now()
+	schedule := &Schedule{
+		ID:          uuid.New(),
+		UserID:      reminderParameter.UserID,
+		ParameterID: reminderParameter.ID,
+		Kind:        input.Kind,
+		Hour:        input.Hour,
+		Minute:      input.Minute,
+		Weekdays:    input.Weekdays,
+		Interval:    input.Interval,
+		StartAt:     input.StartAt,
+		Grace:       input.Grace,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if schedule.Kind == KindDaily && len(schedule.Weekdays) == 0 {
+		schedule.Weekdays = reminderParameter.Schedule
+	}
+	if schedule.StartAt.IsZero() {
+		schedule.StartAt = now
+	}
+	if schedule.Grace == 0 {
+		schedule.Grace = DefaultGrace
+		if schedule.Kind == KindInterval {
+			schedule.Grace = min(DefaultGrace, schedule.Interval/4)
+		}
+	}
+
+	if err := validateSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	return s.repo.CreateSchedule(ctx, schedule)
+}
+
+func (s *ServiceImpl) GetScheduleByID(ctx context.Context, id uuid.UUID) (*Schedule, error) {
+	return s.repo.GetScheduleByID(ctx, id)
+}
+
+func (s *ServiceImpl) ListSchedulesByUser(ctx context.Context, userID uuid.UUID) ([]*Schedule, error) {
+	return s.repo.ListSchedulesByUser(ctx, userID)
+}
+
+func (s *ServiceImpl) DeleteSchedule(ctx context.Context, id uuid.UUID) error {
+	return s.repo.DeleteSchedule(ctx, id)
+}
+
+func (s *ServiceImpl) DeleteSchedulesByUser(ctx context.Context, userID uuid.UUID) error {
+	schedules, err := s.repo.ListSchedulesByUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteSchedules(ctx, schedules)
+}
+
+func (s *ServiceImpl) DeleteSchedulesByParameter(ctx context.Context, parameterID uuid.UUID) error {
+	schedules, err := s.repo.ListSchedulesByParameter(ctx, parameterID)
+	if err != nil {
+		return err
+	}
+
+	return s.deleteSchedules(ctx, schedules)
+}
+
+func (s *ServiceImpl) deleteSchedules(ctx context.Context, schedules []*Schedule) error {
+	for _, schedule := range schedules {
+		if err := s.repo.DeleteSchedule(ctx, schedule.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceImpl) ListRemindersByUser(ctx context.Context, input ListRemindersInput) ([]*Reminder, error) {
+	reminders, err := s.repo.ListRemindersByUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*Reminder{}
+	for _, r := range reminders {
+		if input.Status == "" || r.Status == input.Status {
+			filtered = append(filtered, r)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].DueAt.After(filtered[j].DueAt)
+	})
+
+	return filtered, nil
+}
+
+func (s *ServiceImpl) RunScheduler(ctx context.Context) (*RunResult, error) {
+	now := s.clock.Now()
+	result := &RunResult{
+		Due:       []*Reminder{},
+		Completed: []*Reminder{},
+		Missed:    []*Reminder{},
+	}
+
+	locations := make(map[uuid.UUID]*time.Location)
+	locationOf := func(userID uuid.UUID) (*time.Location, error) {
+		if loc, ok := locations[userID]; ok {
+			return loc, nil
+		}
+		owner, err := s.userService.GetUserByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		locations[userID] = owner.Location()
+		return locations[userID], nil
+	}
+
+	pending, err := s.repo.ListRemindersByStatus(ctx, StatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.ListSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only entries from the start of the earliest grace window of this run
+	// can resolve a reminder, so each parameter's history is loaded from
+	// there. Schedules produce reminders due after their last one.
+	since := make(map[uuid.UUID]time.Time)
+	earliest := func(parameterID uuid.UUID, t time.Time) {
+		if current, ok := since[parameterID]; !ok || t.Before(current) {
+			since[parameterID] = t
+		}
+	}
+	for _, stored := range pending {
+		earliest(stored.ParameterID, stored.DueAt.Add(-stored.Grace))
+	}
+	for _, stored := range schedules {
+		from := stored.StartAt
+		if stored.LastDueAt != nil {
+			from = *stored.LastDueAt
+		}
+		earliest(stored.ParameterID, from.Add(-stored.Grace))
+	}
+
+	histories := make(map[uuid.UUID][]measurement.Measurement)
+	historyOf := func(parameterID uuid.UUID) ([]measurement.Measurement, error) {
+		if history, ok := histories[parameterID]; ok {
+			return history, nil
+		}
+		from := since[parameterID]
+		listed, err := s.measurementService.ListMeasurementsByParameter(ctx, parameterID, measurement.ListOptions{
+			Filter: measurement.Filter{From: &from, To: &now},
+		}, pagination.Page{})
+		if err != nil {
+			return nil, err
+		}
+		histories[parameterID] = listed.Items
+		return listed.Items, nil
+	}
+
+	// Resolve the reminders that were already waiting before producing new
+	// ones, so that every reminder is resolved exactly once per run. A
+	// reminder or schedule that fails, for example because its user or
+	// parameter was deleted, is logged and skipped so that it does not hold
+	// up the others.
+	for _, stored := range pending {
+		history, err := historyOf(stored.ParameterID)
+		if err != nil {
+			log.Printf("failed to list measurements for reminder %s: %v", stored.ID, err)
+			continue
+		}
+
+		r := *stored
+		if !resolve(&r, history, now) {
+			continue
+		}
+		if _, err := s.repo.UpdateReminder(ctx, &r); err != nil {
+			log.Printf("failed to resolve reminder %s: %v", stored.ID, err)
+			continue
+		}
+		result.add(&r)
+	}
+
+	produced := 0
+	for _, stored := range schedules {
+		loc, err := locationOf(stored.UserID)
+		if err != nil {
+			log.Printf("failed to get the owner of reminder schedule %s: %v", stored.ID, err)
+			continue
+		}
+
+		history, err := historyOf(stored.ParameterID)
+		if err != nil {
+			log.Printf("failed to list measurements for reminder schedule %s: %v", stored.ID, err)
+			continue
+		}
+
+		count, err := s.runSchedule(ctx, stored, loc, history, now, maxRemindersPerRun-produced, result)
+		produced += count
+		if err != nil {
+			log.Printf("failed to run reminder schedule %s: %v", stored.ID, err)
+		}
+	}
+
+	return result, nil
+}
+
+// runSchedule produces at most limit of the schedule's reminders that fell
+// due by now and returns how many it produced. The schedule records the
+// reminders produced before a failure, so that a later run does not repeat
+// them.
+func (s *ServiceImpl) runSchedule(
+	ctx context.Context,
+	stored *Schedule,
+	loc *time.Location,
+	history []measurement.Measurement,
+	now time.Time,
+	limit int,
+	result *RunResult,
+) (int, error) {
+	schedule := *stored
+	produced := 0
+	from := schedule.StartAt.Add(-time.Nanosecond)
+	if schedule.LastDueAt != nil {
+		from = *schedule.LastDueAt
+	}
+
+	var createErr error
+	for due := schedule.Next(from, loc); !due.IsZero() && !due.After(now); due = schedule.Next(due, loc) {
+		if produced == limit {
+			break
+		}
+
+		r := &Reminder{
+			ID:          uuid.New(),
+			ScheduleID:  schedule.ID,
+			UserID:      schedule.UserID,
+			ParameterID: schedule.ParameterID,
+			DueAt:       due,
+			Grace:       schedule.Grace,
+			Status:      StatusPending,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		resolve(r, history, now)
+
+		if _, createErr = s.repo.CreateReminder(ctx, r); createErr != nil {
+			break
+		}
+		result.add(r)
+		produced++
+
+		dueAt := due
+		schedule.LastDueAt = &dueAt
+	}
+
+	if produced > 0 {
+		schedule.UpdatedAt = now
+		if _, err := s.repo.UpdateSchedule(ctx, &schedule); err != nil {
+			return produced, err
+		}
+	}
+
+	return produced, createErr
+}
+
+// resolve completes the reminder when an entry falls within its grace window
+// and marks it missed once the window has passed. It reports whether the
+// status changed.
+func resolve(r *Reminder, history []measurement.Measurement, now time.Time) bool {
+	if r.Status != StatusPending {
+		return false
+	}
+
+	for _, m := range history {
+		ts := m.GetTimestamp()
+		if r.Covers(ts) && !ts.After(now) {
+			measurementID := m.GetID()
+			r.Status = StatusCompleted
+			r.MeasurementID = &measurementID
+			r.ResolvedAt = &now
+			r.UpdatedAt = now
+			return true
+		}
+	}
+
+	if now.After(r.ExpiresAt()) {
+		r.Status = StatusMissed
+		r.ResolvedAt = &now
+		r.UpdatedAt = now
+		return true
+	}
+
+	return false
+}
+
+func (r *RunResult) add(reminder *Reminder) {
+	switch reminder.Status {
+	case StatusPending:
+		r.Due = append(r.Due, reminder)
+	case StatusCompleted:
+		r.Completed = append(r.Completed, reminder)
+	case StatusMissed:
+		r.Missed = append(r.Missed, reminder)
+	}
+}
+
+func validateSchedule(schedule *Schedule) error {
+	var spacing time.Duration
+	switch schedule.Kind {
+	case KindDaily:
+		if schedule.Hour < 0 || schedule.Hour > 23 || schedule.Minute < 0 || schedule.Minute > 59 {
+			return ErrInvalidTime
+		}
+		seen := make(map[time.Weekday]bool, len(schedule.Weekdays))
+		for _, weekday := range schedule.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday || seen[weekday] {
+				return ErrInvalidWeekdays
+			}
+			seen[weekday] = true
+		}
+		spacing = 24 * time.Hour
+	case KindInterval:
+		if schedule.Interval < MinInterval {
+			return ErrInvalidInterval
+		}
+		spacing = schedule.Interval
+	default:
+		return ErrInvalidKind
+	}
+
+	// Grace windows of neighbouring reminders must not overlap, or one entry
+	// would complete two reminders.
+	if schedule.Grace <= 0 || 2*schedule.Grace >= spacing {
+		return ErrInvalidGrace
+	}
+
+	return nil
+}
@@ -0,0 +1,302 @@
+package reminder_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/reminder"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func run(t *testing.T, clock *fakeClock, reminderService reminder.Service, now time.Time) *reminder.RunResult {
+	t.Helper()
+
+	clock.now = now
+	result, err := reminderService.RunScheduler(context.Background())
+	require.NoError(t, err)
+
+	return result
+}
+
+func utc(day, hour, minute int) time.Time {
+	return time.Date(2025, time.March, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestDailyReminders(t *testing.T) {
+	// March 3rd 2025 is a Monday. 21:00 in Berlin is 20:00 UTC.
+	clock := &fakeClock{now: utc(3, 10, 0)}
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	reminderService := reminder.NewService(
+		reminder.NewInMemoryRepository(), userService, parameterService, measurementService, clock,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+		Timezone:   "Europe/Berlin",
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	_, err := reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+		ParameterID: mood.ID,
+		Kind:        reminder.KindDaily,
+		Hour:        21,
+		Weekdays:    []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	})
+	require.NoError(t, err)
+
+	result := run(t, clock, reminderService, utc(3, 19, 59))
+	assert.Empty(t, result.Due)
+
+	result = run(t, clock, reminderService, utc(3, 20, 30))
+	require.Len(t, result.Due, 1)
+	assert.Equal(t, utc(3, 20, 0), result.Due[0].DueAt.UTC())
+
+	pending, err := reminderService.ListRemindersByUser(ctx, reminder.ListRemindersInput{
+		UserID: mood.UserID,
+		Status: reminder.StatusPending,
+	})
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	_, err = measurementService.CreateMeasurement(ctx, measurement.CreateMeasurementInput{
+		ParameterID: mood.ID,
+		Value:       7.0,
+		Timestamp:   utc(3, 20, 45),
+	})
+	require.NoError(t, err)
+
+	result = run(t, clock, reminderService, utc(3, 21, 0))
+	require.Len(t, result.Completed, 1)
+	assert.NotNil(t, result.Completed[0].MeasurementID)
+	assert.Empty(t, result.Due)
+
+	// Tuesday's reminder passes its grace window without an entry.
+	result = run(t, clock, reminderService, utc(4, 23, 0))
+	assert.Empty(t, result.Due)
+	assert.Len(t, result.Missed, 1)
+
+	// The weekend is skipped.
+	result = run(t, clock, reminderService, utc(9, 23, 0))
+	assert.Len(t, result.Missed, 3)
+
+	missed, err := reminderService.ListRemindersByUser(ctx, reminder.ListRemindersInput{
+		UserID: mood.UserID,
+		Status: reminder.StatusMissed,
+	})
+	require.NoError(t, err)
+	require.Len(t, missed, 4)
+	assert.Equal(t, utc(7, 20, 0), missed[0].DueAt.UTC())
+}
+
+func TestIntervalReminders(t *testing.T) {
+	clock := &fakeClock{now: utc(3, 0, 0)}
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	reminderService := reminder.NewService(
+		reminder.NewInMemoryRepository(), userService, parameterService, measurementService, clock,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	schedule, err := reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+		ParameterID: mood.ID,
+		Kind:        reminder.KindInterval,
+		Interval:    8 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, schedule.Grace)
+
+	result := run(t, clock, reminderService, utc(3, 17, 0))
+	assert.Len(t, result.Missed, 2)
+	require.Len(t, result.Due, 1)
+	assert.Equal(t, utc(3, 16, 0), result.Due[0].DueAt)
+
+	// Running again at the same time changes nothing.
+	result = run(t, clock, reminderService, utc(3, 17, 0))
+	assert.Empty(t, result.Due)
+	assert.Empty(t, result.Missed)
+	assert.Empty(t, result.Completed)
+
+	result = run(t, clock, reminderService, utc(3, 18, 1))
+	assert.Len(t, result.Missed, 1)
+}
+
+func TestRunSchedulerSkipsFailingSchedule(t *testing.T) {
+	clock := &fakeClock{now: utc(3, 0, 0)}
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	reminderService := reminder.NewService(
+		reminder.NewInMemoryRepository(), userService, parameterService, measurementService, clock,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	other, err := userService.CreateUser(ctx, user.CreateUserInput{
+		ExternalID: "5f1f3c1e-2b7d-4a55-9a63-0c7e1f0b8d2a",
+		FirstName:  "Jane",
+		LastName:   "Doe",
+	})
+	require.NoError(t, err)
+
+	sleep, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   other.ID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	for _, p := range []*parameter.Parameter{mood, sleep} {
+		_, err = reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+			ParameterID: p.ID,
+			Kind:        reminder.KindDaily,
+			Hour:        9,
+		})
+		require.NoError(t, err)
+	}
+
+	// The mood schedule's owner is gone, which must not hold up the other
+	// user's reminder.
+	require.NoError(t, userService.DeleteUser(ctx, mood.UserID, ""))
+
+	result := run(t, clock, reminderService, utc(3, 9, 30))
+	require.Len(t, result.Due, 1)
+	assert.Equal(t, sleep.ID, result.Due[0].ParameterID)
+}
+
+func TestCreateScheduleValidation(t *testing.T) {
+	clock := &fakeClock{now: utc(3, 0, 0)}
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	reminderService := reminder.NewService(
+		reminder.NewInMemoryRepository(), userService, parameterService, measurementService, clock,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	_, err := reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+		ParameterID: mood.ID,
+		Kind:        reminder.KindDaily,
+		Hour:        24,
+	})
+	require.ErrorIs(t, err, reminder.ErrInvalidTime)
+
+	_, err = reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+		ParameterID: mood.ID,
+		Kind:        reminder.KindInterval,
+		Interval:    4 * time.Hour,
+		Grace:       3 * time.Hour,
+	})
+	require.ErrorIs(t, err, reminder.ErrInvalidGrace)
+
+	_, err = reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+		ParameterID: mood.ID,
+		Kind:        reminder.KindInterval,
+		Interval:    30 * time.Minute,
+	})
+	require.ErrorIs(t, err, reminder.ErrInvalidInterval)
+}
+
+func TestDeleteUserAndParameterDeleteSchedules(t *testing.T) {
+	clock := &fakeClock{now: utc(3, 0, 0)}
+	userService := user.NewService(user.NewInMemoryRepository())
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	reminderService := reminder.NewService(
+		reminder.NewInMemoryRepository(), userService, parameterService, measurementService, clock,
+	)
+	owner := domaintest.CreateUser(t, userService, user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+	})
+	mood := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   owner.ID,
+		Name:     "Mood",
+		DataType: parameter.DataTypeFloat,
+	})
+	ctx := context.Background()
+
+	sleep, err := parameterService.CreateParameter(ctx, parameter.CreateParameterInput{
+		UserID:   mood.UserID,
+		Name:     "Sleep",
+		DataType: parameter.DataTypeFloat,
+	})
+	require.NoError(t, err)
+
+	schedules := make([]*reminder.Schedule, 0, 2)
+	for _, p := range []*parameter.Parameter{mood, sleep} {
+		schedule, createErr := reminderService.CreateSchedule(ctx, reminder.CreateScheduleInput{
+			ParameterID: p.ID,
+			Kind:        reminder.KindDaily,
+			Hour:        9,
+		})
+		require.NoError(t, createErr)
+		schedules = append(schedules, schedule)
+	}
+
+	deletingParameterService := reminder.NewParameterService(parameterService, reminderService)
+	require.NoError(t, deletingParameterService.DeleteParameter(ctx, sleep.ID, ""))
+
+	_, err = reminderService.GetScheduleByID(ctx, schedules[1].ID)
+	require.ErrorIs(t, err, reminder.ErrScheduleNotFound)
+	_, err = reminderService.GetScheduleByID(ctx, schedules[0].ID)
+	require.NoError(t, err)
+
+	deletingUserService := reminder.NewUserService(userService, reminderService)
+	require.NoError(t, deletingUserService.DeleteUser(ctx, mood.UserID, ""))
+
+	_, err = reminderService.GetScheduleByID(ctx, schedules[0].ID)
+	require.ErrorIs(t, err, reminder.ErrScheduleNotFound)
+}
@@ -0,0 +1,35 @@
+package reminder
+
+import (
+	"context"
+	"log"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/google/uuid"
+)
+
+// UserService deletes reminder schedules along with their user.
+type UserService struct {
+	user.Service
+	reminderService Service
+}
+
+func NewUserService(userService user.Service, reminderService Service) user.Service {
+	return &UserService{
+		Service:         userService,
+		reminderService: reminderService,
+	}
+}
+
+// DeleteUser deletes the user and then their schedules.
+func (s *UserService) DeleteUser(ctx context.Context, id uuid.UUID, ifMatch string) error {
+	if err := s.Service.DeleteUser(ctx, id, ifMatch); err != nil {
+		return err
+	}
+
+	if err := s.reminderService.DeleteSchedulesByUser(ctx, id); err != nil {
+		log.Printf("failed to delete reminder schedules for user %s: %v", id, err)
+	}
+
+	return nil
+}
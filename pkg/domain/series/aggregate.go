@@ -0,0 +1,106 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+)
+
+// Aggregate groups measurements into calendar days in the given location and
+// reduces each day to a single value. The result is sorted by date.
+func Aggregate(measurements []measurement.Measurement, agg Aggregation, loc *time.Location) ([]Point, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	byDay := make(map[time.Time][]float64)
+	for _, m := range measurements {
+		value, ok := numericValue(m)
+		if !ok {
+			continue
+		}
+
+		day := startOfDay(m.GetTimestamp(), loc)
+		byDay[day] = append(byDay[day], value)
+	}
+
+	points := make([]Point, 0, len(byDay))
+	for day, values := range byDay {
+		value, err := reduce(values, agg)
+		if err != nil {
+			return nil, err
+		}
+
+		points = append(points, Point{
+			Date:  day,
+			Value: value,
+			Count: len(values),
+		})
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].Date.Before(points[j].Date)
+	})
+
+	return points, nil
+}
+
+func reduce(values []float64, agg Aggregation) (float64, error) {
+	switch agg {
+	case AggregationMean, "":
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values)), nil
+	case AggregationSum:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum, nil
+	case AggregationMin:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = min(result, v)
+		}
+		return result, nil
+	case AggregationMax:
+		result := values[0]
+		for _, v := range values[1:] {
+			result = max(result, v)
+		}
+		return result, nil
+	case AggregationCount:
+		return float64(len(values)), nil
+	default:
+		return 0, fmt.Errorf("unsupported aggregation: %s", agg)
+	}
+}
+
+func numericValue(m measurement.Measurement) (float64, bool) {
+	switch v := m.(type) {
+	case *measurement.FloatMeasurement:
+		return v.GetValue(), true
+	case *measurement.BooleanMeasurement:
+		// Booleans count as 1 and 0, so a daily mean is the share of true
+		// answers and they can be correlated like any numeric parameter.
+		if v.GetValue() {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+func nextDay(t time.Time) time.Time {
+	return t.AddDate(0, 0, 1)
+}
@@ -0,0 +1,29 @@
+package series
+
+// Align pairs two daily series by date. Only days present in both series are
+// kept, so gaps should be filled beforehand if they are not meant to be dropped.
+func Align(x, y []Point) []AlignedPoint {
+	aligned := []AlignedPoint{}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i].Date.Equal(y[j].Date):
+			aligned = append(aligned, AlignedPoint{
+				Date:     x[i].Date,
+				X:        x[i].Value,
+				Y:        y[j].Value,
+				XImputed: x[i].Imputed,
+				YImputed: y[j].Imputed,
+			})
+			i++
+			j++
+		case x[i].Date.Before(y[j].Date):
+			i++
+		default:
+			j++
+		}
+	}
+
+	return aligned
+}
@@ -0,0 +1,70 @@
+package series
+
+import (
+	"fmt"
+	"time"
+)
+
+// FillGaps inserts a point for every missing calendar day between the first and
+// the last observed day according to the given options. Inserted points are
+// marked as imputed.
+func FillGaps(points []Point, opts GapOptions) ([]Point, error) {
+	switch opts.Strategy {
+	case GapStrategyDrop, "":
+		return points, nil
+	case GapStrategyCarryForward, GapStrategyLinear, GapStrategyZero:
+	default:
+		return nil, fmt.Errorf("unsupported gap strategy: %s", opts.Strategy)
+	}
+
+	if opts.MaxGap < 0 {
+		return nil, fmt.Errorf("max gap must not be negative: %d", opts.MaxGap)
+	}
+
+	if len(points) < 2 {
+		return points, nil
+	}
+
+	filled := make([]Point, 0, len(points))
+	for i, current := range points {
+		if i > 0 {
+			filled = append(filled, fillBetween(points[i-1], current, opts)...)
+		}
+		filled = append(filled, current)
+	}
+
+	return filled, nil
+}
+
+func fillBetween(prev, next Point, opts GapOptions) []Point {
+	var missing []time.Time
+	for day := nextDay(prev.Date); day.Before(next.Date); day = nextDay(day) {
+		missing = append(missing, day)
+	}
+
+	if len(missing) == 0 || (opts.MaxGap > 0 && len(missing) > opts.MaxGap) {
+		return nil
+	}
+
+	steps := float64(len(missing) + 1)
+	points := make([]Point, 0, len(missing))
+	for i, day := range missing {
+		var value float64
+		switch opts.Strategy {
+		case GapStrategyCarryForward:
+			value = prev.Value
+		case GapStrategyLinear:
+			value = prev.Value + (next.Value-prev.Value)*float64(i+1)/steps
+		case GapStrategyZero, GapStrategyDrop:
+			value = 0
+		}
+
+		points = append(points, Point{
+			Date:    day,
+			Value:   value,
+			Imputed: true,
+		})
+	}
+
+	return points
+}
@@ -0,0 +1,142 @@
+package series
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Aggregation string
+
+const (
+	AggregationMean  Aggregation = "mean"
+	AggregationSum   Aggregation = "sum"
+	AggregationMin   Aggregation = "min"
+	AggregationMax   Aggregation = "max"
+	AggregationCount Aggregation = "count"
+)
+
+type GapStrategy string
+
+const (
+	// GapStrategyDrop leaves missing days out of the series.
+	GapStrategyDrop GapStrategy = "drop"
+	// GapStrategyCarryForward repeats the last observed value.
+	GapStrategyCarryForward GapStrategy = "locf"
+	// GapStrategyLinear interpolates between the surrounding observed values.
+	GapStrategyLinear GapStrategy = "linear"
+	// GapStrategyZero fills missing days with zero, which suits count-like parameters.
+	GapStrategyZero GapStrategy = "zero"
+)
+
+type GapOptions struct {
+	Strategy GapStrategy
+	// MaxGap is the longest run of missing days that is still filled.
+	// Longer gaps are left empty. Zero means no limit.
+	MaxGap int
+}
+
+const dateLayout = "2006-01-02"
+
+// Period is a range of calendar dates, End inclusive. Only the year, month
+// and day of Start and End are used.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains reports whether the calendar date of t, in t's own location,
+// falls within the period.
+func (p Period) Contains(t time.Time) bool {
+	date := t.Format(dateLayout)
+	return date >= p.Start.Format(dateLayout) && date <= p.End.Format(dateLayout)
+}
+
+type Point struct {
+	Date    time.Time
+	Value   float64
+	Count   int
+	Imputed bool
+}
+
+type Series struct {
+	ParameterID uuid.UUID
+	Aggregation Aggregation
+	Points      []Point
+}
+
+func (s *Series) ImputedDates() []time.Time {
+	dates := []time.Time{}
+	for _, p := range s.Points {
+		if p.Imputed {
+			dates = append(dates, p.Date)
+		}
+	}
+
+	return dates
+}
+
+type AlignedPoint struct {
+	Date     time.Time
+	X        float64
+	Y        float64
+	XImputed bool
+	YImputed bool
+}
+
+type AlignedSeries struct {
+	XParameterID uuid.UUID
+	YParameterID uuid.UUID
+	Points       []AlignedPoint
+}
+
+func (s *AlignedSeries) ImputedDates() []time.Time {
+	dates := []time.Time{}
+	for _, p := range s.Points {
+		if p.XImputed || p.YImputed {
+			dates = append(dates, p.Date)
+		}
+	}
+
+	return dates
+}
+
+type SmoothingOptions struct {
+	// Window is the number of points in the simple moving average.
+	Window int
+	// Alpha is the smoothing factor of the exponential moving average.
+	Alpha float64
+	// Span is the fraction of points used by each local LOESS fit.
+	Span float64
+}
+
+const (
+	DefaultWindow = 7
+	DefaultAlpha  = 0.3
+	DefaultSpan   = 0.3
+)
+
+type SmoothedPoint struct {
+	Point
+	MovingAverage            float64
+	ExponentialMovingAverage float64
+	Loess                    float64
+}
+
+type SmoothedSeries struct {
+	ParameterID uuid.UUID
+	Aggregation Aggregation
+	Smoothing   SmoothingOptions
+	Points      []SmoothedPoint
+}
+
+func (s *SmoothedSeries) ImputedDates() []time.Time {
+	dates := []time.Time{}
+	for _, p := range s.Points {
+		if p.Imputed {
+			dates = append(dates, p.Date)
+		}
+	}
+
+	return dates
+}
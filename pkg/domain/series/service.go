@@ -0,0 +1,47 @@
+package series
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Service interface {
+	GetDailySeries(ctx context.Context, input GetDailySeriesInput) (*Series, error)
+	AlignSeries(ctx context.Context, input AlignSeriesInput) (*AlignedSeries, error)
+	GetSmoothedSeries(ctx context.Context, input GetSmoothedSeriesInput) (*SmoothedSeries, error)
+}
+
+type GetDailySeriesInput struct {
+	ParameterID uuid.UUID
+	Aggregation Aggregation
+	Gaps        GapOptions
+	Location    *time.Location
+	// From and To bound the measurement timestamps, both inclusive. Nil
+	// leaves that side open.
+	From *time.Time
+	To   *time.Time
+	// ExcludeOutliers drops measurements whose outlier flag was confirmed.
+	ExcludeOutliers bool
+	// Exclude drops the days within these periods. Gap filling does not fill
+	// them back in.
+	Exclude []Period
+}
+
+type AlignSeriesInput struct {
+	XParameterID    uuid.UUID
+	YParameterID    uuid.UUID
+	Aggregation     Aggregation
+	Gaps            GapOptions
+	Location        *time.Location
+	From            *time.Time
+	To              *time.Time
+	ExcludeOutliers bool
+	Exclude         []Period
+}
+
+type GetSmoothedSeriesInput struct {
+	GetDailySeriesInput
+	Smoothing SmoothingOptions
+}
@@ -0,0 +1,330 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/formula"
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidWindow = errors.New("moving average window must be positive")
+	ErrInvalidAlpha  = errors.New("exponential moving average alpha must be in (0, 1]")
+	ErrInvalidSpan   = errors.New("loess span must be in (0, 1]")
+)
+
+type ServiceImpl struct {
+	parameterService   parameter.Service
+	measurementService measurement.Service
+	outlierService     outlier.Service
+}
+
+func NewService(
+	parameterService parameter.Service,
+	measurementService measurement.Service,
+	outlierService outlier.Service,
+) Service {
+	return &ServiceImpl{
+		parameterService:   parameterService,
+		measurementService: measurementService,
+		outlierService:     outlierService,
+	}
+}
+
+func (s *ServiceImpl) GetDailySeries(ctx context.Context, input GetDailySeriesInput) (*Series, error) {
+	seriesParameter, err := s.parameterService.GetParameterByID(ctx, input.ParameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregation := input.Aggregation
+	if aggregation == "" {
+		aggregation = AggregationMean
+	}
+
+	var points []Point
+	if seriesParameter.IsDerived() {
+		points, err = s.derivedPoints(ctx, seriesParameter, input, aggregation)
+	} else {
+		points, err = s.storedPoints(ctx, seriesParameter, input, aggregation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Excluded days are dropped before filling so they do not anchor the
+	// imputed values, and again after so filling does not bring them back.
+	points, err = FillGaps(withoutPeriods(points, input.Exclude), input.Gaps)
+	if err != nil {
+		return nil, err
+	}
+	points = withoutPeriods(points, input.Exclude)
+
+	return &Series{
+		ParameterID: seriesParameter.ID,
+		Aggregation: aggregation,
+		Points:      points,
+	}, nil
+}
+
+func (s *ServiceImpl) storedPoints(
+	ctx context.Context,
+	seriesParameter *parameter.Parameter,
+	input GetDailySeriesInput,
+	aggregation Aggregation,
+) ([]Point, error) {
+	listed, err := s.measurementService.ListMeasurementsByParameter(
+		ctx,
+		seriesParameter.ID,
+		measurement.ListOptions{Filter: measurement.Filter{From: input.From, To: input.To}},
+		pagination.Page{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	measurements := listed.Items
+
+	if input.ExcludeOutliers {
+		measurements, err = s.withoutConfirmedOutliers(ctx, seriesParameter.ID, measurements)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return Aggregate(measurements, aggregation, input.Location)
+}
+
+// derivedPoints evaluates a derived parameter's formula over the observed
+// daily series of the parameters it references. The aggregation applies to
+// the inputs, so a derived "intake - burned" with sum aggregation is the
+// difference of the daily totals. Gap filling applies to the result only.
+func (s *ServiceImpl) derivedPoints(
+	ctx context.Context,
+	seriesParameter *parameter.Parameter,
+	input GetDailySeriesInput,
+	aggregation Aggregation,
+) ([]Point, error) {
+	expr, err := formula.Parse(seriesParameter.Formula)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make(map[uuid.UUID]map[time.Time]Point)
+	days := make(map[time.Time]bool)
+	for _, id := range expr.References() {
+		inputSeries, inputErr := s.GetDailySeries(ctx, GetDailySeriesInput{
+			ParameterID:     id,
+			Aggregation:     aggregation,
+			Location:        input.Location,
+			From:            input.From,
+			To:              input.To,
+			ExcludeOutliers: input.ExcludeOutliers,
+		})
+		if inputErr != nil {
+			return nil, inputErr
+		}
+
+		byDay := make(map[time.Time]Point, len(inputSeries.Points))
+		for _, p := range inputSeries.Points {
+			byDay[p.Date] = p
+			days[p.Date] = true
+		}
+		inputs[id] = byDay
+	}
+
+	dates := make([]time.Time, 0, len(days))
+	for day := range days {
+		dates = append(dates, day)
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	values := make(map[uuid.UUID][]float64, len(inputs))
+	counts := make([]int, len(dates))
+	for id, byDay := range inputs {
+		column := make([]float64, len(dates))
+		for i, day := range dates {
+			p, ok := byDay[day]
+			if !ok {
+				column[i] = math.NaN()
+				continue
+			}
+			column[i] = p.Value
+			counts[i] += p.Count
+		}
+		values[id] = column
+	}
+
+	points := []Point{}
+	for i, value := range expr.Evaluate(dates, values) {
+		if math.IsNaN(value) {
+			continue
+		}
+
+		points = append(points, Point{
+			Date:  dates[i],
+			Value: value,
+			Count: counts[i],
+		})
+	}
+
+	return points, nil
+}
+
+func (s *ServiceImpl) AlignSeries(ctx context.Context, input AlignSeriesInput) (*AlignedSeries, error) {
+	x, err := s.GetDailySeries(ctx, GetDailySeriesInput{
+		ParameterID:     input.XParameterID,
+		Aggregation:     input.Aggregation,
+		Gaps:            input.Gaps,
+		Location:        input.Location,
+		From:            input.From,
+		To:              input.To,
+		ExcludeOutliers: input.ExcludeOutliers,
+		Exclude:         input.Exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	y, err := s.GetDailySeries(ctx, GetDailySeriesInput{
+		ParameterID:     input.YParameterID,
+		Aggregation:     input.Aggregation,
+		Gaps:            input.Gaps,
+		Location:        input.Location,
+		From:            input.From,
+		To:              input.To,
+		ExcludeOutliers: input.ExcludeOutliers,
+		Exclude:         input.Exclude,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AlignedSeries{
+		XParameterID: x.ParameterID,
+		YParameterID: y.ParameterID,
+		Points:       Align(x.Points, y.Points),
+	}, nil
+}
+
+func (s *ServiceImpl) GetSmoothedSeries(ctx context.Context, input GetSmoothedSeriesInput) (*SmoothedSeries, error) {
+	smoothing, err := withSmoothingDefaults(input.Smoothing)
+	if err != nil {
+		return nil, err
+	}
+
+	dailySeries, err := s.GetDailySeries(ctx, input.GetDailySeriesInput)
+	if err != nil {
+		return nil, err
+	}
+
+	dates := make([]time.Time, len(dailySeries.Points))
+	values := make([]float64, len(dailySeries.Points))
+	for i, p := range dailySeries.Points {
+		dates[i] = p.Date
+		values[i] = p.Value
+	}
+
+	movingAverage := MovingAverage(values, smoothing.Window)
+	exponentialMovingAverage := ExponentialMovingAverage(values, smoothing.Alpha)
+	loess := Loess(dates, values, smoothing.Span)
+
+	points := make([]SmoothedPoint, len(dailySeries.Points))
+	for i, p := range dailySeries.Points {
+		points[i] = SmoothedPoint{
+			Point:                    p,
+			MovingAverage:            movingAverage[i],
+			ExponentialMovingAverage: exponentialMovingAverage[i],
+			Loess:                    loess[i],
+		}
+	}
+
+	return &SmoothedSeries{
+		ParameterID: dailySeries.ParameterID,
+		Aggregation: dailySeries.Aggregation,
+		Smoothing:   smoothing,
+		Points:      points,
+	}, nil
+}
+
+func (s *ServiceImpl) withoutConfirmedOutliers(
+	ctx context.Context,
+	parameterID uuid.UUID,
+	measurements []measurement.Measurement,
+) ([]measurement.Measurement, error) {
+	flags, err := s.outlierService.ListFlagsByParameter(ctx, parameterID)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed := make(map[uuid.UUID]bool, len(flags))
+	for _, flag := range flags {
+		if flag.Status == outlier.StatusConfirmed {
+			confirmed[flag.MeasurementID] = true
+		}
+	}
+
+	filtered := make([]measurement.Measurement, 0, len(measurements))
+	for _, m := range measurements {
+		if !confirmed[m.GetID()] {
+			filtered = append(filtered, m)
+		}
+	}
+
+	return filtered, nil
+}
+
+func withSmoothingDefaults(opts SmoothingOptions) (SmoothingOptions, error) {
+	if opts.Window == 0 {
+		opts.Window = DefaultWindow
+	}
+	if opts.Alpha == 0 {
+		opts.Alpha = DefaultAlpha
+	}
+	if opts.Span == 0 {
+		opts.Span = DefaultSpan
+	}
+
+	if opts.Window < 0 {
+		return opts, ErrInvalidWindow
+	}
+	if opts.Alpha < 0 || opts.Alpha > 1 {
+		return opts, ErrInvalidAlpha
+	}
+	if opts.Span < 0 || opts.Span > 1 {
+		return opts, ErrInvalidSpan
+	}
+
+	return opts, nil
+}
+
+func withoutPeriods(points []Point, periods []Period) []Point {
+	if len(periods) == 0 {
+		return points
+	}
+
+	kept := make([]Point, 0, len(points))
+	for _, p := range points {
+		excluded := false
+		for _, period := range periods {
+			if period.Contains(p.Date) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept
+}
@@ -0,0 +1,337 @@
+package series_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/domaintest"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/measurement"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/outlier"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/parameter"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/series"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logValues(t *testing.T, measurementService measurement.Service, parameterID uuid.UUID, values map[int]float64) {
+	t.Helper()
+
+	start := time.Date(2025, time.March, 1, 8, 0, 0, 0, time.UTC)
+	for day, value := range values {
+		domaintest.LogValue(t, measurementService, parameterID, start.AddDate(0, 0, day), value)
+	}
+}
+
+func values(points []series.Point) []float64 {
+	result := make([]float64, 0, len(points))
+	for _, p := range points {
+		result = append(result, p.Value)
+	}
+
+	return result
+}
+
+func TestGetDailySeries_GapStrategies(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 10, 3: 40, 4: 50})
+
+	tests := []struct {
+		name     string
+		gaps     series.GapOptions
+		expected []float64
+		imputed  int
+	}{
+		{"Drop", series.GapOptions{Strategy: series.GapStrategyDrop}, []float64{10, 40, 50}, 0},
+		{"Carry forward", series.GapOptions{Strategy: series.GapStrategyCarryForward}, []float64{10, 10, 10, 40, 50}, 2},
+		{"Linear", series.GapOptions{Strategy: series.GapStrategyLinear}, []float64{10, 20, 30, 40, 50}, 2},
+		{"Zero", series.GapOptions{Strategy: series.GapStrategyZero}, []float64{10, 0, 0, 40, 50}, 2},
+		{"Max gap exceeded", series.GapOptions{Strategy: series.GapStrategyLinear, MaxGap: 1}, []float64{10, 40, 50}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+				ParameterID: param.ID,
+				Gaps:        tt.gaps,
+			})
+			require.NoError(t, err)
+			assert.InDeltaSlice(t, tt.expected, values(result.Points), 0.0001)
+			assert.Len(t, result.ImputedDates(), tt.imputed)
+		})
+	}
+}
+
+func TestGetDailySeries_InvalidGapStrategy(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 10, 2: 30})
+
+	_, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+		ParameterID: param.ID,
+		Gaps:        series.GapOptions{Strategy: "spline"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported gap strategy")
+}
+
+func TestAlignSeries_FillsGapsBeforeAligning(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	userID := uuid.New()
+	x := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	y := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, x.ID, map[int]float64{0: 1, 1: 2, 2: 3})
+	logValues(t, measurementService, y.ID, map[int]float64{0: 10, 2: 30})
+
+	dropped, err := seriesService.AlignSeries(context.Background(), series.AlignSeriesInput{
+		XParameterID: x.ID,
+		YParameterID: y.ID,
+	})
+	require.NoError(t, err)
+	assert.Len(t, dropped.Points, 2)
+
+	filled, err := seriesService.AlignSeries(context.Background(), series.AlignSeriesInput{
+		XParameterID: x.ID,
+		YParameterID: y.ID,
+		Gaps:         series.GapOptions{Strategy: series.GapStrategyLinear},
+	})
+	require.NoError(t, err)
+	require.Len(t, filled.Points, 3)
+	assert.InDelta(t, 20.0, filled.Points[1].Y, 0.0001)
+	assert.True(t, filled.Points[1].YImputed)
+	assert.False(t, filled.Points[1].XImputed)
+	assert.Len(t, filled.ImputedDates(), 1)
+}
+
+func TestGetDailySeries_ExcludeConfirmedOutliers(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 72.1, 1: 72.4, 2: 720, 3: 72.0, 4: 71.8, 5: 72.2})
+
+	flags, err := outlierService.DetectOutliers(context.Background(), outlier.DetectOutliersInput{
+		ParameterID: param.ID,
+		Method:      outlier.MethodMAD,
+	})
+	require.NoError(t, err)
+	require.Len(t, flags, 1)
+
+	_, err = outlierService.ReviewFlag(context.Background(), outlier.ReviewFlagInput{
+		ID:     flags[0].ID,
+		Status: outlier.StatusConfirmed,
+	})
+	require.NoError(t, err)
+
+	result, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+		ParameterID:     param.ID,
+		Gaps:            series.GapOptions{Strategy: series.GapStrategyLinear},
+		ExcludeOutliers: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Points, 6)
+	assert.True(t, result.Points[2].Imputed)
+	assert.InDelta(t, 72.2, result.Points[2].Value, 0.0001)
+}
+
+func TestGetSmoothedSeries(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 1, 1: 2, 2: 3, 3: 4, 4: 5, 6: 7})
+
+	result, err := seriesService.GetSmoothedSeries(context.Background(), series.GetSmoothedSeriesInput{
+		GetDailySeriesInput: series.GetDailySeriesInput{ParameterID: param.ID},
+		Smoothing:           series.SmoothingOptions{Window: 3, Alpha: 0.5, Span: 0.5},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Points, 6)
+
+	expectedMovingAverage := []float64{1, 1.5, 2, 3, 4, 16.0 / 3}
+	expectedEMA := []float64{1, 1.5, 2.25, 3.125, 4.0625, 5.53125}
+	for i, p := range result.Points {
+		assert.InDelta(t, expectedMovingAverage[i], p.MovingAverage, 1e-9)
+		assert.InDelta(t, expectedEMA[i], p.ExponentialMovingAverage, 1e-9)
+		// A linear trend is reproduced exactly by local linear fits, even across the dropped day.
+		assert.InDelta(t, p.Value, p.Loess, 1e-6)
+	}
+}
+
+func TestGetSmoothedSeries_InvalidOptions(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+
+	_, err := seriesService.GetSmoothedSeries(context.Background(), series.GetSmoothedSeriesInput{
+		GetDailySeriesInput: series.GetDailySeriesInput{ParameterID: param.ID},
+		Smoothing:           series.SmoothingOptions{Alpha: 1.5},
+	})
+	require.ErrorIs(t, err, series.ErrInvalidAlpha)
+}
+
+func TestGetDailySeries_DerivedParameter(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	userID := uuid.New()
+	intake := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	burned := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, intake.ID, map[int]float64{0: 2000, 1: 2500, 2: 1800})
+	logValues(t, measurementService, burned.ID, map[int]float64{0: 500, 2: 300, 3: 400})
+
+	net, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Net calories",
+		DataType: parameter.DataTypeFloat,
+		Formula:  "{" + intake.ID.String() + "} - {" + burned.ID.String() + "}",
+	})
+	require.NoError(t, err)
+
+	dailySeries, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+		ParameterID: net.ID,
+		Aggregation: series.AggregationSum,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1500, 1500}, values(dailySeries.Points))
+	assert.Equal(t, 2, dailySeries.Points[0].Count)
+
+	// Derived parameters can themselves be referenced and correlated.
+	weekly, err := parameterService.CreateParameter(context.Background(), parameter.CreateParameterInput{
+		UserID:   userID,
+		Name:     "Weekly net calories",
+		DataType: parameter.DataTypeFloat,
+		Formula:  "rolling_sum({" + net.ID.String() + "}, 7)",
+	})
+	require.NoError(t, err)
+
+	aligned, err := seriesService.AlignSeries(context.Background(), series.AlignSeriesInput{
+		XParameterID: weekly.ID,
+		YParameterID: intake.ID,
+		Aggregation:  series.AggregationSum,
+	})
+	require.NoError(t, err)
+	require.Len(t, aligned.Points, 2)
+	assert.InDelta(t, 1500.0, aligned.Points[0].X, 1e-9)
+	assert.InDelta(t, 3000.0, aligned.Points[1].X, 1e-9)
+	assert.InDelta(t, 1800.0, aligned.Points[1].Y, 1e-9)
+}
+
+func TestGetDailySeries_ExcludePeriods(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 10, 1: 99, 3: 40, 5: 60})
+
+	result, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+		ParameterID: param.ID,
+		Gaps:        series.GapOptions{Strategy: series.GapStrategyLinear},
+		Exclude: []series.Period{{
+			Start: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		}},
+	})
+	require.NoError(t, err)
+
+	// The excluded March 2 is neither kept nor filled back in, and its value
+	// does not anchor the interpolation of March 3.
+	dates := make([]int, 0, len(result.Points))
+	for _, p := range result.Points {
+		dates = append(dates, p.Date.Day())
+	}
+	assert.Equal(t, []int{1, 3, 4, 5, 6}, dates)
+	assert.InDeltaSlice(t, []float64{10, 30, 40, 50, 60}, values(result.Points), 0.0001)
+	assert.Len(t, result.ImputedDates(), 2)
+}
+
+func TestGetDailySeries_Range(t *testing.T) {
+	parameterService := parameter.NewService(parameter.NewInMemoryRepository())
+	measurementService := measurement.NewService(measurement.NewInMemoryRepository(), parameterService)
+	outlierService := outlier.NewService(outlier.NewInMemoryRepository(), parameterService, measurementService)
+	seriesService := series.NewService(parameterService, measurementService, outlierService)
+	param := domaintest.CreateParameter(t, parameterService, parameter.CreateParameterInput{
+		UserID:   uuid.New(),
+		Name:     "Weight",
+		DataType: parameter.DataTypeFloat,
+		Unit:     "kg",
+	})
+	logValues(t, measurementService, param.ID, map[int]float64{0: 1, 1: 2, 2: 3, 3: 4})
+
+	from := time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 3, 23, 59, 59, 0, time.UTC)
+	result, err := seriesService.GetDailySeries(context.Background(), series.GetDailySeriesInput{
+		ParameterID: param.ID,
+		From:        &from,
+		To:          &to,
+	})
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{2, 3}, values(result.Points), 0.0001)
+}
@@ -0,0 +1,120 @@
+package series
+
+import (
+	"math"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+)
+
+const hoursPerDay = 24
+
+// MovingAverage returns the trailing simple moving average. The first
+// window-1 values average over the points available so far.
+func MovingAverage(values []float64, window int) []float64 {
+	result := make([]float64, len(values))
+
+	sum := 0.0
+	for i, v := range values {
+		sum += v
+		if i >= window {
+			sum -= values[i-window]
+		}
+		result[i] = sum / float64(min(i+1, window))
+	}
+
+	return result
+}
+
+// ExponentialMovingAverage returns the EMA seeded with the first value.
+func ExponentialMovingAverage(values []float64, alpha float64) []float64 {
+	result := make([]float64, len(values))
+
+	for i, v := range values {
+		if i == 0 {
+			result[i] = v
+			continue
+		}
+		result[i] = alpha*v + (1-alpha)*result[i-1]
+	}
+
+	return result
+}
+
+// Loess fits a locally weighted linear regression at every point. Span is the
+// fraction of points used for each local fit. Distances are measured in days,
+// so dropped days do not distort the fit.
+func Loess(dates []time.Time, values []float64, span float64) []float64 {
+	n := len(values)
+	result := make([]float64, n)
+	if n == 0 {
+		return result
+	}
+
+	xs := make([]float64, n)
+	for i, d := range dates {
+		xs[i] = d.Sub(dates[0]).Hours() / hoursPerDay
+	}
+
+	neighbours := max(int(math.Ceil(span*float64(n))), min(n, 2))
+	distances := make([]float64, n)
+	for i := range n {
+		for j := range n {
+			distances[j] = math.Abs(xs[j] - xs[i])
+		}
+
+		bandwidth := kthSmallest(distances, neighbours)
+		result[i] = localLinearFit(xs, values, xs[i], bandwidth)
+	}
+
+	return result
+}
+
+func localLinearFit(xs, ys []float64, x0, bandwidth float64) float64 {
+	var sw, swx, swy, swxx, swxy float64
+	for i, x := range xs {
+		w := 1.0
+		if bandwidth > 0 {
+			w = tricube(math.Abs(x-x0) / bandwidth)
+		}
+		if w == 0 {
+			continue
+		}
+		sw += w
+		swx += w * x
+		swy += w * ys[i]
+		swxx += w * x * x
+		swxy += w * x * ys[i]
+	}
+
+	if sw == 0 {
+		return math.NaN()
+	}
+
+	denominator := sw*swxx - swx*swx
+	if math.Abs(denominator) < 1e-12 {
+		return swy / sw
+	}
+
+	slope := (sw*swxy - swx*swy) / denominator
+	intercept := (swy - slope*swx) / sw
+
+	return intercept + slope*x0
+}
+
+func tricube(u float64) float64 {
+	if u >= 1 {
+		return 0
+	}
+	t := 1 - u*u*u
+
+	return t * t * t
+}
+
+// kthSmallest returns the k-th smallest distance slightly widened so that the
+// k-th neighbour itself still receives a positive weight.
+func kthSmallest(distances []float64, k int) float64 {
+	const widening = 1.000001
+
+	return stats.Sorted(distances)[k-1] * widening
+}
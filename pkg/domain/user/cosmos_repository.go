@@ -3,12 +3,13 @@ package user
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/dim2k2006/correlateapp-be/pkg/domain/internal/cosmosutil"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
@@ -48,10 +49,11 @@ func (r *CosmosUserRepository) CreateUser(ctx context.Context, user *User) (*Use
 
 	pk := azcosmos.NewPartitionKeyString(user.ID.String())
 
-	_, err = r.container.CreateItem(ctx, pk, userJSON, nil)
+	resp, err := r.container.CreateItem(ctx, pk, userJSON, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user in Cosmos DB: %w", err)
 	}
+	user.ETag = string(resp.ETag)
 
 	return user, nil
 }
@@ -112,54 +114,75 @@ func (r *CosmosUserRepository) UpdateUser(ctx context.Context, user *User) (*Use
 
 	pk := azcosmos.NewPartitionKeyString(user.ID.String())
 
-	_, err = r.container.ReplaceItem(ctx, pk, user.ID.String(), userJSON, nil)
+	resp, err := r.container.ReplaceItem(ctx, pk, user.ID.String(), userJSON, cosmosutil.IfMatchOptions(user.ETag))
 	if err != nil {
+		if cosmosutil.HasStatus(err, http.StatusPreconditionFailed) {
+			return nil, ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update user in Cosmos DB: %w", err)
 	}
+	user.ETag = string(resp.ETag)
 
 	return user, nil
 }
 
-func (r *CosmosUserRepository) DeleteUser(ctx context.Context, id uuid.UUID) error {
+func (r *CosmosUserRepository) DeleteUser(ctx context.Context, id uuid.UUID, ifMatch string) error {
 	pk := azcosmos.NewPartitionKeyString(id.String())
 
-	_, err := r.container.DeleteItem(ctx, pk, id.String(), nil)
+	_, err := r.container.DeleteItem(ctx, pk, id.String(), cosmosutil.IfMatchOptions(ifMatch))
 	if err != nil {
-		var responseErr *azcore.ResponseError
-		errors.As(err, &responseErr)
-		panic(responseErr)
+		if cosmosutil.HasStatus(err, http.StatusPreconditionFailed) {
+			return ErrVersionConflict
+		}
+		if cosmosutil.HasStatus(err, http.StatusNotFound) {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("failed to delete user from Cosmos DB: %w", err)
 	}
 
 	return nil
 }
 
 type CosmosUser struct {
-	ID         uuid.UUID `json:"id"`
-	ExternalID string    `json:"externalId"`
-	FirstName  string    `json:"firstName"`
-	LastName   string    `json:"lastName"`
-	CreatedAt  time.Time `json:"createdAt"`
-	UpdatedAt  time.Time `json:"updatedAt"`
+	ID             uuid.UUID                  `json:"id"`
+	ExternalID     string                     `json:"externalId"`
+	FirstName      string                     `json:"firstName"`
+	LastName       string                     `json:"lastName"`
+	Timezone       string                     `json:"timezone,omitempty"`
+	DayStartHour   int                        `json:"dayStartHour,omitempty"`
+	PreferredUnits map[units.Dimension]string `json:"preferredUnits,omitempty"`
+	CreatedAt      time.Time                  `json:"createdAt"`
+	UpdatedAt      time.Time                  `json:"updatedAt"`
+	// ETag is the system _etag property. It is only read; Cosmos DB sets it
+	// on every write.
+	ETag string `json:"_etag,omitempty"`
 }
 
 func NewCosmosUser(u *User) *CosmosUser {
 	return &CosmosUser{
-		ID:         u.ID,
-		ExternalID: u.ExternalID,
-		FirstName:  u.FirstName,
-		LastName:   u.LastName,
-		CreatedAt:  u.CreatedAt,
-		UpdatedAt:  u.UpdatedAt,
+		ID:             u.ID,
+		ExternalID:     u.ExternalID,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		Timezone:       u.Timezone,
+		DayStartHour:   u.DayStartHour,
+		PreferredUnits: u.PreferredUnits,
+		CreatedAt:      u.CreatedAt,
+		UpdatedAt:      u.UpdatedAt,
 	}
 }
 
 func NewUser(cu *CosmosUser) *User {
 	return &User{
-		ID:         cu.ID,
-		ExternalID: cu.ExternalID,
-		FirstName:  cu.FirstName,
-		LastName:   cu.LastName,
-		CreatedAt:  cu.CreatedAt,
-		UpdatedAt:  cu.UpdatedAt,
+		ID:             cu.ID,
+		ExternalID:     cu.ExternalID,
+		FirstName:      cu.FirstName,
+		LastName:       cu.LastName,
+		Timezone:       cu.Timezone,
+		DayStartHour:   cu.DayStartHour,
+		PreferredUnits: cu.PreferredUnits,
+		CreatedAt:      cu.CreatedAt,
+		UpdatedAt:      cu.UpdatedAt,
+		ETag:           cu.ETag,
 	}
 }
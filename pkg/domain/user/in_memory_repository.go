@@ -2,19 +2,24 @@ package user
 
 import (
 	"context"
+	"strconv"
 	"sync"
 
 	"github.com/google/uuid"
 )
 
+// InMemoryRepository hands out copies of the stored users, and counts the
+// writes of each one to stand in for Cosmos DB ETags.
 type InMemoryRepository struct {
-	mu    sync.RWMutex
-	users map[uuid.UUID]*User
+	mu       sync.RWMutex
+	users    map[uuid.UUID]*User
+	versions map[uuid.UUID]int
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
-		users: make(map[uuid.UUID]*User),
+		users:    make(map[uuid.UUID]*User),
+		versions: make(map[uuid.UUID]int),
 	}
 }
 
@@ -22,7 +27,7 @@ func (r *InMemoryRepository) CreateUser(_ context.Context, user *User) (*User, e
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.users[user.ID] = user
+	r.store(user)
 
 	return user, nil
 }
@@ -36,7 +41,9 @@ func (r *InMemoryRepository) GetUserByID(_ context.Context, id uuid.UUID) (*User
 		return nil, ErrUserNotFound
 	}
 
-	return user, nil
+	copied := *user
+
+	return &copied, nil
 }
 
 func (r *InMemoryRepository) GetUserByExternalID(_ context.Context, externalID string) (*User, error) {
@@ -45,7 +52,8 @@ func (r *InMemoryRepository) GetUserByExternalID(_ context.Context, externalID s
 
 	for _, user := range r.users {
 		if user.ExternalID == externalID {
-			return user, nil
+			copied := *user
+			return &copied, nil
 		}
 	}
 
@@ -56,16 +64,45 @@ func (r *InMemoryRepository) UpdateUser(_ context.Context, user *User) (*User, e
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.users[user.ID] = user
+	if _, ok := r.users[user.ID]; !ok {
+		return nil, ErrUserNotFound
+	}
+	if user.ETag != "" && user.ETag != r.etag(user.ID) {
+		return nil, ErrVersionConflict
+	}
+
+	r.store(user)
 
 	return user, nil
 }
 
-func (r *InMemoryRepository) DeleteUser(_ context.Context, id uuid.UUID) error {
+func (r *InMemoryRepository) DeleteUser(_ context.Context, id uuid.UUID, ifMatch string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if _, ok := r.users[id]; !ok {
+		return ErrUserNotFound
+	}
+	if ifMatch != "" && ifMatch != r.etag(id) {
+		return ErrVersionConflict
+	}
+
 	delete(r.users, id)
+	delete(r.versions, id)
 
 	return nil
 }
+
+// store saves a copy of the user under a new version and sets the user's
+// ETag to it. The caller holds the lock.
+func (r *InMemoryRepository) store(user *User) {
+	r.versions[user.ID]++
+	user.ETag = r.etag(user.ID)
+
+	stored := *user
+	r.users[user.ID] = &stored
+}
+
+func (r *InMemoryRepository) etag(id uuid.UUID) string {
+	return strconv.Quote(strconv.Itoa(r.versions[id]))
+}
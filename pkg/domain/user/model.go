@@ -3,6 +3,7 @@ package user
 import (
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
@@ -11,6 +12,47 @@ type User struct {
 	ExternalID string
 	FirstName  string
 	LastName   string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	// Timezone is an IANA time zone name. Empty means UTC.
+	Timezone string
+	// DayStartHour is the local hour at which the user's day begins, so that
+	// late-night entries can count towards the previous day.
+	DayStartHour int
+	// PreferredUnits maps a dimension to the unit symbol values of that
+	// dimension are shown in.
+	PreferredUnits map[units.Dimension]string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// ETag identifies the stored version of the user and changes on every
+	// write. Updates are only written over the version they were read from.
+	ETag string
+}
+
+// Location returns the user's time zone, falling back to UTC.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
+
+// Day returns midnight of the user's day that t falls into, honouring the
+// user's time zone and day start hour.
+func (u *User) Day(t time.Time) time.Time {
+	loc := u.Location()
+	local := t.In(loc).Add(-time.Duration(u.DayStartHour) * time.Hour)
+
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+// PreferredUnit returns the unit the user wants values of a dimension shown
+// in, if they set one.
+func (u *User) PreferredUnit(dimension units.Dimension) (string, bool) {
+	symbol, ok := u.PreferredUnits[dimension]
+	return symbol, ok
 }
@@ -8,13 +8,19 @@ import (
 )
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound    = errors.New("user not found")
+	ErrVersionConflict = errors.New("user was changed since it was read")
 )
 
 type Repository interface {
 	CreateUser(ctx context.Context, user *User) (*User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetUserByExternalID(ctx context.Context, externalID string) (*User, error)
+	// UpdateUser writes the user over the stored version with the user's
+	// ETag, failing with ErrVersionConflict when that is no longer current.
+	// The returned user carries the new ETag.
 	UpdateUser(ctx context.Context, user *User) (*User, error)
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// DeleteUser deletes the user. A non-empty ifMatch must be the current
+	// ETag, otherwise it fails with ErrVersionConflict.
+	DeleteUser(ctx context.Context, id uuid.UUID, ifMatch string) error
 }
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
@@ -11,17 +12,28 @@ type Service interface {
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetUserByExternalID(ctx context.Context, externalID string) (*User, error)
 	UpdateUser(ctx context.Context, input UpdateUserInput) (*User, error)
-	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// DeleteUser deletes the user. A non-empty ifMatch must be the user's
+	// current ETag.
+	DeleteUser(ctx context.Context, id uuid.UUID, ifMatch string) error
 }
 
 type CreateUserInput struct {
-	ExternalID string
-	FirstName  string
-	LastName   string
+	ExternalID     string
+	FirstName      string
+	LastName       string
+	Timezone       string
+	DayStartHour   int
+	PreferredUnits map[units.Dimension]string
 }
 
 type UpdateUserInput struct {
-	ID        uuid.UUID
-	FirstName *string
-	LastName  *string
+	ID             uuid.UUID
+	FirstName      *string
+	LastName       *string
+	Timezone       *string
+	DayStartHour   *int
+	PreferredUnits *map[units.Dimension]string
+	// IfMatch, when not empty, is the ETag the client last read. The update
+	// fails with ErrVersionConflict if the user has changed since.
+	IfMatch string
 }
@@ -3,8 +3,10 @@ package user
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 )
 
@@ -13,7 +15,10 @@ type serviceImpl struct {
 }
 
 var (
-	ErrDuplicateExternalID = errors.New("duplicate external ID")
+	ErrDuplicateExternalID  = errors.New("duplicate external ID")
+	ErrInvalidTimezone      = errors.New("invalid timezone")
+	ErrInvalidDayStartHour  = errors.New("day start hour must be between 0 and 23")
+	ErrInvalidPreferredUnit = errors.New("invalid preferred unit")
 )
 
 func NewService(repo Repository) Service {
@@ -23,6 +28,15 @@ func NewService(repo Repository) Service {
 }
 
 func (s *serviceImpl) CreateUser(ctx context.Context, input CreateUserInput) (*User, error) {
+	if err := validateDayBoundary(input.Timezone, input.DayStartHour); err != nil {
+		return nil, err
+	}
+
+	preferredUnits, err := normalizePreferredUnits(input.PreferredUnits)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetUserByExternalID(ctx, input.ExternalID)
 	if err != nil && !errors.Is(err, ErrUserNotFound) {
 		return nil, err
@@ -33,12 +47,15 @@ func (s *serviceImpl) CreateUser(ctx context.Context, input CreateUserInput) (*U
 	}
 
 	newUser := &User{
-		ID:         uuid.New(),
-		ExternalID: input.ExternalID,
-		FirstName:  input.FirstName,
-		LastName:   input.LastName,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:             uuid.New(),
+		ExternalID:     input.ExternalID,
+		FirstName:      input.FirstName,
+		LastName:       input.LastName,
+		Timezone:       input.Timezone,
+		DayStartHour:   input.DayStartHour,
+		PreferredUnits: preferredUnits,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
 	}
 
 	createdUser, err := s.repo.CreateUser(ctx, newUser)
@@ -73,12 +90,37 @@ func (s *serviceImpl) UpdateUser(ctx context.Context, input UpdateUserInput) (*U
 		return nil, err
 	}
 
+	if input.IfMatch != "" && input.IfMatch != user.ETag {
+		return nil, ErrVersionConflict
+	}
+
+	timezone, dayStartHour := user.Timezone, user.DayStartHour
+	if input.Timezone != nil {
+		timezone = *input.Timezone
+	}
+	if input.DayStartHour != nil {
+		dayStartHour = *input.DayStartHour
+	}
+	if err = validateDayBoundary(timezone, dayStartHour); err != nil {
+		return nil, err
+	}
+
+	preferredUnits := user.PreferredUnits
+	if input.PreferredUnits != nil {
+		preferredUnits, err = normalizePreferredUnits(*input.PreferredUnits)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if input.FirstName != nil {
 		user.FirstName = *input.FirstName
 	}
 	if input.LastName != nil {
 		user.LastName = *input.LastName
 	}
+	user.Timezone, user.DayStartHour = timezone, dayStartHour
+	user.PreferredUnits = preferredUnits
 
 	user.UpdatedAt = time.Now()
 
@@ -90,11 +132,43 @@ func (s *serviceImpl) UpdateUser(ctx context.Context, input UpdateUserInput) (*U
 	return updatedUser, nil
 }
 
-func (s *serviceImpl) DeleteUser(ctx context.Context, id uuid.UUID) error {
-	err := s.repo.DeleteUser(ctx, id)
+func (s *serviceImpl) DeleteUser(ctx context.Context, id uuid.UUID, ifMatch string) error {
+	err := s.repo.DeleteUser(ctx, id, ifMatch)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func validateDayBoundary(timezone string, dayStartHour int) error {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return ErrInvalidTimezone
+		}
+	}
+
+	if dayStartHour < 0 || dayStartHour > 23 {
+		return ErrInvalidDayStartHour
+	}
+
+	return nil
+}
+
+// normalizePreferredUnits checks that every preferred unit belongs to the
+// dimension it is set for and replaces it with its canonical symbol.
+func normalizePreferredUnits(preferred map[units.Dimension]string) (map[units.Dimension]string, error) {
+	normalized := make(map[units.Dimension]string, len(preferred))
+	for dimension, symbol := range preferred {
+		u, err := units.Lookup(symbol)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPreferredUnit, err)
+		}
+		if u.Dimension != dimension {
+			return nil, fmt.Errorf("%w: %s is not a unit of %s", ErrInvalidPreferredUnit, u.Symbol, dimension)
+		}
+		normalized[dimension] = u.Symbol
+	}
+
+	return normalized, nil
+}
@@ -3,8 +3,10 @@ package user_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/dim2k2006/correlateapp-be/pkg/domain/user"
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -44,3 +46,91 @@ func TestService_CreateUser_DuplicateExternalID(t *testing.T) {
 	_, err = svc.CreateUser(context.Background(), input)
 	require.Error(t, err)
 }
+
+func TestService_CreateUser_InvalidDayBoundary(t *testing.T) {
+	svc := user.NewService(user.NewInMemoryRepository())
+
+	_, err := svc.CreateUser(context.Background(), user.CreateUserInput{
+		ExternalID: "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:  "John",
+		LastName:   "Doe",
+		Timezone:   "Mars/Olympus_Mons",
+	})
+	require.ErrorIs(t, err, user.ErrInvalidTimezone)
+
+	_, err = svc.CreateUser(context.Background(), user.CreateUserInput{
+		ExternalID:   "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:    "John",
+		LastName:     "Doe",
+		DayStartHour: 24,
+	})
+	require.ErrorIs(t, err, user.ErrInvalidDayStartHour)
+}
+
+func TestUser_Day(t *testing.T) {
+	u := &user.User{Timezone: "Europe/Berlin", DayStartHour: 4}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	// 01:30 UTC on March 11th is 02:30 in Berlin, before the day starts.
+	day := u.Day(time.Date(2025, time.March, 11, 1, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2025, time.March, 10, 0, 0, 0, 0, berlin), day)
+
+	day = u.Day(time.Date(2025, time.March, 11, 3, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2025, time.March, 11, 0, 0, 0, 0, berlin), day)
+}
+
+func TestService_PreferredUnits(t *testing.T) {
+	svc := user.NewService(user.NewInMemoryRepository())
+
+	createdUser, err := svc.CreateUser(context.Background(), user.CreateUserInput{
+		ExternalID:     "b6541d6a-7987-42ce-b124-018667a76bd5",
+		FirstName:      "John",
+		LastName:       "Doe",
+		PreferredUnits: map[units.Dimension]string{units.DimensionMass: "pounds"},
+	})
+	require.NoError(t, err)
+
+	preferred, ok := createdUser.PreferredUnit(units.DimensionMass)
+	require.True(t, ok)
+	assert.Equal(t, "lb", preferred)
+
+	invalid := map[units.Dimension]string{units.DimensionTemperature: "kg"}
+	_, err = svc.UpdateUser(context.Background(), user.UpdateUserInput{ID: createdUser.ID, PreferredUnits: &invalid})
+	require.ErrorIs(t, err, user.ErrInvalidPreferredUnit)
+}
+
+func TestService_UpdateUser_IfMatch(t *testing.T) {
+	ctx := context.Background()
+	svc := user.NewService(user.NewInMemoryRepository())
+
+	createdUser, err := svc.CreateUser(ctx, user.CreateUserInput{ExternalID: "ext-etag", FirstName: "John"})
+	require.NoError(t, err)
+	require.NotEmpty(t, createdUser.ETag)
+	staleETag := createdUser.ETag
+
+	name := "Jane"
+	updated, err := svc.UpdateUser(ctx, user.UpdateUserInput{ID: createdUser.ID, FirstName: &name, IfMatch: staleETag})
+	require.NoError(t, err)
+	assert.NotEqual(t, staleETag, updated.ETag)
+
+	fetched, err := svc.GetUserByID(ctx, createdUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, updated.ETag, fetched.ETag)
+
+	// A second device still holding the first version must not overwrite.
+	other := "Jim"
+	_, err = svc.UpdateUser(ctx, user.UpdateUserInput{ID: createdUser.ID, FirstName: &other, IfMatch: staleETag})
+	require.ErrorIs(t, err, user.ErrVersionConflict)
+
+	fetched, err = svc.GetUserByID(ctx, createdUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane", fetched.FirstName)
+
+	require.ErrorIs(t, svc.DeleteUser(ctx, createdUser.ID, staleETag), user.ErrVersionConflict)
+	require.NoError(t, svc.DeleteUser(ctx, createdUser.ID, fetched.ETag))
+
+	_, err = svc.GetUserByID(ctx, createdUser.ID)
+	require.ErrorIs(t, err, user.ErrUserNotFound)
+	require.ErrorIs(t, svc.DeleteUser(ctx, createdUser.ID, ""), user.ErrUserNotFound)
+}
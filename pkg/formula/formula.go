@@ -0,0 +1,212 @@
+// Package formula parses and evaluates expressions that define derived
+// parameters in terms of other parameters.
+//
+// Parameters are referenced by ID in braces, e.g.
+//
+//	{weight-id} / ({height-id} / 100) ^ 2
+//
+// Expressions support numbers, + - * / ^, unary minus, parentheses and the
+// functions listed in Functions.
+package formula
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Expression struct {
+	source string
+	root   node
+}
+
+func Parse(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+// References returns the distinct parameter IDs used by the expression in
+// order of first appearance.
+func (e *Expression) References() []uuid.UUID {
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	e.root.walk(func(n node) {
+		if ref, ok := n.(*referenceNode); ok && !seen[ref.id] {
+			seen[ref.id] = true
+			ids = append(ids, ref.id)
+		}
+	})
+
+	return ids
+}
+
+// Evaluate computes the expression for each of the given days. Inputs hold
+// one value per day for every referenced parameter, with NaN marking days
+// without a value. Days on which the result is undefined are NaN as well.
+func (e *Expression) Evaluate(dates []time.Time, inputs map[uuid.UUID][]float64) []float64 {
+	return e.root.evaluate(&context{dates: dates, inputs: inputs})
+}
+
+// Functions returns the names of the functions available in expressions.
+func Functions() []string {
+	names := make([]string, 0, len(functions()))
+	for name := range functions() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+type context struct {
+	dates  []time.Time
+	inputs map[uuid.UUID][]float64
+}
+
+func (c *context) constant(value float64) []float64 {
+	values := make([]float64, len(c.dates))
+	for i := range values {
+		values[i] = value
+	}
+
+	return values
+}
+
+type node interface {
+	evaluate(c *context) []float64
+	walk(visit func(node))
+}
+
+type numberNode struct {
+	value float64
+}
+
+func (n *numberNode) evaluate(c *context) []float64 {
+	return c.constant(n.value)
+}
+
+func (n *numberNode) walk(visit func(node)) {
+	visit(n)
+}
+
+type referenceNode struct {
+	id uuid.UUID
+}
+
+func (n *referenceNode) evaluate(c *context) []float64 {
+	values, ok := c.inputs[n.id]
+	if !ok {
+		return c.constant(math.NaN())
+	}
+
+	return append([]float64(nil), values...)
+}
+
+func (n *referenceNode) walk(visit func(node)) {
+	visit(n)
+}
+
+type negateNode struct {
+	operand node
+}
+
+func (n *negateNode) evaluate(c *context) []float64 {
+	values := n.operand.evaluate(c)
+	for i := range values {
+		values[i] = -values[i]
+	}
+
+	return values
+}
+
+func (n *negateNode) walk(visit func(node)) {
+	visit(n)
+	n.operand.walk(visit)
+}
+
+type binaryNode struct {
+	operator string
+	left     node
+	right    node
+}
+
+func (n *binaryNode) evaluate(c *context) []float64 {
+	left := n.left.evaluate(c)
+	right := n.right.evaluate(c)
+
+	values := make([]float64, len(left))
+	for i := range values {
+		values[i] = defined(apply(n.operator, left[i], right[i]))
+	}
+
+	return values
+}
+
+func (n *binaryNode) walk(visit func(node)) {
+	visit(n)
+	n.left.walk(visit)
+	n.right.walk(visit)
+}
+
+func apply(operator string, a, b float64) float64 {
+	switch operator {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	case "/":
+		return a / b
+	case "^":
+		return math.Pow(a, b)
+	default:
+		return math.NaN()
+	}
+}
+
+// defined maps infinities, e.g. from a division by zero, to NaN so that such
+// days are treated as missing.
+func defined(value float64) float64 {
+	if math.IsInf(value, 0) {
+		return math.NaN()
+	}
+
+	return value
+}
+
+type callNode struct {
+	fn   function
+	args []node
+}
+
+func (n *callNode) evaluate(c *context) []float64 {
+	return n.fn.evaluate(c, n.args)
+}
+
+func (n *callNode) walk(visit func(node)) {
+	visit(n)
+	for _, arg := range n.args {
+		arg.walk(visit)
+	}
+}
@@ -0,0 +1,101 @@
+package formula_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/formula"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func days(n int) []time.Time {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	dates := make([]time.Time, n)
+	for i := range dates {
+		dates[i] = start.AddDate(0, 0, i)
+	}
+
+	return dates
+}
+
+func TestParse_References(t *testing.T) {
+	intake, burned := uuid.New(), uuid.New()
+
+	expr, err := formula.Parse("{" + intake.String() + "} - { " + burned.String() + " } + {" + intake.String() + "}")
+	require.NoError(t, err)
+
+	assert.Equal(t, []uuid.UUID{intake, burned}, expr.References())
+}
+
+func TestParse_Errors(t *testing.T) {
+	id := uuid.New().String()
+
+	for _, source := range []string{
+		"",
+		"{" + id,
+		"{not-a-uuid}",
+		"1 +",
+		"(1 + 2",
+		"1 2",
+		"unknown(1)",
+		"abs(1, 2)",
+		"rolling_sum({" + id + "}, 0)",
+		"rolling_sum({" + id + "}, 2.5)",
+		"rolling_mean({" + id + "}, {" + id + "})",
+		"1 # 2",
+	} {
+		_, err := formula.Parse(source)
+		require.ErrorIs(t, err, formula.ErrSyntax, source)
+	}
+}
+
+func TestEvaluate_Precedence(t *testing.T) {
+	expr, err := formula.Parse("-2 ^ 2 + 3 * (4 - 1) / 9 + 2 ^ 3 ^ 2")
+	require.NoError(t, err)
+
+	values := expr.Evaluate(days(1), nil)
+	assert.InDelta(t, -4+1+512, values[0], 1e-9)
+}
+
+func TestEvaluate_BMIWithCarriedForwardHeight(t *testing.T) {
+	weight, height := uuid.New(), uuid.New()
+	nan := math.NaN()
+
+	expr, err := formula.Parse("{" + weight.String() + "} / (locf({" + height.String() + "}) / 100) ^ 2")
+	require.NoError(t, err)
+
+	values := expr.Evaluate(days(4), map[uuid.UUID][]float64{
+		weight: {81, nan, 80, 79},
+		height: {nan, 180, nan, nan},
+	})
+
+	assert.True(t, math.IsNaN(values[0]), "no height logged yet")
+	assert.True(t, math.IsNaN(values[1]), "no weight logged")
+	assert.InDelta(t, 80/(1.8*1.8), values[2], 1e-9)
+	assert.InDelta(t, 79/(1.8*1.8), values[3], 1e-9)
+}
+
+func TestEvaluate_RollingSumUsesCalendarDays(t *testing.T) {
+	steps := uuid.New()
+	dates := []time.Time{days(10)[0], days(10)[1], days(10)[5], days(10)[9]}
+
+	expr, err := formula.Parse("rolling_sum({" + steps.String() + "}, 7)")
+	require.NoError(t, err)
+
+	values := expr.Evaluate(dates, map[uuid.UUID][]float64{steps: {1, 2, 4, 8}})
+	assert.Equal(t, []float64{1, 3, 7, 12}, values)
+}
+
+func TestEvaluate_DivisionByZeroIsMissing(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	expr, err := formula.Parse("{" + a.String() + "} / {" + b.String() + "}")
+	require.NoError(t, err)
+
+	values := expr.Evaluate(days(2), map[uuid.UUID][]float64{a: {1, 6}, b: {0, 3}})
+	assert.True(t, math.IsNaN(values[0]))
+	assert.InDelta(t, 2.0, values[1], 1e-9)
+}
@@ -0,0 +1,131 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+)
+
+type function struct {
+	arity int
+	// windowed functions take a positive whole number of days as their last
+	// argument.
+	windowed bool
+	evaluate func(c *context, args []node) []float64
+}
+
+func functions() map[string]function {
+	return map[string]function{
+		"abs":          elementwise(math.Abs),
+		"sqrt":         elementwise(math.Sqrt),
+		"min":          pairwise(math.Min),
+		"max":          pairwise(math.Max),
+		"locf":         {arity: 1, evaluate: locf},
+		"rolling_sum":  {arity: 2, windowed: true, evaluate: rolling(false)},
+		"rolling_mean": {arity: 2, windowed: true, evaluate: rolling(true)},
+	}
+}
+
+func (f function) check(name string, args []node) error {
+	if len(args) != f.arity {
+		return fmt.Errorf("%s expects %d argument(s), got %d", name, f.arity, len(args))
+	}
+
+	if f.windowed {
+		if _, ok := window(args[len(args)-1]); !ok {
+			return fmt.Errorf("%s expects a positive whole number of days as its last argument", name)
+		}
+	}
+
+	return nil
+}
+
+func window(n node) (int, bool) {
+	number, ok := n.(*numberNode)
+	if !ok || number.value < 1 || number.value != math.Trunc(number.value) {
+		return 0, false
+	}
+
+	return int(number.value), true
+}
+
+func elementwise(fn func(float64) float64) function {
+	return function{
+		arity: 1,
+		evaluate: func(c *context, args []node) []float64 {
+			values := args[0].evaluate(c)
+			for i := range values {
+				values[i] = defined(fn(values[i]))
+			}
+
+			return values
+		},
+	}
+}
+
+func pairwise(fn func(a, b float64) float64) function {
+	return function{
+		arity: 2,
+		evaluate: func(c *context, args []node) []float64 {
+			left := args[0].evaluate(c)
+			right := args[1].evaluate(c)
+			for i := range left {
+				left[i] = fn(left[i], right[i])
+			}
+
+			return left
+		},
+	}
+}
+
+// locf carries the last defined value forward over missing days, which lets
+// rarely logged inputs such as height combine with daily ones.
+func locf(c *context, args []node) []float64 {
+	values := args[0].evaluate(c)
+	last := math.NaN()
+	for i, value := range values {
+		if math.IsNaN(value) {
+			values[i] = last
+		} else {
+			last = value
+		}
+	}
+
+	return values
+}
+
+// rolling sums or averages the defined values within a trailing window of
+// calendar days ending on each day.
+func rolling(mean bool) func(c *context, args []node) []float64 {
+	return func(c *context, args []node) []float64 {
+		values := args[0].evaluate(c)
+		days, _ := window(args[1])
+
+		result := make([]float64, len(values))
+		start := 0
+		for i, date := range c.dates {
+			windowStart := date.AddDate(0, 0, -days+1)
+			for start < i && c.dates[start].Before(windowStart) {
+				start++
+			}
+
+			sum, count := 0.0, 0
+			for j := start; j <= i; j++ {
+				if !math.IsNaN(values[j]) {
+					sum += values[j]
+					count++
+				}
+			}
+
+			switch {
+			case count == 0:
+				result[i] = math.NaN()
+			case mean:
+				result[i] = sum / float64(count)
+			default:
+				result[i] = sum
+			}
+		}
+
+		return result
+	}
+}
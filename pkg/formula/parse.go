@@ -0,0 +1,255 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSyntax = errors.New("formula syntax error")
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenReference
+	tokenIdentifier
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind     tokenKind
+	text     string
+	position int
+}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	runes := []rune(source)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), position: start})
+		case r == '{':
+			start := i
+			for i < len(runes) && runes[i] != '}' {
+				i++
+			}
+			if i == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated reference at position %d", ErrSyntax, start)
+			}
+			tokens = append(tokens, token{
+				kind:     tokenReference,
+				text:     strings.TrimSpace(string(runes[start+1 : i])),
+				position: start,
+			})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, text: string(runes[start:i]), position: start})
+		case strings.ContainsRune("+-*/^", r):
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), position: i})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", position: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", position: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", position: i})
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrSyntax, r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, position: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%w: unexpected end of formula", ErrSyntax)
+	}
+
+	return fmt.Errorf("%w: unexpected %q at position %d", ErrSyntax, t.text, t.position)
+}
+
+// parseExpression handles addition and subtraction.
+func (p *parser) parseExpression() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == tokenOperator && (t.text == "+" || t.text == "-"); t = p.peek() {
+		p.next()
+		right, termErr := p.parseTerm()
+		if termErr != nil {
+			return nil, termErr
+		}
+		left = &binaryNode{operator: t.text, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+// parseTerm handles multiplication and division.
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for t := p.peek(); t.kind == tokenOperator && (t.text == "*" || t.text == "/"); t = p.peek() {
+		p.next()
+		right, unaryErr := p.parseUnary()
+		if unaryErr != nil {
+			return nil, unaryErr
+		}
+		left = &binaryNode{operator: t.text, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if t := p.peek(); t.kind == tokenOperator && t.text == "-" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{operand: operand}, nil
+	}
+
+	return p.parsePower()
+}
+
+// parsePower handles exponentiation, which is right-associative.
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.kind == tokenOperator && t.text == "^" {
+		p.next()
+		exponent, unaryErr := p.parseUnary()
+		if unaryErr != nil {
+			return nil, unaryErr
+		}
+		return &binaryNode{operator: "^", left: base, right: exponent}, nil
+	}
+
+	return base, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrSyntax, t.text, t.position)
+		}
+		return &numberNode{value: value}, nil
+	case tokenReference:
+		id, err := uuid.Parse(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid parameter reference %q at position %d", ErrSyntax, t.text, t.position)
+		}
+		return &referenceNode{id: id}, nil
+	case tokenIdentifier:
+		return p.parseCall(t)
+	case tokenLeftParen:
+		inner, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, p.unexpected(closing)
+		}
+		return inner, nil
+	case tokenEOF, tokenOperator, tokenRightParen, tokenComma:
+		return nil, p.unexpected(t)
+	default:
+		return nil, p.unexpected(t)
+	}
+}
+
+func (p *parser) parseCall(name token) (node, error) {
+	fn, ok := functions()[name.text]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown function %q at position %d", ErrSyntax, name.text, name.position)
+	}
+
+	if open := p.next(); open.kind != tokenLeftParen {
+		return nil, p.unexpected(open)
+	}
+
+	var args []node
+	if p.peek().kind != tokenRightParen {
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if closing := p.next(); closing.kind != tokenRightParen {
+		return nil, p.unexpected(closing)
+	}
+
+	if err := fn.check(name.text, args); err != nil {
+		return nil, fmt.Errorf("%w: %w at position %d", ErrSyntax, err, name.position)
+	}
+
+	return &callNode{fn: fn, args: args}, nil
+}
@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+)
+
+const (
+	// DefaultLimit is the page size used when a client does not ask for one.
+	DefaultLimit = 50
+	// MaxLimit is the largest page a client may ask for.
+	MaxLimit = 500
+)
+
+var (
+	ErrInvalidLimit  = errors.New("page limit must be between 1 and 500")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+// Page selects a slice of a listing. The zero Page selects everything, which
+// is what the services reading whole histories use.
+type Page struct {
+	// Limit is the largest number of items returned. Zero means no limit.
+	Limit int
+	// Cursor is the opaque Next value of the previous page. Empty starts
+	// from the beginning.
+	Cursor string
+}
+
+// Validate checks the limit. Cursors are checked by the repository that
+// issued them.
+func (p Page) Validate() error {
+	if p.Limit < 0 || p.Limit > MaxLimit {
+		return ErrInvalidLimit
+	}
+
+	return nil
+}
+
+// Result is one page of a listing.
+type Result[T any] struct {
+	Items []T
+	// Next is the cursor of the following page, or empty on the last one.
+	Next string
+}
+
+// EncodeCursor wraps a repository position, such as a Cosmos DB continuation
+// token, into a URL-safe cursor.
+func EncodeCursor(position string) string {
+	if position == "" {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(position))
+}
+
+// DecodeCursor returns the repository position wrapped by EncodeCursor.
+func DecodeCursor(cursor string) (string, error) {
+	position, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(position) == 0 {
+		return "", ErrInvalidCursor
+	}
+
+	return string(position), nil
+}
+
+// Slice returns the page of items, which must already be in a stable order.
+// The cursor is an encoded offset, which suits the in-memory repositories.
+func Slice[T any](items []T, page Page) (Result[T], error) {
+	offset, err := page.Offset()
+	if err != nil {
+		return Result[T]{}, err
+	}
+
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := len(items)
+	if page.Limit > 0 && offset+page.Limit < end {
+		end = offset + page.Limit
+	}
+
+	result := Result[T]{Items: items[offset:end]}
+	if end < len(items) {
+		result.Next = EncodeCursor(strconv.Itoa(end))
+	}
+
+	return result, nil
+}
+
+// Offset returns the position an offset cursor, as issued by Slice, points
+// at. An empty cursor points at the first item.
+func (p Page) Offset() (int, error) {
+	if p.Cursor == "" {
+		return 0, nil
+	}
+
+	position, err := DecodeCursor(p.Cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.Atoi(position)
+	if err != nil || offset < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return offset, nil
+}
+
+// SizeHint returns the limit as a Cosmos DB page size hint, or zero to leave
+// the page size to the server.
+func (p Page) SizeHint() int32 {
+	if p.Limit <= 0 || p.Limit > MaxLimit {
+		return 0
+	}
+
+	return int32(p.Limit)
+}
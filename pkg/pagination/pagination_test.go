@@ -0,0 +1,57 @@
+package pagination_test
+
+import (
+	"testing"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlice(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	all, err := pagination.Slice(items, pagination.Page{})
+	require.NoError(t, err)
+	assert.Equal(t, items, all.Items)
+	assert.Empty(t, all.Next)
+
+	first, err := pagination.Slice(items, pagination.Page{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, first.Items)
+	require.NotEmpty(t, first.Next)
+
+	second, err := pagination.Slice(items, pagination.Page{Limit: 2, Cursor: first.Next})
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, second.Items)
+
+	last, err := pagination.Slice(items, pagination.Page{Limit: 2, Cursor: second.Next})
+	require.NoError(t, err)
+	assert.Equal(t, []int{5}, last.Items)
+	assert.Empty(t, last.Next)
+
+	_, err = pagination.Slice(items, pagination.Page{Limit: 2, Cursor: "%%%"})
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
+
+	_, err = pagination.Slice(items, pagination.Page{Limit: 2, Cursor: pagination.EncodeCursor("-1")})
+	require.ErrorIs(t, err, pagination.ErrInvalidCursor)
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	token := `{"token":"+RID:~abc==#RT:1#TRC:2","range":{"min":"","max":"FF"}}`
+
+	cursor := pagination.EncodeCursor(token)
+	assert.NotContains(t, cursor, "+")
+	assert.NotContains(t, cursor, "/")
+
+	decoded, err := pagination.DecodeCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, token, decoded)
+}
+
+func TestPageValidate(t *testing.T) {
+	require.NoError(t, pagination.Page{}.Validate())
+	require.NoError(t, pagination.Page{Limit: pagination.MaxLimit}.Validate())
+	require.ErrorIs(t, pagination.Page{Limit: -1}.Validate(), pagination.ErrInvalidLimit)
+	require.ErrorIs(t, pagination.Page{Limit: pagination.MaxLimit + 1}.Validate(), pagination.ErrInvalidLimit)
+}
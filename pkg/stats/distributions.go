@@ -0,0 +1,141 @@
+package stats
+
+import (
+	"math"
+)
+
+// NormalCDF returns P(Z <= x) for a standard normal variable.
+func NormalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// NormalQuantile returns x such that NormalCDF(x) = p.
+func NormalQuantile(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return math.NaN()
+	}
+
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// TwoSidedNormalPValue returns the two-sided p-value of a z statistic.
+func TwoSidedNormalPValue(z float64) float64 {
+	return 2 * (1 - NormalCDF(math.Abs(z)))
+}
+
+const (
+	betaMaxIterations = 300
+	betaEpsilon       = 3e-14
+	betaMinFloat      = 1e-300
+
+	quantileIterations = 200
+	quantileBound      = 1e6
+)
+
+// RegularizedIncompleteBeta returns I_x(a, b).
+func RegularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lbetaA, _ := math.Lgamma(a)
+	lbetaB, _ := math.Lgamma(b)
+	lbetaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lbetaAB - lbetaA - lbetaB + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+func betaContinuedFraction(x, a, b float64) float64 {
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < betaMinFloat {
+		d = betaMinFloat
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= betaMaxIterations; m++ {
+		mf := float64(m)
+
+		numerator := mf * (b - mf) * x / ((a + 2*mf - 1) * (a + 2*mf))
+		d = 1 + numerator*d
+		if math.Abs(d) < betaMinFloat {
+			d = betaMinFloat
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < betaMinFloat {
+			c = betaMinFloat
+		}
+		d = 1 / d
+		h *= d * c
+
+		numerator = -(a + mf) * (a + b + mf) * x / ((a + 2*mf) * (a + 2*mf + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < betaMinFloat {
+			d = betaMinFloat
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < betaMinFloat {
+			c = betaMinFloat
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < betaEpsilon {
+			break
+		}
+	}
+
+	return h
+}
+
+// StudentTCDF returns P(T <= t) for Student's t distribution.
+func StudentTCDF(t, df float64) float64 {
+	tail := 0.5 * RegularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+
+	return tail
+}
+
+// StudentTQuantile returns t such that StudentTCDF(t, df) = p.
+func StudentTQuantile(p, df float64) float64 {
+	if p <= 0 || p >= 1 || df <= 0 {
+		return math.NaN()
+	}
+
+	return bisect(func(t float64) float64 { return StudentTCDF(t, df) - p }, -quantileBound, quantileBound)
+}
+
+// FCDF returns P(F <= x) for the F distribution with d1 and d2 degrees of freedom.
+func FCDF(x, d1, d2 float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	return RegularizedIncompleteBeta(d1*x/(d1*x+d2), d1/2, d2/2)
+}
+
+// bisect finds a root of an increasing function on [lo, hi].
+func bisect(f func(float64) float64, lo, hi float64) float64 {
+	for range quantileIterations {
+		mid := (lo + hi) / 2
+		if f(mid) < 0 {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2
+}
@@ -0,0 +1,95 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	nelderMeadReflection  = 1.0
+	nelderMeadExpansion   = 2.0
+	nelderMeadContraction = 0.5
+	nelderMeadShrink      = 0.5
+	nelderMeadTolerance   = 1e-10
+)
+
+// Minimize finds a local minimum of f with the Nelder–Mead simplex method,
+// starting from start with an initial simplex of the given step size.
+func Minimize(f func([]float64) float64, start []float64, step float64, maxIterations int) []float64 {
+	n := len(start)
+	if n == 0 {
+		return []float64{}
+	}
+
+	type vertex struct {
+		x     []float64
+		value float64
+	}
+
+	simplex := make([]vertex, n+1)
+	simplex[0] = vertex{x: append([]float64{}, start...), value: f(start)}
+	for i := range n {
+		x := append([]float64{}, start...)
+		x[i] += step
+		simplex[i+1] = vertex{x: x, value: f(x)}
+	}
+
+	// combine returns a + t * (b - a).
+	combine := func(a, b []float64, t float64) []float64 {
+		result := make([]float64, n)
+		for i := range result {
+			result[i] = a[i] + t*(b[i]-a[i])
+		}
+		return result
+	}
+
+	for range maxIterations {
+		sort.Slice(simplex, func(i, j int) bool { return simplex[i].value < simplex[j].value })
+
+		if math.Abs(simplex[n].value-simplex[0].value) < nelderMeadTolerance {
+			break
+		}
+
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += v.x[i] / float64(n)
+			}
+		}
+
+		worst := simplex[n]
+		reflected := combine(centroid, worst.x, -nelderMeadReflection)
+		reflectedValue := f(reflected)
+
+		switch {
+		case reflectedValue < simplex[0].value:
+			expanded := combine(centroid, worst.x, -nelderMeadExpansion)
+			if expandedValue := f(expanded); expandedValue < reflectedValue {
+				simplex[n] = vertex{x: expanded, value: expandedValue}
+			} else {
+				simplex[n] = vertex{x: reflected, value: reflectedValue}
+			}
+		case reflectedValue < simplex[n-1].value:
+			simplex[n] = vertex{x: reflected, value: reflectedValue}
+		default:
+			contracted := combine(centroid, worst.x, nelderMeadContraction)
+			if contractedValue := f(contracted); contractedValue < worst.value {
+				simplex[n] = vertex{x: contracted, value: contractedValue}
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				x := combine(simplex[0].x, simplex[i].x, nelderMeadShrink)
+				simplex[i] = vertex{x: x, value: f(x)}
+			}
+		}
+	}
+
+	sort.Slice(simplex, func(i, j int) bool { return simplex[i].value < simplex[j].value })
+
+	return simplex[0].x
+}
+
+// Logistic maps the real line onto (0, 1).
+func Logistic(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
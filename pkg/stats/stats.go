@@ -0,0 +1,142 @@
+package stats
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// PermutationTolerance keeps permuted statistics that equal the observed one
+// up to floating point noise counted as at least as extreme.
+const PermutationTolerance = 1e-9
+
+func Sum(values []float64) float64 {
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum
+}
+
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	return Sum(values) / float64(len(values))
+}
+
+// Variance returns the sample variance (n - 1 in the denominator).
+func Variance(values []float64) float64 {
+	if len(values) < 2 {
+		return math.NaN()
+	}
+
+	mean := Mean(values)
+	sum := 0.0
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+
+	return sum / float64(len(values)-1)
+}
+
+// StdDev returns the sample standard deviation.
+func StdDev(values []float64) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+func Median(values []float64) float64 {
+	return Quantile(values, 0.5)
+}
+
+// Quantile returns the q-th quantile using linear interpolation between the
+// closest ranks. The input slice is not modified.
+func Quantile(values []float64, q float64) float64 {
+	if len(values) == 0 || q < 0 || q > 1 {
+		return math.NaN()
+	}
+
+	sorted := Sorted(values)
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+// MedianAbsoluteDeviation returns the median of absolute deviations from the median.
+func MedianAbsoluteDeviation(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+
+	median := Median(values)
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		deviations[i] = math.Abs(v - median)
+	}
+
+	return Median(deviations)
+}
+
+// HedgesG is the difference in means, b minus a, divided by the pooled
+// standard deviation, with the usual small-sample correction.
+func HedgesG(a, b []float64) float64 {
+	nA, nB := float64(len(a)), float64(len(b))
+	pooled := math.Sqrt(((nA-1)*Variance(a) + (nB-1)*Variance(b)) / (nA + nB - 2))
+	if pooled == 0 {
+		return 0
+	}
+
+	d := (Mean(b) - Mean(a)) / pooled
+	correction := 1 - 3/(4*(nA+nB)-9)
+
+	return d * correction
+}
+
+// PermutationTest shuffles the labels of a and b between the pooled values
+// and compares the absolute difference in means with the observed one. The
+// p-value is two-sided and includes the observed labelling, so it is never
+// zero.
+func PermutationTest(a, b []float64, permutations int, rng *rand.Rand) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 1
+	}
+
+	values := make([]float64, 0, len(a)+len(b))
+	values = append(values, a...)
+	values = append(values, b...)
+
+	total := Sum(values)
+	nA, nB := float64(len(a)), float64(len(b))
+	difference := func(bSum float64) float64 {
+		return math.Abs(bSum/nB - (total-bSum)/nA)
+	}
+
+	observed := difference(Sum(b))
+
+	extreme := 0
+	for range permutations {
+		rng.Shuffle(len(values), func(i, j int) {
+			values[i], values[j] = values[j], values[i]
+		})
+		if difference(Sum(values[:len(b)])) >= observed-PermutationTolerance {
+			extreme++
+		}
+	}
+
+	return float64(extreme+1) / float64(permutations+1)
+}
+
+func Sorted(values []float64) []float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	return sorted
+}
@@ -0,0 +1,68 @@
+package stats_test
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/stats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescriptive(t *testing.T) {
+	values := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	assert.InDelta(t, 5.0, stats.Mean(values), 1e-9)
+	assert.InDelta(t, 4.571428571, stats.Variance(values), 1e-9)
+	assert.InDelta(t, 4.5, stats.Median(values), 1e-9)
+	assert.InDelta(t, 4.0, stats.Quantile(values, 0.25), 1e-9)
+	assert.InDelta(t, 5.5, stats.Quantile(values, 0.75), 1e-9)
+	assert.InDelta(t, 0.5, stats.MedianAbsoluteDeviation(values), 1e-9)
+}
+
+func TestHedgesG(t *testing.T) {
+	assert.InDelta(t, 2.4, stats.HedgesG([]float64{1, 2, 3}, []float64{4, 5, 6}), 1e-9)
+	assert.InDelta(t, 0.0, stats.HedgesG([]float64{2, 2}, []float64{2, 2}), 1e-9)
+}
+
+func TestPermutationTest(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 1))
+	low := []float64{1, 2, 3, 2, 1, 3, 2, 1, 2, 3}
+	high := []float64{11, 12, 13, 12, 11, 13, 12, 11, 12, 13}
+
+	assert.InDelta(t, 1.0/1000, stats.PermutationTest(low, high, 999, rng), 1e-9)
+	assert.InDelta(t, 1.0, stats.PermutationTest(low, low, 999, rng), 1e-9)
+	assert.InDelta(t, 1.0, stats.PermutationTest(nil, high, 999, rng), 1e-9)
+}
+
+func TestEmptyInput(t *testing.T) {
+	assert.True(t, math.IsNaN(stats.Mean(nil)))
+	assert.True(t, math.IsNaN(stats.Variance([]float64{1})))
+	assert.True(t, math.IsNaN(stats.Quantile(nil, 0.5)))
+}
+
+func TestNormalDistribution(t *testing.T) {
+	assert.InDelta(t, 0.5, stats.NormalCDF(0), 1e-12)
+	assert.InDelta(t, 0.975002105, stats.NormalCDF(1.96), 1e-9)
+	assert.InDelta(t, 1.959963985, stats.NormalQuantile(0.975), 1e-9)
+	assert.InDelta(t, 0.05, stats.TwoSidedNormalPValue(-1.959963985), 1e-9)
+}
+
+func TestStudentTAndF(t *testing.T) {
+	assert.InDelta(t, 0.5, stats.StudentTCDF(0, 5), 1e-12)
+	assert.InDelta(t, 0.975, stats.StudentTCDF(2.570581836, 5), 1e-8)
+	assert.InDelta(t, 2.228138852, stats.StudentTQuantile(0.975, 10), 1e-8)
+	assert.InDelta(t, -2.228138852, stats.StudentTQuantile(0.025, 10), 1e-8)
+	assert.InDelta(t, 0.95, stats.FCDF(3.098391212, 3, 20), 1e-7)
+	assert.InDelta(t, 0.0, stats.FCDF(-1, 3, 20), 1e-12)
+}
+
+func TestMinimize(t *testing.T) {
+	rosenbrock := func(x []float64) float64 {
+		return (1-x[0])*(1-x[0]) + 100*(x[1]-x[0]*x[0])*(x[1]-x[0]*x[0])
+	}
+
+	result := stats.Minimize(rosenbrock, []float64{-1.2, 1}, 0.5, 5000)
+	assert.InDelta(t, 1.0, result[0], 1e-3)
+	assert.InDelta(t, 1.0, result[1], 1e-3)
+}
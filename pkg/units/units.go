@@ -0,0 +1,166 @@
+// Package units is a registry of physical units with conversions between
+// units of the same dimension.
+package units
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrUnknownUnit       = errors.New("unknown unit")
+	ErrUnknownDimension  = errors.New("unknown dimension")
+	ErrIncompatibleUnits = errors.New("incompatible units")
+)
+
+type Dimension string
+
+const (
+	DimensionMass        Dimension = "mass"
+	DimensionLength      Dimension = "length"
+	DimensionTemperature Dimension = "temperature"
+	DimensionVolume      Dimension = "volume"
+	DimensionEnergy      Dimension = "energy"
+	DimensionTime        Dimension = "time"
+)
+
+// Unit converts to the canonical unit of its dimension as
+// canonical = value*factor + offset.
+type Unit struct {
+	Symbol    string
+	Name      string
+	Dimension Dimension
+	factor    float64
+	offset    float64
+	aliases   []string
+}
+
+func (u Unit) toCanonical(value float64) float64 {
+	return value*u.factor + u.offset
+}
+
+func (u Unit) fromCanonical(value float64) float64 {
+	return (value - u.offset) / u.factor
+}
+
+var registry = []Unit{
+	{Symbol: "kg", Name: "kilogram", Dimension: DimensionMass, factor: 1, aliases: []string{"kgs", "kilograms"}},
+	{Symbol: "g", Name: "gram", Dimension: DimensionMass, factor: 1e-3, aliases: []string{"grams"}},
+	{Symbol: "mg", Name: "milligram", Dimension: DimensionMass, factor: 1e-6, aliases: []string{"milligrams"}},
+	{Symbol: "lb", Name: "pound", Dimension: DimensionMass, factor: 0.45359237, aliases: []string{"lbs", "pounds"}},
+	{Symbol: "oz", Name: "ounce", Dimension: DimensionMass, factor: 0.028349523125, aliases: []string{"ounces"}},
+	{Symbol: "st", Name: "stone", Dimension: DimensionMass, factor: 6.35029318, aliases: []string{"stones"}},
+
+	{Symbol: "m", Name: "metre", Dimension: DimensionLength, factor: 1, aliases: []string{"meter", "meters", "metres"}},
+	{Symbol: "km", Name: "kilometre", Dimension: DimensionLength, factor: 1000, aliases: []string{"kilometer", "kilometers"}},
+	{Symbol: "cm", Name: "centimetre", Dimension: DimensionLength, factor: 0.01, aliases: []string{"centimeter", "centimeters"}},
+	{Symbol: "mm", Name: "millimetre", Dimension: DimensionLength, factor: 0.001, aliases: []string{"millimeter", "millimeters"}},
+	{Symbol: "in", Name: "inch", Dimension: DimensionLength, factor: 0.0254, aliases: []string{"inches"}},
+	{Symbol: "ft", Name: "foot", Dimension: DimensionLength, factor: 0.3048, aliases: []string{"feet"}},
+	{Symbol: "mi", Name: "mile", Dimension: DimensionLength, factor: 1609.344, aliases: []string{"miles"}},
+
+	{Symbol: "°C", Name: "degree Celsius", Dimension: DimensionTemperature, factor: 1, aliases: []string{"c", "degc", "celsius"}},
+	{
+		Symbol: "°F", Name: "degree Fahrenheit", Dimension: DimensionTemperature,
+		factor: 5.0 / 9.0, offset: -32 * 5.0 / 9.0, aliases: []string{"f", "degf", "fahrenheit"},
+	},
+	{Symbol: "K", Name: "kelvin", Dimension: DimensionTemperature, factor: 1, offset: -273.15, aliases: []string{"kelvin"}},
+
+	{Symbol: "L", Name: "litre", Dimension: DimensionVolume, factor: 1, aliases: []string{"l", "liter", "liters", "litres"}},
+	{Symbol: "mL", Name: "millilitre", Dimension: DimensionVolume, factor: 1e-3, aliases: []string{"milliliter", "milliliters"}},
+	{Symbol: "fl oz", Name: "US fluid ounce", Dimension: DimensionVolume, factor: 0.0295735295625, aliases: []string{"floz"}},
+	{Symbol: "cup", Name: "US cup", Dimension: DimensionVolume, factor: 0.2365882365, aliases: []string{"cups"}},
+	{Symbol: "gal", Name: "US gallon", Dimension: DimensionVolume, factor: 3.785411784, aliases: []string{"gallon", "gallons"}},
+
+	{Symbol: "kcal", Name: "kilocalorie", Dimension: DimensionEnergy, factor: 1, aliases: []string{"cal", "calories", "kilocalories"}},
+	{Symbol: "kJ", Name: "kilojoule", Dimension: DimensionEnergy, factor: 1 / 4.184, aliases: []string{"kilojoules"}},
+	{Symbol: "J", Name: "joule", Dimension: DimensionEnergy, factor: 1 / 4184.0, aliases: []string{"joules"}},
+	{Symbol: "kWh", Name: "kilowatt-hour", Dimension: DimensionEnergy, factor: 3.6e6 / 4184.0},
+
+	{Symbol: "s", Name: "second", Dimension: DimensionTime, factor: 1, aliases: []string{"sec", "seconds"}},
+	{Symbol: "ms", Name: "millisecond", Dimension: DimensionTime, factor: 1e-3, aliases: []string{"milliseconds"}},
+	{Symbol: "min", Name: "minute", Dimension: DimensionTime, factor: 60, aliases: []string{"minutes"}},
+	{Symbol: "h", Name: "hour", Dimension: DimensionTime, factor: 3600, aliases: []string{"hr", "hrs", "hours"}},
+	{Symbol: "d", Name: "day", Dimension: DimensionTime, factor: 86400, aliases: []string{"days"}},
+}
+
+var bySymbol = func() map[string]Unit {
+	index := make(map[string]Unit)
+	for _, u := range registry {
+		index[strings.ToLower(u.Symbol)] = u
+		index[strings.ToLower(u.Name)] = u
+		for _, alias := range u.aliases {
+			index[alias] = u
+		}
+	}
+
+	return index
+}()
+
+// Lookup finds a unit by symbol, name or common alias, ignoring case.
+func Lookup(symbol string) (Unit, error) {
+	if u, ok := bySymbol[strings.ToLower(strings.TrimSpace(symbol))]; ok {
+		return u, nil
+	}
+
+	return Unit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, symbol)
+}
+
+// Convert converts a value between two units of the same dimension.
+func Convert(value float64, from, to string) (float64, error) {
+	fromUnit, err := Lookup(from)
+	if err != nil {
+		return 0, err
+	}
+
+	toUnit, err := Lookup(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if fromUnit.Dimension != toUnit.Dimension {
+		return 0, fmt.Errorf("%w: cannot convert %s (%s) to %s (%s)",
+			ErrIncompatibleUnits, fromUnit.Symbol, fromUnit.Dimension, toUnit.Symbol, toUnit.Dimension)
+	}
+
+	if fromUnit.Symbol == toUnit.Symbol {
+		return value, nil
+	}
+
+	return toUnit.fromCanonical(fromUnit.toCanonical(value)), nil
+}
+
+// Dimensions returns every known dimension in alphabetical order.
+func Dimensions() []Dimension {
+	seen := make(map[Dimension]bool)
+	dimensions := []Dimension{}
+	for _, u := range registry {
+		if !seen[u.Dimension] {
+			seen[u.Dimension] = true
+			dimensions = append(dimensions, u.Dimension)
+		}
+	}
+	sort.Slice(dimensions, func(i, j int) bool {
+		return dimensions[i] < dimensions[j]
+	})
+
+	return dimensions
+}
+
+// Units returns the units of a dimension in registry order.
+func Units(dimension Dimension) ([]Unit, error) {
+	result := []Unit{}
+	for _, u := range registry {
+		if u.Dimension == dimension {
+			result = append(result, u)
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDimension, dimension)
+	}
+
+	return result, nil
+}
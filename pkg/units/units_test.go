@@ -0,0 +1,70 @@
+package units_test
+
+import (
+	"testing"
+
+	"github.com/dim2k2006/correlateapp-be/pkg/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	for _, symbol := range []string{"kg", "KG", "kilograms", " kg "} {
+		u, err := units.Lookup(symbol)
+		require.NoError(t, err, symbol)
+		assert.Equal(t, "kg", u.Symbol)
+		assert.Equal(t, units.DimensionMass, u.Dimension)
+	}
+
+	u, err := units.Lookup("Celsius")
+	require.NoError(t, err)
+	assert.Equal(t, "°C", u.Symbol)
+
+	_, err = units.Lookup("furlongs per fortnight")
+	require.ErrorIs(t, err, units.ErrUnknownUnit)
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		value    float64
+		from, to string
+		expected float64
+	}{
+		{160, "lb", "kg", 72.5747792},
+		{72.5747792, "kg", "lb", 160},
+		{98.6, "°F", "°C", 37},
+		{37, "°C", "°F", 98.6},
+		{0, "°C", "K", 273.15},
+		{212, "°F", "K", 373.15},
+		{180, "cm", "ft", 5.905511811},
+		{1, "cup", "mL", 236.5882365},
+		{2000, "kcal", "kJ", 8368},
+		{90, "min", "h", 1.5},
+		{5, "kg", "kg", 5},
+	}
+
+	for _, tt := range tests {
+		converted, err := units.Convert(tt.value, tt.from, tt.to)
+		require.NoError(t, err)
+		assert.InDelta(t, tt.expected, converted, 1e-6, "%v %s -> %s", tt.value, tt.from, tt.to)
+	}
+}
+
+func TestConvert_Incompatible(t *testing.T) {
+	_, err := units.Convert(1, "kg", "m")
+	require.ErrorIs(t, err, units.ErrIncompatibleUnits)
+
+	_, err = units.Convert(1, "kg", "bananas")
+	require.ErrorIs(t, err, units.ErrUnknownUnit)
+}
+
+func TestUnits(t *testing.T) {
+	assert.Len(t, units.Dimensions(), 6)
+
+	mass, err := units.Units(units.DimensionMass)
+	require.NoError(t, err)
+	assert.Equal(t, "kg", mass[0].Symbol)
+
+	_, err = units.Units("luminosity")
+	require.ErrorIs(t, err, units.ErrUnknownDimension)
+}